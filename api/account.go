@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/policy"
 	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
@@ -39,14 +40,23 @@ func (server *Server) createAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	key, err := idempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	//* 2. Add account to db
-	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Currency: req.Currency,
-		Balance:  0,
+	arg := db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Currency: req.Currency,
+			Balance:  0,
+		},
+		IdempotencyKey: key,
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	result, err := server.store.CreateAccountTx(ctx, arg)
 	if err != nil {
 		if pqError, ok := err.(*pq.Error); ok {
 			switch pqError.Code.Name() {
@@ -56,10 +66,15 @@ func (server *Server) createAccount(ctx *gin.Context) {
 			}
 		}
 
+		if errors.Is(err, db.ErrIdempotencyKeyReused) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, result.Account)
 
 }
 
@@ -84,7 +99,8 @@ func (server *Server) getAccount(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
+	// Depositors only see their own accounts, bankers can read any account
+	if !policy.CanReadAccount(authPayload, account) {
 		err := errors.New("account does not belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
@@ -125,3 +141,46 @@ func (server *Server) listAccount(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, accounts)
 
 }
+
+// freezeAccount handles POST /accounts/:id/freeze requests
+// Frozen accounts can neither send nor receive money until a banker unfreezes them
+func (server *Server) freezeAccount(ctx *gin.Context) {
+	server.setAccountFrozen(ctx, true)
+}
+
+// unfreezeAccount handles POST /accounts/:id/unfreeze requests
+func (server *Server) unfreezeAccount(ctx *gin.Context) {
+	server.setAccountFrozen(ctx, false)
+}
+
+func (server *Server) setAccountFrozen(ctx *gin.Context, frozen bool) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !policy.CanFreezeAccount(authPayload) {
+		err := errors.New("only bankers can freeze or unfreeze accounts")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.SetAccountFrozenTx(ctx, db.SetAccountFrozenTxParams{
+		AccountID: req.ID,
+		IsFrozen:  frozen,
+		Audit:     auditContext(ctx, authPayload.Username),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result.Account)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// fxQuoteRequest defines the request body for locking an exchange rate
+type fxQuoteRequest struct {
+	FromCurrency string `json:"from_currency" binding:"required,currency"`
+	ToCurrency   string `json:"to_currency" binding:"required,currency,nefield=FromCurrency"`
+}
+
+// createFxQuote handles POST /fx_quotes requests
+// It locks the current rate with the configured spread applied until the quote expires
+// The returned quote id is passed as quote_id to POST /transfers
+func (server *Server) createFxQuote(ctx *gin.Context) {
+	var req fxQuoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rate, err := server.store.GetFxRate(ctx, db.GetFxRateParams{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+	})
+	if err != nil {
+		// No rate has been fetched from the rate provider for this pair yet
+		if err == sql.ErrNoRows {
+			err := errors.New("no exchange rate available for this currency pair")
+			ctx.JSON(http.StatusServiceUnavailable, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	quote, err := server.store.CreateFxQuote(ctx, db.CreateFxQuoteParams{
+		ID:           uuid.New(),
+		Username:     authPayload.Username,
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Rate:         util.ApplySpread(rate.Rate, server.config.FXSpreadBps),
+		SpreadBps:    server.config.FXSpreadBps,
+		ExpiresAt:    time.Now().Add(server.config.FXQuoteDuration),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quote)
+}
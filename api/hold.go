@@ -0,0 +1,246 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/gin-gonic/gin"
+)
+
+// placeHoldRequest defines the expected JSON structure for reserving funds for a payee
+type placeHoldRequest struct {
+	AccountID   int64     `json:"account_id" binding:"required,min=1"`                      // Account the funds are held on
+	ToAccountID int64     `json:"to_account_id" binding:"required,min=1,nefield=AccountID"` // Payee, who can capture or release the hold
+	Amount      int64     `json:"amount" binding:"required,gt=0"`                           // Held amount (must be greater than 0)
+	Currency    string    `json:"currency" binding:"required,currency"`                     // Currency of both accounts
+	ExpiresAt   time.Time `json:"expires_at"`                                               // Optional, defaults to the configured hold duration
+}
+
+// placeHold handles POST /holds requests to reserve funds on an account for a later payment to another account
+// Held funds no longer count towards the available balance until the hold is captured, released or expires
+func (server *Server) placeHold(ctx *gin.Context) {
+	var req placeHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	expiresAt := time.Now().Add(server.config.HoldDuration)
+	if !req.ExpiresAt.IsZero() {
+		if !req.ExpiresAt.After(time.Now()) || req.ExpiresAt.After(time.Now().Add(server.config.HoldMaxDuration)) {
+			err := fmt.Errorf("expires_at must be in the future and within %s", server.config.HoldMaxDuration)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		expiresAt = req.ExpiresAt
+	}
+
+	account, valid := server.validAccount(ctx, req.AccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// Only the owner of the account can promise its funds to someone else
+	if !policy.CanMoveMoney(authPayload, account) {
+		err := errors.New("account does not belong to the authenticated user")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	if !server.requireVerifiedEmail(ctx, authPayload.Username) {
+		return
+	}
+
+	// The hold is captured as a plain transfer, so the payee must hold the same currency
+	if _, valid = server.validAccount(ctx, req.ToAccountID, req.Currency); !valid {
+		return
+	}
+
+	key, err := idempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.PlaceHoldTx(ctx, db.PlaceHoldTxParams{
+		Owner:          authPayload.Username,
+		AccountID:      req.AccountID,
+		ToAccountID:    req.ToAccountID,
+		Amount:         req.Amount,
+		ExpiresAt:      expiresAt,
+		IdempotencyKey: key,
+		Audit:          auditContext(ctx, authPayload.Username),
+	})
+	if err != nil {
+		ctx.JSON(holdErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type holdURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getHold handles GET /holds/:id requests, for the payer, the payee and bankers
+func (server *Server) getHold(ctx *gin.Context) {
+	var uri holdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, payee, ok := server.holdWithPayee(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if !policy.CanReadHold(authPayload, hold, payee) {
+		err := errors.New("hold was neither placed by nor for the authenticated user")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, hold)
+}
+
+// captureHoldRequest is the optional body of a capture, the full hold is captured without it
+type captureHoldRequest struct {
+	Amount int64 `json:"amount" binding:"omitempty,gt=0"` // At most the held amount, the rest of the hold is released
+}
+
+// captureHold handles POST /holds/:id/capture requests to collect a hold as a transfer to the payee
+// Only the payee can capture a hold
+func (server *Server) captureHold(ctx *gin.Context) {
+	var uri holdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req captureHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, payee, ok := server.holdWithPayee(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// Only the payee collects the funds the payer promised them
+	if !policy.CanCaptureHold(authPayload, payee) {
+		err := errors.New("hold is not payable to the authenticated user")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	if !server.requireVerifiedEmail(ctx, authPayload.Username) {
+		return
+	}
+
+	key, err := idempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID:         uri.ID,
+		Amount:         req.Amount,
+		IdempotencyKey: key,
+		Audit:          auditContext(ctx, authPayload.Username),
+	})
+	if err != nil {
+		ctx.JSON(holdErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// releaseHold handles POST /holds/:id/release requests to lift a hold without moving any money
+// The payer cannot take back funds they promised, only the payee or an admin can release a hold
+func (server *Server) releaseHold(ctx *gin.Context) {
+	var uri holdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, payee, ok := server.holdWithPayee(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if !policy.CanReleaseHold(authPayload, payee) {
+		err := errors.New("hold is not payable to the authenticated user")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ReleaseHoldTx(ctx, db.ReleaseHoldTxParams{
+		HoldID: uri.ID,
+		Audit:  auditContext(ctx, authPayload.Username),
+	})
+	if err != nil {
+		ctx.JSON(holdErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// holdWithPayee looks up the hold and the account it is payable to
+// Returns: false if either could not be loaded (with error response sent)
+func (server *Server) holdWithPayee(ctx *gin.Context, holdID int64) (db.Hold, db.Account, bool) {
+	hold, err := server.store.GetHold(ctx, holdID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return hold, db.Account{}, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return hold, db.Account{}, false
+	}
+
+	payee, err := server.store.GetAccount(ctx, hold.ToAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return hold, payee, false
+	}
+
+	return hold, payee, true
+}
+
+// holdErrorStatus maps the errors returned by the hold transactions to an HTTP status code
+func holdErrorStatus(err error) int {
+	switch {
+	// The hold was already captured, released or expired, or the capture is larger than the hold
+	case errors.Is(err, db.ErrHoldNotActive),
+		errors.Is(err, db.ErrHoldExpired),
+		errors.Is(err, db.ErrCaptureTooLarge):
+		return http.StatusUnprocessableEntity
+	}
+
+	return transferErrorStatus(err)
+}
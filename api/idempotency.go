@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
+var ErrIdempotencyKeyTooLong = errors.New("idempotency key must not be longer than 255 characters")
+
+// idempotencyKey reads the optional Idempotency-Key header and scopes it to the authenticated user.
+// It returns nil when the client did not send a key.
+func idempotencyKey(ctx *gin.Context, username string) (*db.IdempotencyParams, error) {
+	key := ctx.GetHeader(idempotencyKeyHeader)
+
+	if len(key) == 0 {
+		return nil, nil
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, ErrIdempotencyKeyTooLong
+	}
+
+	return &db.IdempotencyParams{
+		Username: username,
+		Key:      key,
+	}, nil
+}
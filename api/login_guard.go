@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/worker"
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+)
+
+// ErrIncorrectCredentials is returned for an unknown username and for a wrong password alike,
+// so the login does not reveal which usernames exist
+var ErrIncorrectCredentials = errors.New("incorrect username or password")
+
+// ErrTooManyLoginAttempts is returned while the username or the client IP has to wait before logging in again
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+
+// loginAttempt identifies a login attempt by the username and the IP of the client
+func loginAttempt(ctx *gin.Context, username string) lockout.Attempt {
+	return lockout.Attempt{
+		Username: username,
+		ClientIP: ctx.ClientIP(),
+	}
+}
+
+// checkLoginAttempt rejects the attempt with 429 Too Many Requests while the username or the client IP has to wait
+// Returns: true if the attempt may go ahead, false otherwise (with error response sent)
+func (server *Server) checkLoginAttempt(ctx *gin.Context, attempt lockout.Attempt) bool {
+	retryAfter, err := server.loginGuard.Check(ctx, attempt)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if retryAfter > 0 {
+		//? clients are told in whole seconds, rounded up so they never retry too early
+		ctx.Header("Retry-After", fmt.Sprint(int64(math.Ceil(retryAfter.Seconds()))))
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(ErrTooManyLoginAttempts))
+		return false
+	}
+
+	return true
+}
+
+// loginFailed records a failed login attempt and sends the given error response
+// userExists tells whether the username belongs to a user, who is emailed once the login gets locked
+func (server *Server) loginFailed(ctx *gin.Context, attempt lockout.Attempt, userExists bool, code int, failure error) {
+	result, err := server.loginGuard.Failure(ctx, attempt)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if result.LockedOut && userExists {
+		taskPayload := &worker.PayloadSendLockoutEmail{
+			Username:    attempt.Username,
+			LockedUntil: time.Now().Add(result.RetryAfter),
+		}
+
+		//? the lockout is already in place, a failure only loses the notification, so it is just recorded for the logger
+		message, err := worker.OutboxTaskSendLockoutEmail(taskPayload, asynq.MaxRetry(10), asynq.Queue(worker.QueueCritical))
+		if err == nil {
+			_, err = server.store.CreateOutboxMessage(ctx, message)
+		}
+
+		if err != nil {
+			ctx.Error(err)
+		}
+	}
+
+	ctx.JSON(code, errorResponse(failure))
+}
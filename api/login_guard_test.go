@@ -0,0 +1,112 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/VihangaFTW/Go-Backend/worker"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testLockoutPolicy locks a username out after two failures, without any backoff before that
+var testLockoutPolicy = lockout.Policy{
+	FreeAttempts:    1,
+	LockoutAttempts: 2,
+	LockoutDuration: time.Minute,
+	Window:          time.Hour,
+}
+
+func TestLoginUserIncorrectCredentialsAPI(t *testing.T) {
+	user, password := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+
+	wrongPassword := postJSON(t, server, "/users/login", gin.H{"username": user.Username, "password": password + "x"})
+	require.Equal(t, http.StatusUnauthorized, wrongPassword.Code)
+
+	missingUsername := util.RandomOwner()
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(missingUsername)).Times(1).Return(db.User{}, sql.ErrNoRows)
+
+	missingUser := postJSON(t, server, "/users/login", gin.H{"username": missingUsername, "password": password})
+	require.Equal(t, http.StatusUnauthorized, missingUser.Code)
+
+	//! both failures look the same, so the response does not reveal whether the user exists
+	require.Equal(t, wrongPassword.Body.String(), missingUser.Body.String())
+	require.Contains(t, missingUser.Body.String(), ErrIncorrectCredentials.Error())
+}
+
+func TestLoginUserLockoutAPI(t *testing.T) {
+	user, password := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	server := newTestServer(t, store)
+	server.loginGuard = lockout.NewGuardWithPolicies(lockout.NewMemoryCounter(), testLockoutPolicy, lockout.ClientIPPolicy)
+
+	//? only the attempts before the lockout reach the database
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(2).Return(user, nil)
+
+	//? the user is emailed once, when the lockout starts
+	store.EXPECT().
+		CreateOutboxMessage(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, message db.CreateOutboxMessageParams) (db.Outbox, error) {
+			require.Equal(t, worker.TaskSendLockoutEmail, message.TaskType)
+
+			var payload worker.PayloadSendLockoutEmail
+			require.NoError(t, json.Unmarshal(message.Payload, &payload))
+			require.Equal(t, user.Username, payload.Username)
+			require.WithinDuration(t, time.Now().Add(testLockoutPolicy.LockoutDuration), payload.LockedUntil, time.Second)
+			return db.Outbox{}, nil
+		})
+
+	for range testLockoutPolicy.LockoutAttempts {
+		recorder := postJSON(t, server, "/users/login", gin.H{"username": user.Username, "password": password + "x"})
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+
+	//! even the right password is rejected until the lockout ends
+	recorder := postJSON(t, server, "/users/login", gin.H{"username": user.Username, "password": password})
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+	retryAfter, err := strconv.Atoi(recorder.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	require.InDelta(t, testLockoutPolicy.LockoutDuration.Seconds(), retryAfter, 1)
+}
+
+func TestLoginUserLockoutMissingUserAPI(t *testing.T) {
+	username := util.RandomOwner()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	server := newTestServer(t, store)
+	server.loginGuard = lockout.NewGuardWithPolicies(lockout.NewMemoryCounter(), testLockoutPolicy, lockout.ClientIPPolicy)
+
+	//? a missing user is locked out the same way, but there is no one to email
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(2).Return(db.User{}, sql.ErrNoRows)
+	store.EXPECT().CreateOutboxMessage(gomock.Any(), gomock.Any()).Times(0)
+
+	for range testLockoutPolicy.LockoutAttempts {
+		recorder := postJSON(t, server, "/users/login", gin.H{"username": username, "password": "secret"})
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+
+	recorder := postJSON(t, server, "/users/login", gin.H{"username": username, "password": "secret"})
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/ratelimit"
 	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/gin-gonic/gin"
@@ -14,11 +16,17 @@ import (
 
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
-		PasetoHexKey:        token.TestingHexKey,
-		AccessTokenDuration: time.Minute,
+		PasetoHexKey:         token.TestingHexKey,
+		AccessTokenDuration:  time.Minute,
+		MFAEncryptionKey:     token.TestingHexKey,
+		MFAChallengeDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store)
+	//? no limits are configured, so the rate limiter lets every request through
+	rateLimiter, err := ratelimit.NewLimiterFromConfig(config, nil)
+	require.NoError(t, err)
+
+	server, err := NewServer(config, store, token.NewMemoryDenyList(), lockout.NewGuard(lockout.NewMemoryCounter()), rateLimiter)
 	require.NoError(t, err)
 
 	return server
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/VihangaFTW/Go-Backend/mfa"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/gin-gonic/gin"
+)
+
+// verifyLoginMFARequest carries the mfa token returned by the login and a one-time password or recovery code
+type verifyLoginMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required,min=6,max=32"`
+}
+
+// mfaCodeRequest carries a one-time password or recovery code
+type mfaCodeRequest struct {
+	Code string `json:"code" binding:"required,min=6,max=32"`
+}
+
+// enrollMFAResponse is what an authenticator app needs to start generating codes
+type enrollMFAResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// confirmMFAResponse lists the recovery codes, which are only ever shown this once
+type confirmMFAResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// mfaErrorStatus maps the errors returned by the mfa.Authenticator to HTTP status codes
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, mfa.ErrInvalidCode):
+		return http.StatusUnauthorized
+	case errors.Is(err, mfa.ErrNotEnrolled), errors.Is(err, mfa.ErrAlreadyEnabled):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+// verifyLoginMFA handles POST /users/login/mfa requests
+// It finishes the login of a user with two-factor authentication
+func (server *Server) verifyLoginMFA(ctx *gin.Context) {
+	var req verifyLoginMFARequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	//? the mfa token proves the password was checked, the code proves the user holds the second factor
+	mfaPayload, err := server.tokenMaker.VerifyToken(req.MFAToken, token.TokenTypeMFAChallenge)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	//? a code has only a million values, so guessing it is throttled like guessing the password
+	attempt := loginAttempt(ctx, mfaPayload.Username)
+	if !server.checkLoginAttempt(ctx, attempt) {
+		return
+	}
+
+	if err = server.mfa.Verify(ctx, mfaPayload.Username, req.Code); err != nil {
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			server.loginFailed(ctx, attempt, true, mfaErrorStatus(err), err)
+			return
+		}
+
+		ctx.JSON(mfaErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	if err = server.loginGuard.Success(ctx, attempt); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// read the user again, the role may have changed since the password check
+	user, err := server.store.GetUser(ctx, mfaPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.createLoginSession(ctx, user)
+}
+
+// enrollMFA handles POST /mfa/enroll requests
+// It generates a TOTP secret, two-factor authentication stays off until it is confirmed with a code
+func (server *Server) enrollMFA(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	enrollment, err := server.mfa.Enroll(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(mfaErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, enrollMFAResponse{
+		Secret:     enrollment.Secret,
+		OtpauthURI: enrollment.URI,
+	})
+}
+
+// confirmMFA handles POST /mfa/confirm requests
+// It turns on two-factor authentication once the user enters a code from their authenticator app
+func (server *Server) confirmMFA(ctx *gin.Context) {
+	var req mfaCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	recoveryCodes, err := server.mfa.Confirm(ctx, authPayload.Username, req.Code)
+	if err != nil {
+		ctx.JSON(mfaErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, confirmMFAResponse{RecoveryCodes: recoveryCodes})
+}
+
+// disableMFA handles POST /mfa/disable requests
+// It takes a code, so a stolen access token alone cannot turn two-factor authentication off
+func (server *Server) disableMFA(ctx *gin.Context) {
+	var req mfaCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if err := server.mfa.Disable(ctx, authPayload.Username, req.Code, auditContext(ctx, authPayload.Username)); err != nil {
+		ctx.JSON(mfaErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/mfa"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// randomUserMFA returns a confirmed TOTP secret of the user, encrypted the way the test server expects
+func randomUserMFA(t *testing.T, username string) (userMFA db.UserMfa, secret string) {
+	secret, err := mfa.GenerateSecret()
+	require.NoError(t, err)
+
+	cipher, err := mfa.NewCipher(token.TestingHexKey)
+	require.NoError(t, err)
+
+	encryptedSecret, err := cipher.Encrypt(secret, username)
+	require.NoError(t, err)
+
+	userMFA = db.UserMfa{
+		Username:        username,
+		EncryptedSecret: encryptedSecret,
+		ConfirmedAt:     sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	return
+}
+
+func TestLoginUserWithoutMFAAPI(t *testing.T) {
+	user, password := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().GetUserMFA(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.UserMfa{}, sql.ErrNoRows)
+	store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateSessionTxResult{Session: randomSession(user.Username, "")}, nil)
+
+	server := newTestServer(t, store)
+
+	recorder := postJSON(t, server, "/users/login", gin.H{"username": user.Username, "password": password})
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got loginUserResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.NotEmpty(t, got.AccessToken)
+	require.NotEmpty(t, got.RefreshToken)
+}
+
+func TestLoginUserMFAAPI(t *testing.T) {
+	user, password := randomUser(t)
+	userMFA, secret := randomUserMFA(t, user.Username)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	//? the password alone only gets a challenge, no session is created
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().GetUserMFA(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(userMFA, nil)
+	store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+
+	recorder := postJSON(t, server, "/users/login", gin.H{"username": user.Username, "password": password})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NotContains(t, recorder.Body.String(), "access_token")
+
+	var challenge mfaChallengeResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &challenge))
+	require.True(t, challenge.MFARequired)
+	require.NotEmpty(t, challenge.MFAToken)
+
+	//! the challenge cannot be used as an access token
+	_, err := server.tokenMaker.VerifyToken(challenge.MFAToken, token.TokenTypeAccessToken)
+	require.ErrorIs(t, err, token.ErrInvalidTokenType)
+
+	//? a wrong code is rejected
+	store.EXPECT().GetUserMFA(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(userMFA, nil)
+
+	recorder = postJSON(t, server, "/users/login/mfa", gin.H{"mfa_token": challenge.MFAToken, "code": "000000"})
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	//? a valid code finishes the login
+	code, err := mfa.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	store.EXPECT().GetUserMFA(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(userMFA, nil)
+	store.EXPECT().UseMFAStep(gomock.Any(), gomock.Any()).Times(1).Return(userMFA, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateSessionTxResult{Session: randomSession(user.Username, "")}, nil)
+
+	recorder = postJSON(t, server, "/users/login/mfa", gin.H{"mfa_token": challenge.MFAToken, "code": code})
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got loginUserResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.NotEmpty(t, got.AccessToken)
+	require.Equal(t, user.Username, got.User.Username)
+
+	//? a code that was already used is rejected, the store refuses the old step
+	store.EXPECT().GetUserMFA(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(userMFA, nil)
+	store.EXPECT().UseMFAStep(gomock.Any(), gomock.Any()).Times(1).Return(db.UserMfa{}, sql.ErrNoRows)
+
+	recorder = postJSON(t, server, "/users/login/mfa", gin.H{"mfa_token": challenge.MFAToken, "code": code})
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	//! an access token is not a challenge
+	accessToken, _, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeAccessToken, got.SessionID, time.Minute)
+	require.NoError(t, err)
+
+	recorder = postJSON(t, server, "/users/login/mfa", gin.H{"mfa_token": accessToken, "code": code})
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -5,7 +5,10 @@ import (
 	"net/http"
 	"strings"
 
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/ratelimit"
 	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,6 +22,7 @@ var (
 	ErrAuthorizationHeadMissing       = errors.New("authorization header is not provided")
 	ErrAuthorizationHeadFormatInvalid = errors.New("invalid authorization header format")
 	ErrAuthorizationHeadUnsupported   = errors.New("unsupported authorization types")
+	ErrRateLimited                    = errors.New("too many requests, slow down")
 )
 
 // authMiddleware is a HOF that returns the gin auth middleware function.
@@ -46,7 +50,7 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		}
 
 		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := tokenMaker.VerifyToken(accessToken, token.TokenTypeAccessToken)
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
@@ -57,3 +61,76 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 	}
 
 }
+
+// requestIDMiddleware makes sure every request carries a request ID and sends it back to the client,
+// so a request can be matched with its audit events.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := util.RequestID(ctx.GetHeader(util.RequestIDHeader))
+
+		ctx.Request.Header.Set(util.RequestIDHeader, requestID)
+		ctx.Header(util.RequestIDHeader, requestID)
+	}
+}
+
+// auditContext describes the request for the audit events recorded by the transactions it runs.
+func auditContext(ctx *gin.Context, actor string) db.AuditContext {
+	return db.AuditContext{
+		Actor:     actor,
+		ClientIP:  ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+		RequestID: util.RequestID(ctx.GetHeader(util.RequestIDHeader)),
+	}
+}
+
+// routeRateLimitGroups lists the routes that do not fall into the reads or default groups by their HTTP method.
+// The keys are the method and the route pattern, as returned by gin.Context.FullPath.
+var routeRateLimitGroups = map[string]ratelimit.Group{
+	"POST /users/login":           ratelimit.GroupLogin,
+	"POST /users/login/mfa":       ratelimit.GroupLogin,
+	"POST /transfers":             ratelimit.GroupTransfers,
+	"POST /transfers/:id/reverse": ratelimit.GroupTransfers,
+	"POST /holds":                 ratelimit.GroupTransfers,
+	"POST /holds/:id/capture":     ratelimit.GroupTransfers,
+}
+
+// routeRateLimitGroup returns the group whose limit applies to a route.
+func routeRateLimitGroup(method string, route string) ratelimit.Group {
+	if group, ok := routeRateLimitGroups[method+" "+route]; ok {
+		return group
+	}
+
+	if method == http.MethodGet {
+		return ratelimit.GroupReads
+	}
+
+	return ratelimit.GroupDefault
+}
+
+// rateLimitMiddleware is a HOF that returns the gin rate limit middleware function.
+// Requests are counted per user once the auth middleware has run and per client IP otherwise.
+func rateLimitMiddleware(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ratelimit.ClientIPKey(ctx.ClientIP())
+
+		if payload, ok := ctx.Get(authorizationPayloadKey); ok {
+			key = ratelimit.UserKey(payload.(*token.Payload).Username)
+		}
+
+		result, err := limiter.Allow(ctx, routeRateLimitGroup(ctx.Request.Method, ctx.FullPath()), key)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		//* the headers are sent on every response, so clients can slow down before they are rejected
+		for name, value := range result.Headers() {
+			ctx.Header(name, value)
+		}
+
+		if !result.Allowed {
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, errorResponse(ErrRateLimited))
+			return
+		}
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/VihangaFTW/Go-Backend/ratelimit"
 	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,16 +23,18 @@ import (
 //   - tokenMaker: token maker instance for creating JWT tokens
 //   - authorizationType: type of authorization (e.g., "Bearer")
 //   - username: username for token creation
+//   - role: role carried by the token
 //   - duration: how long the token should be valid
 func addAuthorization(t *testing.T,
 	request *http.Request,
 	tokenMaker token.Maker,
 	authorizationType string,
 	username string,
+	role string,
 	duration time.Duration,
 ) {
 	// Create a new token for the specified user and duration
-	token, payload, err := tokenMaker.CreateToken(username, duration)
+	token, payload, err := tokenMaker.CreateToken(username, role, token.TokenTypeAccessToken, uuid.Nil, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -56,7 +61,7 @@ func TestAuthMiddleware(t *testing.T) {
 			name: "OK",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				// Add valid Bearer token authorization header with 1-minute duration
-				addAuthorization(t, request, tokenMaker, authorizationHeadTypeBearer, "user", time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationHeadTypeBearer, "user", util.DepositorRole, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				// Verify that the response status is 200 OK
@@ -79,7 +84,7 @@ func TestAuthMiddleware(t *testing.T) {
 			name: "InvalidAuthorizationFormat",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				// Add valid Bearer token authorization header with 1-minute duration
-				addAuthorization(t, request, tokenMaker, "", "user", time.Minute)
+				addAuthorization(t, request, tokenMaker, "", "user", util.DepositorRole, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				// Verify that the response status is 200 OK
@@ -91,19 +96,33 @@ func TestAuthMiddleware(t *testing.T) {
 			name: "ExpiredToken",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				// Add expired Bearer token authorization header (negative duration creates expired token)
-				addAuthorization(t, request, tokenMaker, authorizationHeadTypeBearer, "user", -time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationHeadTypeBearer, "user", util.DepositorRole, -time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				// Verify that the response status is 401 Unauthorized for expired tokens
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		//* Refresh token presented as an access token
+		{
+			name: "RefreshTokenAsBearer",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				refreshToken, _, err := tokenMaker.CreateToken("user", util.DepositorRole, token.TokenTypeRefreshToken, uuid.Nil, time.Minute)
+				require.NoError(t, err)
+
+				request.Header.Set(authorizationHeadKey, fmt.Sprintf("%s %s", authorizationHeadTypeBearer, refreshToken))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				// Verify that a refresh token cannot stand in for an access token
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 		//* Wrong authorization type (not Bearer)
 		{
 			name: "UnsupportedAuthorization",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				// Add valid Bearer token authorization header with 1-minute duration
-				addAuthorization(t, request, tokenMaker, "unsupported", "user", time.Minute)
+				addAuthorization(t, request, tokenMaker, "unsupported", "user", util.DepositorRole, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				// Verify that the response status is 200 OK
@@ -150,3 +169,60 @@ func TestAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+// TestRateLimitMiddleware tests that the rate limit middleware counts requests per user or client IP
+// and rejects them once the limit of their route group is used up
+func TestRateLimitMiddleware(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryBackend(), map[ratelimit.Group]ratelimit.Limit{
+		ratelimit.GroupReads: {Requests: 2, Period: time.Minute},
+	})
+
+	authPath := "/auth/rate_limited"
+	server.router.GET(authPath, authMiddleware(server.tokenMaker), rateLimitMiddleware(limiter),
+		func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{})
+		},
+	)
+
+	serve := func(username string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest(http.MethodGet, authPath, nil)
+		require.NoError(t, err)
+
+		addAuthorization(t, request, server.tokenMaker, authorizationHeadTypeBearer, username, util.DepositorRole, time.Minute)
+
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	username := util.RandomOwner()
+
+	recorder := serve(username)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "2", recorder.Header().Get(ratelimit.HeaderLimit))
+	require.Equal(t, "1", recorder.Header().Get(ratelimit.HeaderRemaining))
+	require.Equal(t, "2;w=60", recorder.Header().Get(ratelimit.HeaderPolicy))
+
+	recorder = serve(username)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "0", recorder.Header().Get(ratelimit.HeaderRemaining))
+
+	//* the limit is used up, the client is told when to come back
+	recorder = serve(username)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "30", recorder.Header().Get(ratelimit.HeaderRetryAfter))
+
+	//? another user behind the same IP has a bucket of their own
+	recorder = serve(util.RandomOwner())
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestRouteRateLimitGroup(t *testing.T) {
+	require.Equal(t, ratelimit.GroupLogin, routeRateLimitGroup(http.MethodPost, "/users/login"))
+	require.Equal(t, ratelimit.GroupTransfers, routeRateLimitGroup(http.MethodPost, "/transfers"))
+	require.Equal(t, ratelimit.GroupTransfers, routeRateLimitGroup(http.MethodPost, "/transfers/:id/reverse"))
+	require.Equal(t, ratelimit.GroupReads, routeRateLimitGroup(http.MethodGet, "/accounts/:id"))
+	require.Equal(t, ratelimit.GroupDefault, routeRateLimitGroup(http.MethodPost, "/accounts"))
+}
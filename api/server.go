@@ -4,6 +4,9 @@ import (
 	"fmt"
 
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/mfa"
+	"github.com/VihangaFTW/Go-Backend/ratelimit"
 	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/gin-gonic/gin"
@@ -17,18 +20,49 @@ type Server struct {
 	router     *gin.Engine
 	tokenMaker token.Maker
 	config     util.Config
+
+	// tokenRevoker rejects tokens before they expire, e.g. once their session is signed out
+	tokenRevoker token.Revoker
+	// mfa checks the second factor of users with two-factor authentication
+	mfa *mfa.Authenticator
+	// loginGuard throttles failed logins per username and client IP
+	loginGuard *lockout.Guard
+	// rateLimiter limits the requests of every user and client
+	rateLimiter *ratelimit.Limiter
 }
 
 // NewServer creates a new HTTP server and setup routing
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+// Revoked tokens are kept in the deny list until they expire.
+// Failed logins are counted by the login guard and requests by the rate limiter,
+// which are shared with the gRPC server so both apply the same limits.
+func NewServer(config util.Config, store db.Store, denyList token.DenyList, loginGuard *lockout.Guard, rateLimiter *ratelimit.Limiter) (*Server, error) {
 
-	tokenMaker, err := token.NewPasetoMaker(config.PasetoHexKey)
+	baseMaker, err := token.NewMakerFromConfig(config)
 
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err) // %w wraps the original error
 	}
+
+	//? every token check goes through the deny list, so revoked tokens are rejected everywhere
+	tokenMaker := token.NewRevocationMaker(baseMaker, denyList, config.RefreshTokenDuration)
+
+	mfaCipher, err := mfa.NewCipher(config.MFAEncryptionKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot create mfa cipher: %w", err)
+	}
+
 	// initialize server struct; router will be added later
-	server := &Server{store: store, tokenMaker: tokenMaker, config: config}
+	server := &Server{
+		store:        store,
+		tokenMaker:   tokenMaker,
+		tokenRevoker: tokenMaker,
+		mfa:          mfa.NewAuthenticator(store, mfaCipher, config.MFAIssuer),
+		config:       config,
+
+		loginGuard:  loginGuard,
+		rateLimiter: rateLimiter,
+	}
 
 	//? setup a custom validation tag used to validate struct fields
 	//! interface{} = any type. Need to cast the interface to check what concrete type it is
@@ -47,22 +81,53 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 func (server *Server) setupRouter() {
 
 	router := gin.Default()
+	router.Use(requestIDMiddleware())
 
-	router.POST("/users", server.createUser)
-	router.POST("/users/login", server.loginUser)
-	router.POST("/tokens/renew_access", server.renewAccessToken)
+	// Public routes are rate limited per client IP
+	publicRoutes := router.Group("/").Use(rateLimitMiddleware(server.rateLimiter))
+
+	publicRoutes.POST("/users", server.createUser)
+	publicRoutes.POST("/users/login", server.loginUser)
+	publicRoutes.POST("/users/login/mfa", server.verifyLoginMFA)
+	publicRoutes.POST("/users/logout", server.logoutUser)
+	publicRoutes.POST("/tokens/renew_access", server.renewAccessToken)
 
 	// Create a route group that applies authentication middleware to all routes within it
 	// This means all routes in this group will require a valid access token to access
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	// The rate limit runs after the authentication, so these routes are limited per user
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker), rateLimitMiddleware(server.rateLimiter))
 
 	// Protected account routes - require authentication
 	authRoutes.POST("/accounts", server.createAccount) // Create a new bank account
 	authRoutes.GET("/accounts/:id", server.getAccount) // Get a specific account by ID
 	authRoutes.GET("/accounts", server.listAccount)    // List all accounts for authenticated user
 
+	// Protected banker routes - require authentication and the banker role
+	authRoutes.POST("/accounts/:id/freeze", server.freezeAccount)     // Stop an account from sending or receiving money
+	authRoutes.POST("/accounts/:id/unfreeze", server.unfreezeAccount) // Lift the freeze on an account
+
 	// Protected transfer routes - require authentication
-	authRoutes.POST("/transfers", server.createTransfer) // Create a money transfer between accounts
+	authRoutes.POST("/transfers", server.createTransfer)              // Create a money transfer between accounts
+	authRoutes.POST("/transfers/:id/reverse", server.reverseTransfer) // Refund a received transfer, fully or partially
+
+	// Protected hold routes - require authentication
+	authRoutes.POST("/holds", server.placeHold)               // Reserve funds for a payee
+	authRoutes.GET("/holds/:id", server.getHold)              // Get a hold placed by or for the authenticated user
+	authRoutes.POST("/holds/:id/capture", server.captureHold) // Collect a hold as a transfer to the payee
+	authRoutes.POST("/holds/:id/release", server.releaseHold) // Lift a hold without moving any money
+
+	// Protected FX routes - require authentication
+	authRoutes.POST("/fx_quotes", server.createFxQuote) // Lock an exchange rate for a cross-currency transfer
+
+	// Protected session routes - require authentication
+	authRoutes.GET("/sessions", server.listSessions)                       // List the devices signed in as the authenticated user
+	authRoutes.DELETE("/sessions/:id", server.revokeSession)               // Sign out a single device
+	authRoutes.POST("/sessions/revoke_others", server.revokeOtherSessions) // Sign out every other device
+
+	// Protected two-factor authentication routes - require authentication
+	authRoutes.POST("/mfa/enroll", server.enrollMFA)   // Get a TOTP secret for an authenticator app
+	authRoutes.POST("/mfa/confirm", server.confirmMFA) // Turn on two-factor authentication
+	authRoutes.POST("/mfa/disable", server.disableMFA) // Turn off two-factor authentication
 
 	// add the routes to the router
 	server.router = router
@@ -2,11 +2,14 @@ package api
 
 import (
 	"database/sql"
-	"fmt"
+	"errors"
 	"net/http"
 	"time"
 
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // renewAccessTokenRequest defines the request structure for the refresh token endpoint.
@@ -16,10 +19,13 @@ type renewAccessTokenRequest struct {
 }
 
 // renewAccessTokenResponse defines the response structure for the refresh token endpoint.
-// It returns a new access token and its expiration time.
+// It returns a new access token and the refresh token that replaces the one in the request.
 type renewAccessTokenResponse struct {
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	SessionID             uuid.UUID `json:"session_id"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
 
 // renewAccessToken handles the refresh token endpoint.
@@ -36,74 +42,85 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 
 	// Verify the refresh token and extract its payload.
 	// This ensures the token is valid, not expired, and properly signed.
-	refreshPayload, err := server.tokenMaker.VerifyToken(req.RefreshToken)
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.RefreshToken, token.TokenTypeRefreshToken)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	// Retrieve the session from the database using the token's ID.
-	// The session contains additional security information and constraints.
-	session, err := server.store.GetSession(ctx, refreshPayload.ID)
-	if err != nil {
-		// Handle case where session doesn't exist.
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
+	// Refresh tokens are rotated: every renewal also replaces the refresh token itself.
+	refreshToken, newRefreshPayload, err := server.tokenMaker.CreateToken(refreshPayload.Username, refreshPayload.Role, token.TokenTypeRefreshToken, refreshPayload.FamilyID, server.config.RefreshTokenDuration)
 
-		// Handle database errors.
+	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	// Security check: Ensure the session hasn't been blocked.
-	// Blocked sessions are typically the result of suspicious activity.
-	if session.IsBlocked {
-		err := fmt.Errorf("blocked session")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	// Security check: Verify the session belongs to the same user as the token.
-	// This prevents token hijacking or session confusion attacks.
-	if session.Username != refreshPayload.Username {
-		err := fmt.Errorf("incorrect session user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	// Security check: Ensure the refresh token matches what's stored in the session.
-	// This prevents replay attacks with old or stolen tokens.
-	if session.RefreshToken != req.RefreshToken {
-		err := fmt.Errorf("mismatched session token")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
+	// Retrieve the session using the token's ID, run the security checks and swap it for a new session.
+	// Presenting a refresh token that was already rotated revokes every session of the same login.
+	result, err := server.store.RenewSessionTx(ctx, db.RenewSessionTxParams{
+		SessionID:    refreshPayload.ID,
+		Username:     refreshPayload.Username,
+		RefreshToken: req.RefreshToken,
+		NewSession: db.CreateSessionParams{
+			ID:           newRefreshPayload.ID,
+			RefreshToken: refreshToken,
+			UserAgent:    ctx.Request.UserAgent(),
+			ClientIp:     ctx.ClientIP(),
+			ExpiresAt:    newRefreshPayload.ExpiresAt,
+		},
+		Audit: auditContext(ctx, refreshPayload.Username),
+	})
+	if err != nil {
+		//? the whole family was blocked, so its access tokens must stop working as well
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			revokeErr := server.tokenRevoker.RevokeFamily(ctx, refreshPayload.FamilyID, time.Now().Add(server.config.RefreshTokenDuration))
+			if revokeErr != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(revokeErr))
+				return
+			}
+		}
 
-	// Security check: Verify the session hasn't expired.
-	// Even if the token is valid, the session itself might have expired.
-	if time.Now().After(session.ExpiresAt) {
-		err := fmt.Errorf("expired session")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		ctx.JSON(renewSessionErrorStatus(err), errorResponse(err))
 		return
 	}
 
 	// Generate a new access token for the authenticated user.
-	// The new token will have a fresh expiration time based on the configured duration.
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(refreshPayload.Username, server.config.AccessTokenDuration)
+	// The new token will have a fresh expiration time based on the configured duration,
+	// and carries the user's current role in case it changed since the login.
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(result.User.Username, result.User.Role, token.TokenTypeAccessToken, newRefreshPayload.FamilyID, server.config.AccessTokenDuration)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	// Prepare the successful response with the new access token.
+	// Prepare the successful response with the new access and refresh tokens.
 	response := &renewAccessTokenResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: accessPayload.ExpiresAt,
+		SessionID:             result.Session.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: result.Session.ExpiresAt,
 	}
 
-	// Return the new access token to the client.
+	// Return the new tokens to the client.
 	ctx.JSON(http.StatusOK, response)	
 }
+
+// renewSessionErrorStatus maps the errors returned by RenewSessionTx to an HTTP status code
+func renewSessionErrorStatus(err error) int {
+	switch {
+	// Handle case where session doesn't exist.
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	// The session was revoked, expired, belongs to someone else or its refresh token was reused.
+	case errors.Is(err, db.ErrSessionBlocked),
+		errors.Is(err, db.ErrSessionExpired),
+		errors.Is(err, db.ErrSessionMismatch),
+		errors.Is(err, db.ErrRefreshTokenReused):
+		return http.StatusUnauthorized
+	}
+
+	return http.StatusInternalServerError
+}
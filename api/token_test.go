@@ -0,0 +1,131 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRenewAccessTokenAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testcases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, refreshToken string)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, refreshToken string)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.RenewSessionTxParams) (db.RenewSessionTxResult, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, refreshToken, arg.RefreshToken)
+						require.NotEqual(t, arg.SessionID, arg.NewSession.ID)
+						require.NotEqual(t, refreshToken, arg.NewSession.RefreshToken)
+
+						session := randomSession(user.Username, arg.NewSession.RefreshToken)
+						session.ID = arg.NewSession.ID
+
+						return db.RenewSessionTxResult{Session: session}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, refreshToken string) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got renewAccessTokenResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.NotEmpty(t, got.AccessToken)
+
+				//? the refresh token is rotated on every renewal
+				require.NotEmpty(t, got.RefreshToken)
+				require.NotEqual(t, refreshToken, got.RefreshToken)
+			},
+		},
+		{
+			name: "RefreshTokenReused",
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.RenewSessionTxResult{}, db.ErrRefreshTokenReused)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, refreshToken string) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "RevokedSession",
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.RenewSessionTxResult{}, db.ErrSessionBlocked)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, refreshToken string) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.RenewSessionTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, refreshToken string) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			refreshToken, _, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeRefreshToken, uuid.Nil, time.Hour)
+			require.NoError(t, err)
+
+			tc.buildStubs(store, refreshToken)
+
+			recorder := postJSON(t, server, "/tokens/renew_access", gin.H{"refresh_token": refreshToken})
+			tc.checkResponse(t, recorder, refreshToken)
+		})
+	}
+
+	t.Run("AccessTokenAsRefreshToken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+
+		accessToken, _, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeAccessToken, uuid.Nil, time.Hour)
+		require.NoError(t, err)
+
+		recorder := postJSON(t, server, "/tokens/renew_access", gin.H{"refresh_token": accessToken})
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+
+		recorder := postJSON(t, server, "/tokens/renew_access", gin.H{"refresh_token": "invalid"})
+		require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
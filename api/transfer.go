@@ -4,11 +4,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/policy"
 	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // transferRequest defines the expected JSON structure for transfer creation requests
@@ -18,6 +21,7 @@ type transferRequest struct {
 	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`   // Destination account ID (must be positive)
 	Amount        int64  `json:"amount" binding:"required,gt=0"`           // Transfer amount (must be greater than 0)
 	Currency      string `json:"currency" binding:"required,currency"`     // Currency code (validated by custom currency validator)
+	QuoteID       string `json:"quote_id" binding:"omitempty,uuid"`        // Optional FX quote for transfers between different currencies
 }
 
 // createTransfer handles POST /transfers requests to create money transfers between accounts
@@ -45,34 +49,195 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 	// Authorization check: Verify that the authenticated user owns the source account
 	// This prevents users from transferring money from accounts they don't own
 	// Only the account owner can initiate transfers from their account
-	if authPayload.Username != fromAccount.Owner {
+	if !policy.CanMoveMoney(authPayload, fromAccount) {
 		err := errors.New("from account does not belong to authenticated user")
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	// Transfers are blocked until the user has verified their email address
+	if !server.requireVerifiedEmail(ctx, authPayload.Username) {
+		return
+	}
+
+	// Optional Idempotency-Key header lets clients safely retry a transfer after a timeout
+	key, err := idempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// A quote allows the receiver to hold a different currency than the sender
+	if req.QuoteID != "" {
+		server.createConvertTransfer(ctx, req, authPayload.Username, key)
+		return
+	}
+
 	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
 
 	if !valid {
 		return
 	}
+
 	// Prepare parameters for the database transfer transaction
-	arg := db.CreateTranferParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+	arg := db.TransferTxParams{
+		FromAccountID:  req.FromAccountID,
+		ToAccountID:    req.ToAccountID,
+		Amount:         req.Amount,
+		IdempotencyKey: key,
+		Audit:          auditContext(ctx, authPayload.Username),
 	}
 
 	// Execute the transfer transaction in the database
 	// This will create transfer, entry, and account balance update records atomically
-	transfer, err := server.store.CreateTranfer(ctx, arg)
+	result, err := server.store.TransferTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(transferErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	// Return the transfer along with both entries and the updated accounts.
+	// A retry with the same idempotency key receives the originally recorded result.
+	ctx.JSON(http.StatusOK, result)
+}
+
+// createConvertTransfer moves money between accounts holding different currencies at the rate locked by the request's quote
+// The quote and both account currencies are checked inside the database transaction
+func (server *Server) createConvertTransfer(ctx *gin.Context, req transferRequest, username string, key *db.IdempotencyParams) {
+	// The receiver may hold any currency, but it must exist
+	if _, err := server.store.GetAccount(ctx, req.ToAccountID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ConvertTransferTxParams{
+		FromAccountID:  req.FromAccountID,
+		ToAccountID:    req.ToAccountID,
+		Amount:         req.Amount,
+		QuoteID:        uuid.MustParse(req.QuoteID),
+		Username:       username,
+		IdempotencyKey: key,
+		Audit:          auditContext(ctx, username),
+	}
+
+	result, err := server.store.ConvertTransferTx(ctx, arg)
+	if err != nil {
+		// The quote does not exist
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(transferErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type reverseTransferURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// reverseTransferRequest is the optional body of a reversal, the full amount is refunded without it
+type reverseTransferRequest struct {
+	Amount int64 `json:"amount" binding:"omitempty,gt=0"` // Refund in the receiver's currency, at most what the transfer credited
+}
+
+// reverseTransfer handles POST /transfers/:id/reverse requests to refund a transfer, fully or partially
+// The refund is a new transfer from the original receiver back to the original sender, linked to the original one
+// Only the receiver or an admin can reverse a transfer, and every transfer can be reversed once
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	var uri reverseTransferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// An empty body refunds the full amount
+	var req reverseTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transfer, err := server.store.GetTranfer(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	receiver, err := server.store.GetAccount(ctx, transfer.ToAccountID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	// Return the created transfer details
-	ctx.JSON(http.StatusOK, transfer)
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// Only the receiver can give the money back, unless an admin steps in
+	if !policy.CanReverseTransfer(authPayload, receiver) {
+		err := errors.New("transfer was not received by the authenticated user")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	if !server.requireVerifiedEmail(ctx, authPayload.Username) {
+		return
+	}
+
+	key, err := idempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID:     transfer.ID,
+		Amount:         req.Amount,
+		IdempotencyKey: key,
+		Audit:          auditContext(ctx, authPayload.Username),
+	})
+	if err != nil {
+		ctx.JSON(transferErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// transferErrorStatus maps the errors returned by the transfer transactions to an HTTP status code
+func transferErrorStatus(err error) int {
+	switch {
+	// The sender cannot cover the amount, an account is frozen, or the FX quote cannot be used; the transaction has been rolled back
+	case errors.Is(err, db.ErrInsufficientFunds),
+		errors.Is(err, db.ErrQuoteExpired),
+		errors.Is(err, db.ErrQuoteUsed),
+		errors.Is(err, db.ErrQuoteMismatch),
+		errors.Is(err, db.ErrConversionTooSmall),
+		errors.Is(err, db.ErrAccountFrozen):
+		return http.StatusUnprocessableEntity
+	// The transfer was already refunded, is a refund itself, or the refund is larger than the transfer
+	case errors.Is(err, db.ErrTransferAlreadyReversed),
+		errors.Is(err, db.ErrReverseReversal),
+		errors.Is(err, db.ErrRefundTooLarge):
+		return http.StatusUnprocessableEntity
+	// The key was already used for a transfer with a different body
+	case errors.Is(err, db.ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
 }
 
 // validAccount is a helper function that validates an account for transfer operations
@@ -2,17 +2,22 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 
 	"time"
 
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
+// ErrEmailNotVerified is returned when an unverified user attempts a sensitive operation
+var ErrEmailNotVerified = errors.New("email address must be verified before this operation")
+
 // ? define request shape for create user endpoint
 type createUserRequest struct {
 	Username string `json:"username" binding:"required,alphanum"`
@@ -26,6 +31,7 @@ type userResponse struct {
 	Username string `json:"username"`
 	FullName string `json:"full_name"`
 	Email    string `json:"email"`
+	Role     string `json:"role"`
 }
 
 // newUserResponse converts a db.User struct into a userResponse struct by omitting secret and/or unncessary fields.
@@ -34,6 +40,7 @@ func newUserResponse(user db.User) userResponse {
 		Username: user.Username,
 		FullName: user.FullName,
 		Email:    user.Email,
+		Role:     user.Role,
 	}
 }
 
@@ -60,7 +67,10 @@ func (server *Server) createUser(ctx *gin.Context) {
 		Email:          request.Email,
 	}
 
-	user, err := server.store.CreateUser(ctx, arg)
+	txResult, err := server.store.CreateUserTx(ctx, db.CreateUserTxParams{
+		CreateUserParams: arg,
+		Audit:            auditContext(ctx, request.Username),
+	})
 	if err != nil {
 
 		if pqErr, ok := err.(*pq.Error); ok {
@@ -74,7 +84,7 @@ func (server *Server) createUser(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	response := newUserResponse(user)
+	response := newUserResponse(txResult.User)
 	ctx.JSON(http.StatusOK, response)
 
 }
@@ -95,6 +105,14 @@ type loginUserResponse struct {
 	User                  userResponse `json:"user"`
 }
 
+// mfaChallengeResponse is returned by the login instead of the tokens when the user has two-factor authentication enabled.
+// The mfa token has to be sent to /users/login/mfa together with a code to finish the login.
+type mfaChallengeResponse struct {
+	MFARequired       bool      `json:"mfa_required"`
+	MFAToken          string    `json:"mfa_token"`
+	MFATokenExpiresAt time.Time `json:"mfa_token_expires_at"`
+}
+
 // ? define endpoint handler for login in a user
 func (server *Server) loginUser(ctx *gin.Context) {
 	var req loginUserRequest
@@ -103,10 +121,19 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	attempt := loginAttempt(ctx, req.Username)
+
+	//? throttled attempts are rejected before the password is checked, so they cannot be used to guess it
+	if !server.checkLoginAttempt(ctx, attempt) {
+		return
+	}
+
 	user, err := server.store.GetUser(ctx, req.Username)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			//! a missing user takes as long and fails the same way as a wrong password, so usernames cannot be probed
+			_ = util.CheckMissingUserPassword(req.Password)
+			server.loginFailed(ctx, attempt, false, http.StatusUnauthorized, ErrIncorrectCredentials)
 			return
 		}
 
@@ -118,20 +145,55 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	//? check if given password matches its stored hash
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		server.loginFailed(ctx, attempt, true, http.StatusUnauthorized, ErrIncorrectCredentials)
+		return
+	}
+
+	//? password correct, but users with two-factor authentication still have to enter a code.
+	//? their failed attempts are only forgotten once the code is right as well, so the codes are throttled too
+	mfaEnabled, err := server.mfa.Enabled(ctx, user.Username)
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if mfaEnabled {
+		mfaToken, mfaPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeMFAChallenge, uuid.Nil, server.config.MFAChallengeDuration)
+
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, mfaChallengeResponse{
+			MFARequired:       true,
+			MFAToken:          mfaToken,
+			MFATokenExpiresAt: mfaPayload.ExpiresAt,
+		})
 		return
 	}
 
-	// password correct. Generate access token
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.AccessTokenDuration)
+	if err = server.loginGuard.Success(ctx, attempt); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.createLoginSession(ctx, user)
+}
+
+// createLoginSession starts a session for a user who passed every login check and responds with its tokens
+func (server *Server) createLoginSession(ctx *gin.Context, user db.User) {
+	//? generate the refresh token first, it starts the token family of this login
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeRefreshToken, uuid.Nil, server.config.RefreshTokenDuration)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	//? generate refresh token too
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.RefreshTokenDuration)
+	// generate access token in the same family, so signing out the session revokes it too
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeAccessToken, refreshPayload.FamilyID, server.config.AccessTokenDuration)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -139,14 +201,19 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	// create a new session
-	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     refreshPayload.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    ctx.Request.UserAgent(),
-		ClientIp:     ctx.ClientIP(),
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiresAt,
+	txResult, err := server.store.CreateSessionTx(ctx, db.CreateSessionTxParams{
+		CreateSessionParams: db.CreateSessionParams{
+			ID:           refreshPayload.ID,
+			Username:     refreshPayload.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    ctx.Request.UserAgent(),
+			ClientIp:     ctx.ClientIP(),
+			IsBlocked:    false,
+			ExpiresAt:    refreshPayload.ExpiresAt,
+			// the login starts a new family that every rotated refresh token joins
+			FamilyID: refreshPayload.FamilyID,
+		},
+		Audit: auditContext(ctx, user.Username),
 	})
 
 	if err != nil {
@@ -155,7 +222,7 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	response := loginUserResponse{
-		SessionID:             session.ID,
+		SessionID:             txResult.Session.ID,
 		AccessToken:           accessToken,
 		AccessTokenExpiresAt:  accessPayload.ExpiresAt,
 		RefreshToken:          refreshToken,
@@ -164,5 +231,21 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, response)
+}
+
+// requireVerifiedEmail blocks sensitive operations such as transfers until the user has verified their email address
+// Returns: true if the email is verified, false otherwise (with error response sent)
+func (server *Server) requireVerifiedEmail(ctx *gin.Context, username string) bool {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if !user.IsEmailVerified {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrEmailNotVerified))
+		return false
+	}
 
+	return true
 }
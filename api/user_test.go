@@ -21,7 +21,7 @@ import (
 )
 
 // eqCreateUserParamsMatcher is a custom matcher for validating whether a given password matches
-// its hashed equivalent stored in the db.
+// its hashed equivalent stored in the db, and that the signup is recorded as the new user's own action.
 // When testing user creation, the password gets
 // hashed before being stored, so we can't directly compare the expected parameters with the actual
 // parameters because the hashed password will be different each time (due to bcrypt's random salt).
@@ -32,14 +32,20 @@ type eqCreateUserParamsMatcher struct {
 
 // Mathches returns whether x is a match. This method must be implemented to satisfy the Matcher interface.
 func (e eqCreateUserParamsMatcher) Matches(x any) bool {
-	//? make sure the input is a CreateUserParams type
-	actual, ok := x.(db.CreateUserParams)
+	//? make sure the input is a CreateUserTxParams type
+	txArg, ok := x.(db.CreateUserTxParams)
 
 	// input is not expected type: match failed
 	if !ok {
 		return false
 	}
 
+	if txArg.Audit.Actor != e.arg.Username {
+		return false
+	}
+
+	actual := txArg.CreateUserParams
+
 	//! use bcrypt to verify the password matches its hash instead of directly comparing two unidentical hashes
 	if err := util.CheckPassword(e.password, actual.HashedPassword); err != nil {
 		return false
@@ -67,7 +73,7 @@ func EqCreateUserParams(expected db.CreateUserParams, password string) gomock.Ma
 //
 // # API Call
 //
-// Handler calls store.CreateUser(ctx, actualParams)
+// Handler calls store.CreateUserTx(ctx, actualParams)
 //
 //	↓
 //
@@ -117,10 +123,10 @@ func TestCreateUserAPI(t *testing.T) {
 					Email:    user.Email,
 				}
 				//? EqCreateUserParams creates a Matcher object and stores it in gomock's expectations.
-				//? When the API handler calls the store's CreateUser function, gomock intercepts this function call.
+				//? When the API handler calls the store's CreateUserTx function, gomock intercepts this function call.
 				//? Gomock runs the provided Matcher's Matches function and determines whether the expectation matches
 				//? depending on the results of this function.
-				store.EXPECT().CreateUser(gomock.Any(), EqCreateUserParams(arg, password)).Times(1).Return(user, nil)
+				store.EXPECT().CreateUserTx(gomock.Any(), EqCreateUserParams(arg, password)).Times(1).Return(db.CreateUserTxResult{User: user}, nil)
 								
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -138,9 +144,9 @@ func TestCreateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateUser(gomock.Any(), gomock.Any()).
+					CreateUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.User{}, sql.ErrConnDone)
+					Return(db.CreateUserTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -156,9 +162,9 @@ func TestCreateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateUser(gomock.Any(), gomock.Any()).
+					CreateUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.User{}, &pq.Error{Code: "23505"}) // code for unique_violation postgres error
+					Return(db.CreateUserTxResult{}, &pq.Error{Code: "23505"}) // code for unique_violation postgres error
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusForbidden, recorder.Code)
@@ -174,7 +180,7 @@ func TestCreateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateUser(gomock.Any(), gomock.Any()).
+					CreateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -191,7 +197,7 @@ func TestCreateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateUser(gomock.Any(), gomock.Any()).
+					CreateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -208,7 +214,7 @@ func TestCreateUserAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateUser(gomock.Any(), gomock.Any()).
+					CreateUserTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -257,6 +263,7 @@ func randomUser(t *testing.T) (user db.User, password string) {
 		Email:          util.RandomEmail(),
 		FullName:       util.RandomOwner(),
 		HashedPassword: hashedPassword,
+		Role:           util.DepositorRole,
 	}
 	return
 }
@@ -273,6 +280,7 @@ func requireBodyMatchUser(t *testing.T, body *bytes.Buffer, user db.User) {
 	require.Equal(t, user.FullName, gotUser.FullName)
 	require.Equal(t, user.Email, gotUser.Email)
 	require.Equal(t, user.Username, gotUser.Username)
+	require.Equal(t, user.Role, gotUser.Role)
 	//? request should not return the password hash as we removed that field in the createUser api handler
 	require.Empty(t, gotUser.HashedPassword)
 }
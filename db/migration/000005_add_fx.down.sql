@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "fx_conversions";
+
+DROP TABLE IF EXISTS "fx_quotes";
+
+DROP TABLE IF EXISTS "fx_rates";
\ No newline at end of file
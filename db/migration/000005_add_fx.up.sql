@@ -0,0 +1,45 @@
+CREATE TABLE "fx_rates" (
+    "from_currency" varchar NOT NULL,
+    "to_currency" varchar NOT NULL,
+    "rate" bigint NOT NULL,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("from_currency", "to_currency")
+);
+
+CREATE TABLE "fx_quotes" (
+    "id" uuid PRIMARY KEY,
+    "username" varchar NOT NULL,
+    "from_currency" varchar NOT NULL,
+    "to_currency" varchar NOT NULL,
+    "rate" bigint NOT NULL,
+    "spread_bps" bigint NOT NULL,
+    "is_used" boolean NOT NULL DEFAULT false,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "fx_conversions" (
+    "transfer_id" bigint PRIMARY KEY,
+    "quote_id" uuid NOT NULL UNIQUE,
+    "from_currency" varchar NOT NULL,
+    "to_currency" varchar NOT NULL,
+    "from_amount" bigint NOT NULL,
+    "to_amount" bigint NOT NULL,
+    "rate" bigint NOT NULL,
+    "spread_bps" bigint NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "fx_rates"."rate" IS 'mid-market units of to_currency per unit of from_currency, scaled by 10^8';
+
+COMMENT ON COLUMN "fx_quotes"."rate" IS 'locked rate with the spread applied, scaled by 10^8';
+
+COMMENT ON COLUMN "fx_conversions"."from_amount" IS 'debited from the sender in from_currency';
+
+COMMENT ON COLUMN "fx_conversions"."to_amount" IS 'credited to the receiver in to_currency';
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "fx_conversions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "fx_conversions" ADD FOREIGN KEY ("quote_id") REFERENCES "fx_quotes" ("id");
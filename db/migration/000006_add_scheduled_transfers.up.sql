@@ -0,0 +1,49 @@
+CREATE TABLE "scheduled_transfers" (
+    "id" bigserial PRIMARY KEY,
+    "owner" varchar NOT NULL,
+    "from_account_id" bigint NOT NULL,
+    "to_account_id" bigint NOT NULL,
+    "amount" bigint NOT NULL,
+    "frequency" varchar NOT NULL,
+    "cron_expression" varchar NOT NULL DEFAULT '',
+    "start_at" timestamptz NOT NULL,
+    "next_run_at" timestamptz NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'active',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfer_runs" (
+    "id" bigserial PRIMARY KEY,
+    "scheduled_transfer_id" bigint NOT NULL,
+    "scheduled_for" timestamptz NOT NULL,
+    "attempt" integer NOT NULL,
+    "transfer_id" bigint,
+    "status" varchar NOT NULL,
+    "error" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+
+CREATE INDEX ON "scheduled_transfers" ("status", "next_run_at");
+
+CREATE INDEX ON "scheduled_transfer_runs" ("scheduled_transfer_id");
+
+COMMENT ON COLUMN "scheduled_transfers"."frequency" IS 'once, daily, weekly, monthly or cron';
+
+COMMENT ON COLUMN "scheduled_transfers"."start_at" IS 'first run, anchors the recurrence';
+
+COMMENT ON COLUMN "scheduled_transfers"."status" IS 'active, paused, completed, failed or cancelled';
+
+COMMENT ON COLUMN "scheduled_transfer_runs"."transfer_id" IS 'null when the attempt failed';
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "statement_exports";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "created_at";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "transfer_id";
\ No newline at end of file
@@ -0,0 +1,36 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+-- entries recorded so far keep a NULL created_at, only entries added from here on get the default
+ALTER TABLE "entries" ADD COLUMN "created_at" timestamptz;
+
+ALTER TABLE "entries" ALTER COLUMN "created_at" SET DEFAULT (now());
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer that created the entry, null for entries recorded before it was tracked';
+
+COMMENT ON COLUMN "entries"."created_at" IS 'time the entry was recorded, null for entries recorded before it was tracked. Those are left out of statements and counted in the opening balance';
+
+CREATE TABLE "statement_exports" (
+    "id" uuid PRIMARY KEY,
+    "username" varchar NOT NULL,
+    "account_id" bigint NOT NULL,
+    "format" varchar NOT NULL,
+    "from_time" timestamptz NOT NULL,
+    "to_time" timestamptz NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "content" bytea NOT NULL DEFAULT '',
+    "error" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "completed_at" timestamptz
+);
+
+COMMENT ON COLUMN "statement_exports"."format" IS 'csv or ofx';
+
+COMMENT ON COLUMN "statement_exports"."status" IS 'pending, ready or failed';
+
+ALTER TABLE "statement_exports" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "statement_exports" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1 @@
+CREATE INDEX ON "sessions" ("username", "created_at");
\ No newline at end of file
@@ -0,0 +1,29 @@
+CREATE TABLE "user_mfa" (
+    "username" varchar PRIMARY KEY,
+    "encrypted_secret" bytea NOT NULL,
+    "confirmed_at" timestamptz,
+    "last_used_step" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "mfa_recovery_codes" (
+    "id" bigserial PRIMARY KEY,
+    "username" varchar NOT NULL,
+    "code_hash" varchar NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "mfa_recovery_codes" ("username", "code_hash");
+
+COMMENT ON COLUMN "user_mfa"."encrypted_secret" IS 'AES-GCM encrypted TOTP secret';
+
+COMMENT ON COLUMN "user_mfa"."confirmed_at" IS 'null until the first code is confirmed, login only asks for codes once set';
+
+COMMENT ON COLUMN "user_mfa"."last_used_step" IS 'TOTP step of the last accepted code, so codes cannot be replayed';
+
+COMMENT ON COLUMN "mfa_recovery_codes"."code_hash" IS 'SHA-256 of the normalized code';
+
+ALTER TABLE "user_mfa" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "mfa_recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
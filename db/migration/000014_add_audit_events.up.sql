@@ -0,0 +1,38 @@
+CREATE TABLE "audit_events" (
+    "id" bigserial PRIMARY KEY,
+    "actor" varchar NOT NULL,
+    "action" varchar NOT NULL,
+    "resource_type" varchar NOT NULL,
+    "resource_id" varchar NOT NULL,
+    "before" jsonb NOT NULL DEFAULT '{}',
+    "after" jsonb NOT NULL DEFAULT '{}',
+    "client_ip" varchar NOT NULL DEFAULT '',
+    "user_agent" varchar NOT NULL DEFAULT '',
+    "request_id" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_events" ("actor", "created_at");
+
+CREATE INDEX ON "audit_events" ("resource_type", "resource_id", "created_at");
+
+CREATE INDEX ON "audit_events" ("action", "created_at");
+
+COMMENT ON COLUMN "audit_events"."actor" IS 'Username that made the change, or system for background jobs. Not a foreign key, so events outlive the user';
+
+COMMENT ON COLUMN "audit_events"."before" IS 'Snapshot of the resource before the change, empty for new resources';
+
+-- the audit log is append-only: rows can never be changed or removed, not even by the application
+CREATE FUNCTION "reject_audit_event_change"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_events_append_only"
+BEFORE UPDATE OR DELETE ON "audit_events"
+FOR EACH ROW EXECUTE FUNCTION "reject_audit_event_change"();
+
+CREATE TRIGGER "audit_events_no_truncate"
+BEFORE TRUNCATE ON "audit_events"
+FOR EACH STATEMENT EXECUTE FUNCTION "reject_audit_event_change"();
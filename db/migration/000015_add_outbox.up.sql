@@ -0,0 +1,27 @@
+CREATE TABLE "outbox" (
+    "id" bigserial PRIMARY KEY,
+    "task_type" varchar NOT NULL,
+    "payload" jsonb NOT NULL DEFAULT '{}',
+    "queue" varchar NOT NULL,
+    "max_retry" int NOT NULL,
+    "process_at" timestamptz NOT NULL DEFAULT (now()),
+    "task_id" varchar NOT NULL DEFAULT '',
+    "attempts" int NOT NULL DEFAULT 0,
+    "last_error" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "dispatched_at" timestamptz
+);
+
+CREATE INDEX ON "outbox" ("id") WHERE "dispatched_at" IS NULL;
+
+CREATE INDEX ON "outbox" ("dispatched_at") WHERE "dispatched_at" IS NOT NULL;
+
+COMMENT ON COLUMN "outbox"."task_type" IS 'Type of the asynq task, e.g. task:send_verify_email';
+
+COMMENT ON COLUMN "outbox"."process_at" IS 'Earliest time the task is processed, passed on to asynq';
+
+COMMENT ON COLUMN "outbox"."task_id" IS 'asynq task ID, empty to derive one from the row ID so a row relayed twice is only queued once';
+
+COMMENT ON COLUMN "outbox"."attempts" IS 'Number of failed attempts to hand the task to asynq';
+
+COMMENT ON COLUMN "outbox"."dispatched_at" IS 'Time the task was handed to asynq, NULL while it is pending';
@@ -0,0 +1,6 @@
+ALTER TABLE "transfers" ADD COLUMN "reversal_of" bigint REFERENCES "transfers" ("id");
+
+-- a transfer is reversed at most once, whether the refund was full or partial
+CREATE UNIQUE INDEX ON "transfers" ("reversal_of");
+
+COMMENT ON COLUMN "transfers"."reversal_of" IS 'Transfer refunded by this one - null for regular transfers';
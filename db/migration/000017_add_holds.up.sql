@@ -0,0 +1,40 @@
+CREATE TABLE "holds" (
+    "id" bigserial PRIMARY KEY,
+    "owner" varchar NOT NULL,
+    "account_id" bigint NOT NULL,
+    "to_account_id" bigint NOT NULL,
+    "amount" bigint NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'active',
+    "expires_at" timestamptz NOT NULL,
+    "transfer_id" bigint,
+    "captured_amount" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "closed_at" timestamptz
+);
+
+ALTER TABLE "accounts" ADD COLUMN "held_balance" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD COLUMN "available_balance" bigint NOT NULL GENERATED ALWAYS AS ("balance" - "held_balance") STORED;
+
+CREATE INDEX ON "holds" ("account_id", "status");
+
+CREATE INDEX ON "holds" ("to_account_id");
+
+-- the expiry job looks up active holds by their expiry time
+CREATE INDEX ON "holds" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "holds"."status" IS 'active, captured, released or expired';
+
+COMMENT ON COLUMN "holds"."transfer_id" IS 'transfer made when the hold was captured';
+
+COMMENT ON COLUMN "accounts"."held_balance" IS 'sum of the active holds on the account';
+
+COMMENT ON COLUMN "accounts"."available_balance" IS 'ledger balance minus active holds, what the account can spend';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
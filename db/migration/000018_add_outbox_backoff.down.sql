@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "outbox_attempts_id_idx";
+
+CREATE INDEX ON "outbox" ("id") WHERE "dispatched_at" IS NULL;
+
+ALTER TABLE "outbox" DROP COLUMN IF EXISTS "failed_at";
+
+ALTER TABLE "outbox" DROP COLUMN IF EXISTS "next_attempt_at";
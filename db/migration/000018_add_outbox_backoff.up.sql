@@ -0,0 +1,11 @@
+ALTER TABLE "outbox" ADD COLUMN "next_attempt_at" timestamptz NOT NULL DEFAULT (now());
+
+ALTER TABLE "outbox" ADD COLUMN "failed_at" timestamptz;
+
+DROP INDEX IF EXISTS "outbox_id_idx";
+
+CREATE INDEX ON "outbox" ("attempts", "id") WHERE "dispatched_at" IS NULL AND "failed_at" IS NULL;
+
+COMMENT ON COLUMN "outbox"."next_attempt_at" IS 'Earliest time the relay hands the task to asynq again, pushed back after every failed attempt';
+
+COMMENT ON COLUMN "outbox"."failed_at" IS 'Time the relay gave up on the task after too many failed attempts, NULL while it is retried';
@@ -27,12 +27,24 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
+-- name: AddAccountHeldBalance :one
+UPDATE accounts
+SET held_balance = held_balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: UpdateAccount :one
 UPDATE accounts 
 SET balance = $2 
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateAccountFrozen :one
+UPDATE accounts
+SET is_frozen = sqlc.arg(is_frozen)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: DeleteAccount :exec
 DELETE FROM accounts
 WHERE id =  $1;
\ No newline at end of file
@@ -1,9 +1,9 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
-    account_id, amount
+    account_id, amount, transfer_id
 )
 VALUES (
-    $1,$2
+    $1,$2,$3
 )
 RETURNING *;
 
@@ -18,4 +18,28 @@ SELECT * FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
-OFFSET $3;
\ No newline at end of file
+OFFSET $3;
+
+-- name: ListStatementEntries :many
+-- entries without a created_at predate every range, the comparisons leave them out
+SELECT
+    e.*,
+    COALESCE(c.id, 0)::bigint AS counterparty_account_id,
+    COALESCE(c.owner, '')::varchar AS counterparty_owner
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN accounts c ON c.id = CASE
+    WHEN t.from_account_id = e.account_id THEN t.to_account_id
+    ELSE t.from_account_id
+END
+WHERE e.account_id = sqlc.arg(account_id)
+    AND e.created_at >= sqlc.arg(from_time)::timestamptz
+    AND e.created_at < sqlc.arg(to_time)::timestamptz
+ORDER BY e.created_at, e.id;
+
+-- name: SumEntriesSince :one
+-- entries without a created_at are never counted, so they stay in the opening balance of every statement
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+    AND created_at >= sqlc.arg(since)::timestamptz;
\ No newline at end of file
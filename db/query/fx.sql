@@ -0,0 +1,62 @@
+-- name: UpsertFxRate :one
+INSERT INTO fx_rates (
+    from_currency,
+    to_currency,
+    rate
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (from_currency, to_currency)
+DO UPDATE SET rate = EXCLUDED.rate, updated_at = now()
+RETURNING *;
+
+-- name: GetFxRate :one
+SELECT * FROM fx_rates
+WHERE from_currency = $1 AND to_currency = $2
+LIMIT 1;
+
+-- name: CreateFxQuote :one
+INSERT INTO fx_quotes (
+    id,
+    username,
+    from_currency,
+    to_currency,
+    rate,
+    spread_bps,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetFxQuote :one
+SELECT * FROM fx_quotes
+WHERE id = $1 LIMIT 1;
+
+-- name: GetFxQuoteForUpdate :one
+SELECT * FROM fx_quotes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: MarkFxQuoteUsed :one
+UPDATE fx_quotes
+SET is_used = true
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateFxConversion :one
+INSERT INTO fx_conversions (
+    transfer_id,
+    quote_id,
+    from_currency,
+    to_currency,
+    from_amount,
+    to_amount,
+    rate,
+    spread_bps
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetFxConversion :one
+SELECT * FROM fx_conversions
+WHERE transfer_id = $1 LIMIT 1;
@@ -0,0 +1,38 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+    owner,
+    account_id,
+    to_account_id,
+    amount,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListExpiredHoldsForUpdate :many
+-- Holds locked by a capture or release are skipped, so the expiry job does not wait on them.
+-- They are ordered by account so their accounts are updated in the same order transfers lock them.
+SELECT * FROM holds
+WHERE status = 'active' AND expires_at <= sqlc.arg(now)
+ORDER BY account_id, id
+LIMIT sqlc.arg(limit_count)
+FOR NO KEY UPDATE SKIP LOCKED;
+
+-- name: CloseHold :one
+UPDATE holds
+SET
+    status = sqlc.arg(status),
+    transfer_id = sqlc.narg(transfer_id),
+    captured_amount = sqlc.arg(captured_amount),
+    closed_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
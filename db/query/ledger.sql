@@ -0,0 +1,80 @@
+-- name: CountAccounts :one
+SELECT COUNT(*) FROM accounts;
+
+-- name: ListBalanceDrifts :many
+SELECT
+    a.id AS account_id,
+    a.owner,
+    a.currency,
+    a.balance,
+    COALESCE(e.total, 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN (
+    SELECT account_id, SUM(amount) AS total
+    FROM entries
+    GROUP BY account_id
+) e ON e.account_id = a.id
+WHERE a.balance <> COALESCE(e.total, 0)
+ORDER BY a.id;
+
+-- name: ListHeldBalanceDrifts :many
+SELECT
+    a.id AS account_id,
+    a.owner,
+    a.currency,
+    a.held_balance,
+    COALESCE(h.total, 0)::bigint AS holds_total
+FROM accounts a
+LEFT JOIN (
+    SELECT account_id, SUM(amount) AS total
+    FROM holds
+    WHERE status = 'active'
+    GROUP BY account_id
+) h ON h.account_id = a.id
+WHERE a.held_balance <> COALESCE(h.total, 0)
+ORDER BY a.id;
+
+-- name: ListUnbalancedTransfers :many
+-- A transfer must debit the sender by its amount and credit the receiver by the same amount,
+-- or by the converted amount recorded in fx_conversions for a cross-currency transfer.
+-- A reversal of a cross-currency transfer credits the same share of the original debit, rounded down.
+SELECT
+    t.id AS transfer_id,
+    t.from_account_id,
+    t.to_account_id,
+    t.amount,
+    COALESCE(c.to_amount, div(t.amount::numeric * rc.from_amount, rc.to_amount), t.amount)::bigint AS expected_credit,
+    COALESCE(e.entry_count, 0)::bigint AS entry_count,
+    COALESCE(e.debit_total, 0)::bigint AS debit_total,
+    COALESCE(e.credit_total, 0)::bigint AS credit_total
+FROM transfers t
+LEFT JOIN fx_conversions c ON c.transfer_id = t.id
+LEFT JOIN fx_conversions rc ON rc.transfer_id = t.reversal_of
+LEFT JOIN (
+    SELECT
+        en.transfer_id,
+        COUNT(*) AS entry_count,
+        SUM(en.amount) FILTER (WHERE en.account_id = tr.from_account_id AND en.amount < 0) AS debit_total,
+        SUM(en.amount) FILTER (WHERE en.account_id = tr.to_account_id AND en.amount > 0) AS credit_total
+    FROM entries en
+    JOIN transfers tr ON tr.id = en.transfer_id
+    GROUP BY en.transfer_id
+) e ON e.transfer_id = t.id
+WHERE COALESCE(e.entry_count, 0) <> 2
+    OR COALESCE(e.debit_total, 0) <> -t.amount
+    OR COALESCE(e.credit_total, 0) <> COALESCE(c.to_amount, div(t.amount::numeric * rc.from_amount, rc.to_amount), t.amount)
+ORDER BY t.id;
+
+-- name: ListOrphanEntries :many
+-- Entries that their transfer does not explain: booked to an account that is not a party of it.
+-- Entries recorded before transfers were tracked have no transfer_id and are counted by CountLegacyEntries instead.
+SELECT e.*
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.transfer_id IS NOT NULL
+    AND (t.id IS NULL OR e.account_id NOT IN (t.from_account_id, t.to_account_id))
+ORDER BY e.id;
+
+-- name: CountLegacyEntries :one
+SELECT COUNT(*) FROM entries
+WHERE transfer_id IS NULL;
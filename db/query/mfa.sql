@@ -0,0 +1,65 @@
+-- name: UpsertUserMFA :one
+-- Enrolling again replaces a secret that was never confirmed, but never a confirmed one.
+INSERT INTO user_mfa (
+    username,
+    encrypted_secret
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET
+    encrypted_secret = EXCLUDED.encrypted_secret,
+    last_used_step = 0,
+    created_at = now()
+WHERE user_mfa.confirmed_at IS NULL
+RETURNING *;
+
+-- name: GetUserMFA :one
+SELECT * FROM user_mfa
+WHERE username = $1 LIMIT 1;
+
+-- name: ConfirmUserMFA :one
+UPDATE user_mfa
+SET
+    confirmed_at = now(),
+    last_used_step = @step
+WHERE
+    username = @username
+    AND confirmed_at IS NULL
+RETURNING *;
+
+-- name: UseMFAStep :one
+-- Only a step after the last accepted one is taken, so each code works once.
+UPDATE user_mfa
+SET
+    last_used_step = @step
+WHERE
+    username = @username
+    AND last_used_step < @step
+RETURNING *;
+
+-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa
+WHERE username = $1;
+
+-- name: CreateMFARecoveryCode :one
+INSERT INTO mfa_recovery_codes (
+    username,
+    code_hash
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: UseMFARecoveryCode :one
+UPDATE mfa_recovery_codes
+SET
+    used_at = now()
+WHERE
+    username = @username
+    AND code_hash = @code_hash
+    AND used_at IS NULL
+RETURNING *;
+
+-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE username = $1;
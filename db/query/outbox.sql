@@ -0,0 +1,41 @@
+-- name: CreateOutboxMessage :one
+INSERT INTO outbox (
+    task_type,
+    payload,
+    queue,
+    max_retry,
+    process_at,
+    task_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListPendingOutboxMessagesForUpdate :many
+-- rows locked by another relay are skipped, so several workers can relay at once without sending a row twice.
+-- Messages that failed before come after the fresh ones, so a batch of failing rows cannot hold back newer tasks.
+SELECT * FROM outbox
+WHERE dispatched_at IS NULL
+    AND failed_at IS NULL
+    AND next_attempt_at <= now()
+ORDER BY attempts, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxMessageDispatched :exec
+UPDATE outbox
+SET dispatched_at = now()
+WHERE id = $1;
+
+-- name: RecordOutboxMessageFailure :exec
+-- the message is retried after the delay, or given up on once it reaches max_attempts
+UPDATE outbox
+SET
+    attempts = attempts + 1,
+    last_error = sqlc.arg(last_error),
+    next_attempt_at = now() + make_interval(secs => sqlc.arg(retry_delay_seconds)::float8),
+    failed_at = CASE WHEN attempts + 1 >= sqlc.arg(max_attempts)::int THEN now() END
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteDispatchedOutboxMessages :execrows
+DELETE FROM outbox
+WHERE dispatched_at < sqlc.arg(dispatched_before)::timestamptz;
@@ -0,0 +1,27 @@
+-- name: CreatePasswordReset :one
+INSERT INTO password_resets (
+    username,
+    email,
+    token_hash
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: UsePasswordReset :one
+UPDATE password_resets
+SET
+    is_used = TRUE
+WHERE
+    token_hash = @token_hash
+    AND is_used = FALSE
+    AND expired_at > now()
+RETURNING *;
+
+-- name: InvalidatePasswordResets :exec
+-- Once the password is reset, any other link that was sent becomes useless.
+UPDATE password_resets
+SET
+    is_used = TRUE
+WHERE
+    username = @username
+    AND is_used = FALSE;
@@ -0,0 +1,69 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    frequency,
+    cron_expression,
+    start_at,
+    next_run_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListDueScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE status = 'active' AND next_run_at <= sqlc.arg(now)
+ORDER BY next_run_at
+LIMIT sqlc.arg(limit_count);
+
+-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET
+    -- use the new value if provided, otherwise keep the existing field value
+    amount = COALESCE(sqlc.narg(amount), amount),
+    frequency = COALESCE(sqlc.narg(frequency), frequency),
+    cron_expression = COALESCE(sqlc.narg(cron_expression), cron_expression),
+    start_at = COALESCE(sqlc.narg(start_at), start_at),
+    next_run_at = COALESCE(sqlc.narg(next_run_at), next_run_at),
+    status = COALESCE(sqlc.narg(status), status),
+    updated_at = now()
+WHERE
+    id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+    scheduled_transfer_id,
+    scheduled_for,
+    attempt,
+    transfer_id,
+    status,
+    error
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListScheduledTransferRuns :many
+SELECT * FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
@@ -6,11 +6,61 @@ INSERT INTO sessions (
     user_agent,
     client_ip,
     is_blocked,
-    expires_at
+    expires_at,
+    family_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING *;
 
 -- name: GetSession :one
 SELECT * FROM sessions
 WHERE id = $1 LIMIT 1;
+
+-- name: GetSessionForUpdate :one
+SELECT * FROM sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListSessions :many
+SELECT * FROM sessions
+WHERE username = $1
+AND rotated_at IS NULL
+AND expires_at > now()
+ORDER BY created_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: BlockSession :one
+UPDATE sessions
+SET is_blocked = true
+WHERE id = sqlc.arg(id)
+AND username = sqlc.arg(username)
+RETURNING *;
+
+-- name: BlockOtherSessions :many
+UPDATE sessions
+SET is_blocked = true
+WHERE username = sqlc.arg(username)
+AND id <> sqlc.arg(current_id)
+AND is_blocked = false
+AND rotated_at IS NULL
+AND expires_at > now()
+RETURNING *;
+
+-- name: RotateSession :one
+UPDATE sessions
+SET rotated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: BlockSessionFamily :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1;
+
+-- name: BlockAllSessions :many
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1
+AND is_blocked = false
+RETURNING *;
@@ -0,0 +1,25 @@
+-- name: CreateStatementExport :one
+INSERT INTO statement_exports (
+    id,
+    username,
+    account_id,
+    format,
+    from_time,
+    to_time
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetStatementExport :one
+SELECT * FROM statement_exports
+WHERE id = $1 LIMIT 1;
+
+-- name: CompleteStatementExport :one
+UPDATE statement_exports
+SET
+    status = sqlc.arg(status),
+    content = sqlc.arg(content),
+    error = sqlc.arg(error),
+    completed_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
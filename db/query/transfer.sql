@@ -6,11 +6,30 @@ INSERT INTO transfers(
 )
 RETURNING *;
 
+-- name: CreateTransferReversal :one
+INSERT INTO transfers(
+    from_account_id, to_account_id, amount, reversal_of
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
 -- name: GetTranfer :one
 SELECT * FROM transfers
 WHERE id = $1
 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetTransferReversal :one
+SELECT * FROM transfers
+WHERE reversal_of = sqlc.arg(transfer_id)::bigint
+LIMIT 1;
+
 -- name: ListTransfers :many
 SELECT * FROM transfers
 WHERE from_account_id = $1 OR to_account_id = $2
@@ -13,7 +13,7 @@ const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, is_frozen, held_balance, available_balance
 `
 
 type AddAccountBalanceParams struct {
@@ -30,6 +30,37 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.IsFrozen,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+	)
+	return i, err
+}
+
+const addAccountHeldBalance = `-- name: AddAccountHeldBalance :one
+UPDATE accounts
+SET held_balance = held_balance + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, is_frozen, held_balance, available_balance
+`
+
+type AddAccountHeldBalanceParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddAccountHeldBalance(ctx context.Context, arg AddAccountHeldBalanceParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, addAccountHeldBalance, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.IsFrozen,
+		&i.HeldBalance,
+		&i.AvailableBalance,
 	)
 	return i, err
 }
@@ -39,7 +70,7 @@ INSERT INTO accounts(
     owner,
     balance,
     currency
-) VALUES ($1, $2, $3) RETURNING id, owner, balance, currency, created_at
+) VALUES ($1, $2, $3) RETURNING id, owner, balance, currency, created_at, is_frozen, held_balance, available_balance
 `
 
 type CreateAccountParams struct {
@@ -57,6 +88,9 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.IsFrozen,
+		&i.HeldBalance,
+		&i.AvailableBalance,
 	)
 	return i, err
 }
@@ -72,7 +106,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts 
+SELECT id, owner, balance, currency, created_at, is_frozen, held_balance, available_balance FROM accounts 
 WHERE id = $1 LIMIT 1
 `
 
@@ -85,12 +119,15 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.IsFrozen,
+		&i.HeldBalance,
+		&i.AvailableBalance,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts 
+SELECT id, owner, balance, currency, created_at, is_frozen, held_balance, available_balance FROM accounts 
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
 `
 
@@ -103,12 +140,15 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.IsFrozen,
+		&i.HeldBalance,
+		&i.AvailableBalance,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, is_frozen, held_balance, available_balance FROM accounts
 WHERE owner = $1
 ORDER BY id
 LIMIT $2
@@ -136,6 +176,9 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.IsFrozen,
+			&i.HeldBalance,
+			&i.AvailableBalance,
 		); err != nil {
 			return nil, err
 		}
@@ -154,7 +197,7 @@ const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts 
 SET balance = $2 
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, is_frozen, held_balance, available_balance
 `
 
 type UpdateAccountParams struct {
@@ -171,6 +214,37 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.IsFrozen,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+	)
+	return i, err
+}
+
+const updateAccountFrozen = `-- name: UpdateAccountFrozen :one
+UPDATE accounts
+SET is_frozen = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, is_frozen, held_balance, available_balance
+`
+
+type UpdateAccountFrozenParams struct {
+	IsFrozen bool  `json:"is_frozen"`
+	ID       int64 `json:"id"`
+}
+
+func (q *Queries) UpdateAccountFrozen(ctx context.Context, arg UpdateAccountFrozenParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountFrozen, arg.IsFrozen, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.IsFrozen,
+		&i.HeldBalance,
+		&i.AvailableBalance,
 	)
 	return i, err
 }
@@ -43,6 +43,25 @@ func createRandomAccount(t *testing.T) Account {
 	return account
 }
 
+// createRandomAccountWithBalance creates an account for a new random user holding exactly the given balance.
+// Transfer tests use it so the sender always has a known amount of funds available.
+func createRandomAccountWithBalance(t *testing.T, balance int64) Account {
+	user := createRandomUser(t)
+
+	arg := CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  balance,
+		Currency: util.RandomCurrency(),
+	}
+
+	account, err := testQueries.CreateAccount(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, account)
+	require.Equal(t, balance, account.Balance)
+
+	return account
+}
+
 // TestCreateAccount tests the basic account creation functionality
 // This test verifies that we can successfully create an account with valid data
 func TestCreateAccount(t *testing.T) {
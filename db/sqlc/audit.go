@@ -0,0 +1,205 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AuditContext describes who made a change and where the request came from.
+// The transactions that change security settings or move money record it in audit_events along with the change.
+type AuditContext struct {
+	// Actor is the username that made the change, or AuditActorSystem for background jobs
+	Actor     string
+	ClientIP  string
+	UserAgent string
+	// RequestID matches the event with the request logs
+	RequestID string
+}
+
+// AuditActorSystem is the actor of changes made by background jobs, e.g. scheduled transfers.
+const AuditActorSystem = "system"
+
+// Actions recorded in the audit log
+const (
+	AuditActionUserCreate        = "user.create"
+	AuditActionUserUpdate        = "user.update"
+	AuditActionUserRoleChange    = "user.role_change"
+	AuditActionUserPasswordReset = "user.password_reset"
+	AuditActionUserMFADisable    = "user.mfa_disable"
+	AuditActionSessionCreate     = "session.create"
+	AuditActionSessionRenew      = "session.renew"
+	AuditActionTransferCreate    = "transfer.create"
+	AuditActionTransferConvert   = "transfer.convert"
+	AuditActionTransferReverse   = "transfer.reverse"
+	AuditActionHoldPlace         = "hold.place"
+	AuditActionHoldCapture       = "hold.capture"
+	AuditActionHoldRelease       = "hold.release"
+	AuditActionHoldExpire        = "hold.expire"
+	AuditActionAccountFreeze     = "account.freeze"
+	AuditActionAccountUnfreeze   = "account.unfreeze"
+)
+
+// Resource types recorded in the audit log
+const (
+	AuditResourceUser     = "user"
+	AuditResourceSession  = "session"
+	AuditResourceTransfer = "transfer"
+	AuditResourceHold     = "hold"
+	AuditResourceAccount  = "account"
+)
+
+// AuditActions and AuditResourceTypes list the values the audit log can be filtered by.
+var (
+	AuditActions = []string{
+		AuditActionUserCreate,
+		AuditActionUserUpdate,
+		AuditActionUserRoleChange,
+		AuditActionUserPasswordReset,
+		AuditActionUserMFADisable,
+		AuditActionSessionCreate,
+		AuditActionSessionRenew,
+		AuditActionTransferCreate,
+		AuditActionTransferConvert,
+		AuditActionTransferReverse,
+		AuditActionHoldPlace,
+		AuditActionHoldCapture,
+		AuditActionHoldRelease,
+		AuditActionHoldExpire,
+		AuditActionAccountFreeze,
+		AuditActionAccountUnfreeze,
+	}
+	AuditResourceTypes = []string{
+		AuditResourceUser,
+		AuditResourceSession,
+		AuditResourceTransfer,
+		AuditResourceHold,
+		AuditResourceAccount,
+	}
+)
+
+// auditUser is the snapshot of a user in the audit log. The password hash is left out,
+// a change of password shows as a new password_changed_at.
+type auditUser struct {
+	Username          string    `json:"username"`
+	Role              string    `json:"role"`
+	FullName          string    `json:"full_name"`
+	Email             string    `json:"email"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+}
+
+func newAuditUser(user User) auditUser {
+	return auditUser{
+		Username:          user.Username,
+		Role:              user.Role,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		IsEmailVerified:   user.IsEmailVerified,
+		PasswordChangedAt: user.PasswordChangedAt,
+	}
+}
+
+// auditMFA is the snapshot of the two-factor settings of a user in the audit log. The secret is left out.
+type auditMFA struct {
+	Enabled     bool         `json:"enabled"`
+	ConfirmedAt sql.NullTime `json:"confirmed_at"`
+}
+
+// auditSession is the snapshot of a session in the audit log. The refresh token is left out,
+// anyone who can read the log must not be able to use it.
+type auditSession struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	FamilyID  string    `json:"family_id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func newAuditSession(session Session) auditSession {
+	return auditSession{
+		ID:        session.ID.String(),
+		Username:  session.Username,
+		FamilyID:  session.FamilyID.String(),
+		UserAgent: session.UserAgent,
+		ClientIP:  session.ClientIp,
+		ExpiresAt: session.ExpiresAt,
+	}
+}
+
+// auditAccounts is the snapshot of the accounts a transfer moved money between.
+type auditAccounts struct {
+	FromAccount Account `json:"from_account"`
+	ToAccount   Account `json:"to_account"`
+}
+
+// auditHold is the snapshot of a hold and the account it reserves funds on.
+// ToAccount is only set when capturing the hold moved money to the payee.
+type auditHold struct {
+	Hold      *Hold    `json:"hold,omitempty"`
+	Account   *Account `json:"account,omitempty"`
+	ToAccount *Account `json:"to_account,omitempty"`
+}
+
+// recordAuditEvent adds an event to the audit log within the transaction of q, so the event is only
+// recorded if the change is committed. A nil before snapshot is stored as an empty object.
+func recordAuditEvent(
+	ctx context.Context,
+	q *Queries,
+	audit AuditContext,
+	action string,
+	resourceType string,
+	resourceID string,
+	before any,
+	after any,
+) error {
+
+	beforeJSON, err := auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+
+	afterJSON, err := auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	actor := audit.Actor
+	if actor == "" {
+		actor = AuditActorSystem
+	}
+
+	_, err = q.CreateAuditEvent(ctx, CreateAuditEventParams{
+		Actor:        actor,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Before:       beforeJSON,
+		After:        afterJSON,
+		ClientIp:     audit.ClientIP,
+		UserAgent:    audit.UserAgent,
+		RequestID:    audit.RequestID,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	return nil
+}
+
+func auditSnapshot(snapshot any) (json.RawMessage, error) {
+	if snapshot == nil {
+		return json.RawMessage("{}"), nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit snapshot: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: audit_event.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const createAuditEvent = `-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+    actor,
+    action,
+    resource_type,
+    resource_id,
+    before,
+    after,
+    client_ip,
+    user_agent,
+    request_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, actor, action, resource_type, resource_id, before, after, client_ip, user_agent, request_id, created_at
+`
+
+type CreateAuditEventParams struct {
+	Actor        string          `json:"actor"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id"`
+	Before       json.RawMessage `json:"before"`
+	After        json.RawMessage `json:"after"`
+	ClientIp     string          `json:"client_ip"`
+	UserAgent    string          `json:"user_agent"`
+	RequestID    string          `json:"request_id"`
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error) {
+	row := q.db.QueryRowContext(ctx, createAuditEvent,
+		arg.Actor,
+		arg.Action,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.Before,
+		arg.After,
+		arg.ClientIp,
+		arg.UserAgent,
+		arg.RequestID,
+	)
+	var i AuditEvent
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.ResourceType,
+		&i.ResourceID,
+		&i.Before,
+		&i.After,
+		&i.ClientIp,
+		&i.UserAgent,
+		&i.RequestID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, actor, action, resource_type, resource_id, before, after, client_ip, user_agent, request_id, created_at FROM audit_events
+WHERE ($1::varchar IS NULL OR actor = $1)
+AND ($2::varchar IS NULL OR action = $2)
+AND ($3::varchar IS NULL OR resource_type = $3)
+AND ($4::varchar IS NULL OR resource_id = $4)
+AND ($5::timestamptz IS NULL OR created_at >= $5)
+AND ($6::timestamptz IS NULL OR created_at < $6)
+ORDER BY id DESC
+LIMIT $8
+OFFSET $7
+`
+
+type ListAuditEventsParams struct {
+	Actor         sql.NullString `json:"actor"`
+	Action        sql.NullString `json:"action"`
+	ResourceType  sql.NullString `json:"resource_type"`
+	ResourceID    sql.NullString `json:"resource_id"`
+	CreatedAfter  sql.NullTime   `json:"created_after"`
+	CreatedBefore sql.NullTime   `json:"created_before"`
+	Offset        int32          `json:"offset"`
+	Limit         int32          `json:"limit"`
+}
+
+// every filter is optional and narrows the list when set
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEvents,
+		arg.Actor,
+		arg.Action,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.Before,
+			&i.After,
+			&i.ClientIp,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+// latestAuditEvent returns the newest event recorded for the resource.
+func latestAuditEvent(t *testing.T, resourceType string, resourceID string) AuditEvent {
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		ResourceType: sql.NullString{String: resourceType, Valid: true},
+		ResourceID:   sql.NullString{String: resourceID, Valid: true},
+		Limit:        1,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	return events[0]
+}
+
+func TestUpdateUserTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	admin := util.RandomOwner()
+
+	audit := AuditContext{Actor: admin, ClientIP: "10.0.0.1", UserAgent: "test", RequestID: util.RandomString(12)}
+
+	result, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			Role:     sql.NullString{String: util.BankerRole, Valid: true},
+		},
+		Audit: audit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.BankerRole, result.User.Role)
+
+	event := latestAuditEvent(t, AuditResourceUser, user.Username)
+	require.Equal(t, admin, event.Actor)
+	require.Equal(t, AuditActionUserRoleChange, event.Action)
+	require.Equal(t, audit.RequestID, event.RequestID)
+	require.Equal(t, audit.ClientIP, event.ClientIp)
+
+	var before, after auditUser
+	require.NoError(t, json.Unmarshal(event.Before, &before))
+	require.NoError(t, json.Unmarshal(event.After, &after))
+	require.Equal(t, user.Role, before.Role)
+	require.Equal(t, util.BankerRole, after.Role)
+
+	//! the password hash never ends up in the log
+	require.NotContains(t, string(event.Before), user.HashedPassword)
+
+	//? a change that leaves the role alone is a plain update
+	_, err = store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			FullName: sql.NullString{String: util.RandomOwner(), Valid: true},
+		},
+		Audit: audit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AuditActionUserUpdate, latestAuditEvent(t, AuditResourceUser, user.Username).Action)
+}
+
+func TestTransferTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+		Audit:         AuditContext{Actor: account1.Owner},
+	})
+	require.NoError(t, err)
+
+	event := latestAuditEvent(t, AuditResourceTransfer, strconv.FormatInt(result.Transfer.ID, 10))
+	require.Equal(t, AuditActionTransferCreate, event.Action)
+	require.Equal(t, account1.Owner, event.Actor)
+
+	var before, after auditAccounts
+	require.NoError(t, json.Unmarshal(event.Before, &before))
+	require.NoError(t, json.Unmarshal(event.After, &after))
+	require.Equal(t, account1.Balance, before.FromAccount.Balance)
+	require.Equal(t, account1.Balance-1, after.FromAccount.Balance)
+}
+
+func TestSetAccountFrozenTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+	banker := util.RandomOwner()
+
+	result, err := store.SetAccountFrozenTx(context.Background(), SetAccountFrozenTxParams{
+		AccountID: account.ID,
+		IsFrozen:  true,
+		Audit:     AuditContext{Actor: banker},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Account.IsFrozen)
+
+	event := latestAuditEvent(t, AuditResourceAccount, strconv.FormatInt(account.ID, 10))
+	require.Equal(t, AuditActionAccountFreeze, event.Action)
+	require.Equal(t, banker, event.Actor)
+
+	var before, after Account
+	require.NoError(t, json.Unmarshal(event.Before, &before))
+	require.NoError(t, json.Unmarshal(event.After, &after))
+	require.False(t, before.IsFrozen)
+	require.True(t, after.IsFrozen)
+
+	_, err = store.SetAccountFrozenTx(context.Background(), SetAccountFrozenTxParams{
+		AccountID: account.ID,
+		IsFrozen:  false,
+		Audit:     AuditContext{Actor: banker},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AuditActionAccountUnfreeze, latestAuditEvent(t, AuditResourceAccount, strconv.FormatInt(account.ID, 10)).Action)
+
+	//? an unknown account is not found and nothing is recorded
+	_, err = store.SetAccountFrozenTx(context.Background(), SetAccountFrozenTxParams{AccountID: -1, IsFrozen: true})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestAuditEventsAppendOnly(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	_, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			FullName: sql.NullString{String: util.RandomOwner(), Valid: true},
+		},
+	})
+	require.NoError(t, err)
+
+	event := latestAuditEvent(t, AuditResourceUser, user.Username)
+	//? a change without an actor is attributed to the system
+	require.Equal(t, AuditActorSystem, event.Actor)
+
+	//! the triggers reject every change to a recorded event
+	_, err = testDB.ExecContext(context.Background(), "UPDATE audit_events SET actor = 'someone' WHERE id = $1", event.ID)
+	require.Error(t, err)
+
+	_, err = testDB.ExecContext(context.Background(), "DELETE FROM audit_events WHERE id = $1", event.ID)
+	require.Error(t, err)
+
+	_, err = testDB.ExecContext(context.Background(), "TRUNCATE audit_events")
+	require.Error(t, err)
+}
@@ -0,0 +1,259 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: fx.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createFxConversion = `-- name: CreateFxConversion :one
+INSERT INTO fx_conversions (
+    transfer_id,
+    quote_id,
+    from_currency,
+    to_currency,
+    from_amount,
+    to_amount,
+    rate,
+    spread_bps
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING transfer_id, quote_id, from_currency, to_currency, from_amount, to_amount, rate, spread_bps, created_at
+`
+
+type CreateFxConversionParams struct {
+	TransferID   int64     `json:"transfer_id"`
+	QuoteID      uuid.UUID `json:"quote_id"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	FromAmount   int64     `json:"from_amount"`
+	ToAmount     int64     `json:"to_amount"`
+	Rate         int64     `json:"rate"`
+	SpreadBps    int64     `json:"spread_bps"`
+}
+
+func (q *Queries) CreateFxConversion(ctx context.Context, arg CreateFxConversionParams) (FxConversion, error) {
+	row := q.db.QueryRowContext(ctx, createFxConversion,
+		arg.TransferID,
+		arg.QuoteID,
+		arg.FromCurrency,
+		arg.ToCurrency,
+		arg.FromAmount,
+		arg.ToAmount,
+		arg.Rate,
+		arg.SpreadBps,
+	)
+	var i FxConversion
+	err := row.Scan(
+		&i.TransferID,
+		&i.QuoteID,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.FromAmount,
+		&i.ToAmount,
+		&i.Rate,
+		&i.SpreadBps,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createFxQuote = `-- name: CreateFxQuote :one
+INSERT INTO fx_quotes (
+    id,
+    username,
+    from_currency,
+    to_currency,
+    rate,
+    spread_bps,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, username, from_currency, to_currency, rate, spread_bps, is_used, expires_at, created_at
+`
+
+type CreateFxQuoteParams struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	Rate         int64     `json:"rate"`
+	SpreadBps    int64     `json:"spread_bps"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateFxQuote(ctx context.Context, arg CreateFxQuoteParams) (FxQuote, error) {
+	row := q.db.QueryRowContext(ctx, createFxQuote,
+		arg.ID,
+		arg.Username,
+		arg.FromCurrency,
+		arg.ToCurrency,
+		arg.Rate,
+		arg.SpreadBps,
+		arg.ExpiresAt,
+	)
+	var i FxQuote
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.Rate,
+		&i.SpreadBps,
+		&i.IsUsed,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFxConversion = `-- name: GetFxConversion :one
+SELECT transfer_id, quote_id, from_currency, to_currency, from_amount, to_amount, rate, spread_bps, created_at FROM fx_conversions
+WHERE transfer_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetFxConversion(ctx context.Context, transferID int64) (FxConversion, error) {
+	row := q.db.QueryRowContext(ctx, getFxConversion, transferID)
+	var i FxConversion
+	err := row.Scan(
+		&i.TransferID,
+		&i.QuoteID,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.FromAmount,
+		&i.ToAmount,
+		&i.Rate,
+		&i.SpreadBps,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFxQuote = `-- name: GetFxQuote :one
+SELECT id, username, from_currency, to_currency, rate, spread_bps, is_used, expires_at, created_at FROM fx_quotes
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetFxQuote(ctx context.Context, id uuid.UUID) (FxQuote, error) {
+	row := q.db.QueryRowContext(ctx, getFxQuote, id)
+	var i FxQuote
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.Rate,
+		&i.SpreadBps,
+		&i.IsUsed,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFxQuoteForUpdate = `-- name: GetFxQuoteForUpdate :one
+SELECT id, username, from_currency, to_currency, rate, spread_bps, is_used, expires_at, created_at FROM fx_quotes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetFxQuoteForUpdate(ctx context.Context, id uuid.UUID) (FxQuote, error) {
+	row := q.db.QueryRowContext(ctx, getFxQuoteForUpdate, id)
+	var i FxQuote
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.Rate,
+		&i.SpreadBps,
+		&i.IsUsed,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFxRate = `-- name: GetFxRate :one
+SELECT from_currency, to_currency, rate, updated_at FROM fx_rates
+WHERE from_currency = $1 AND to_currency = $2
+LIMIT 1
+`
+
+type GetFxRateParams struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+}
+
+func (q *Queries) GetFxRate(ctx context.Context, arg GetFxRateParams) (FxRate, error) {
+	row := q.db.QueryRowContext(ctx, getFxRate, arg.FromCurrency, arg.ToCurrency)
+	var i FxRate
+	err := row.Scan(
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.Rate,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const markFxQuoteUsed = `-- name: MarkFxQuoteUsed :one
+UPDATE fx_quotes
+SET is_used = true
+WHERE id = $1
+RETURNING id, username, from_currency, to_currency, rate, spread_bps, is_used, expires_at, created_at
+`
+
+func (q *Queries) MarkFxQuoteUsed(ctx context.Context, id uuid.UUID) (FxQuote, error) {
+	row := q.db.QueryRowContext(ctx, markFxQuoteUsed, id)
+	var i FxQuote
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.Rate,
+		&i.SpreadBps,
+		&i.IsUsed,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertFxRate = `-- name: UpsertFxRate :one
+INSERT INTO fx_rates (
+    from_currency,
+    to_currency,
+    rate
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (from_currency, to_currency)
+DO UPDATE SET rate = EXCLUDED.rate, updated_at = now()
+RETURNING from_currency, to_currency, rate, updated_at
+`
+
+type UpsertFxRateParams struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	Rate         int64  `json:"rate"`
+}
+
+func (q *Queries) UpsertFxRate(ctx context.Context, arg UpsertFxRateParams) (FxRate, error) {
+	row := q.db.QueryRowContext(ctx, upsertFxRate, arg.FromCurrency, arg.ToCurrency, arg.Rate)
+	var i FxRate
+	err := row.Scan(
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.Rate,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
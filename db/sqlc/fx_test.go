@@ -0,0 +1,209 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomFxQuote creates an unused quote owned by username that converts between the given currencies.
+func createRandomFxQuote(t *testing.T, username, fromCurrency, toCurrency string, rate int64) FxQuote {
+	arg := CreateFxQuoteParams{
+		ID:           uuid.New(),
+		Username:     username,
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         rate,
+		SpreadBps:    50,
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+
+	quote, err := testQueries.CreateFxQuote(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, quote.ID)
+	require.Equal(t, arg.Username, quote.Username)
+	require.Equal(t, arg.Rate, quote.Rate)
+	require.False(t, quote.IsUsed)
+
+	return quote
+}
+
+// createAccountInCurrency creates an account for a new random user holding the given balance and currency.
+func createAccountInCurrency(t *testing.T, balance int64, currency string) Account {
+	user := createRandomUser(t)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  balance,
+		Currency: currency,
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestUpsertFxRate(t *testing.T) {
+	arg := UpsertFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         80_000_000,
+	}
+
+	rate1, err := testQueries.UpsertFxRate(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Rate, rate1.Rate)
+
+	//? a second upsert for the same pair overwrites the rate
+	arg.Rate = 90_000_000
+	rate2, err := testQueries.UpsertFxRate(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Rate, rate2.Rate)
+
+	rate3, err := testQueries.GetFxRate(context.Background(), GetFxRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+	})
+	require.NoError(t, err)
+	require.Equal(t, rate2.Rate, rate3.Rate)
+}
+
+func TestConvertTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	amount := int64(100)
+	rate := int64(125_000_000)
+
+	account1 := createAccountInCurrency(t, 10*amount, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.CAD)
+	quote := createRandomFxQuote(t, account1.Owner, util.USD, util.CAD, rate)
+
+	arg := ConvertTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		QuoteID:       quote.ID,
+		Username:      account1.Owner,
+	}
+
+	result, err := store.ConvertTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	toAmount := util.ConvertAmount(amount, rate)
+
+	require.Equal(t, -amount, result.FromEntry.Amount)
+	require.Equal(t, toAmount, result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+toAmount, result.ToAccount.Balance)
+
+	require.Equal(t, result.Transfer.ID, result.Conversion.TransferID)
+	require.Equal(t, quote.ID, result.Conversion.QuoteID)
+	require.Equal(t, amount, result.Conversion.FromAmount)
+	require.Equal(t, toAmount, result.Conversion.ToAmount)
+	require.Equal(t, rate, result.Conversion.Rate)
+
+	//? the quote is consumed by the transfer
+	_, err = store.ConvertTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrQuoteUsed)
+
+	updatedQuote, err := testQueries.GetFxQuote(context.Background(), quote.ID)
+	require.NoError(t, err)
+	require.True(t, updatedQuote.IsUsed)
+}
+
+func TestConvertTransferTxRejectsQuote(t *testing.T) {
+	store := NewStore(testDB)
+
+	amount := int64(100)
+
+	account1 := createAccountInCurrency(t, 10*amount, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.EUR)
+
+	testCases := []struct {
+		name   string
+		quote  func() FxQuote
+		amount int64
+		err    error
+	}{
+		{
+			name: "WrongCurrencies",
+			quote: func() FxQuote {
+				return createRandomFxQuote(t, account1.Owner, util.USD, util.CAD, 125_000_000)
+			},
+			amount: amount,
+			err:    ErrQuoteMismatch,
+		},
+		{
+			name: "OtherUser",
+			quote: func() FxQuote {
+				return createRandomFxQuote(t, account2.Owner, util.USD, util.EUR, 80_000_000)
+			},
+			amount: amount,
+			err:    ErrQuoteMismatch,
+		},
+		{
+			name: "Expired",
+			quote: func() FxQuote {
+				quote, err := testQueries.CreateFxQuote(context.Background(), CreateFxQuoteParams{
+					ID:           uuid.New(),
+					Username:     account1.Owner,
+					FromCurrency: util.USD,
+					ToCurrency:   util.EUR,
+					Rate:         80_000_000,
+					ExpiresAt:    time.Now().Add(-time.Minute),
+				})
+				require.NoError(t, err)
+				return quote
+			},
+			amount: amount,
+			err:    ErrQuoteExpired,
+		},
+		{
+			name: "InsufficientFunds",
+			quote: func() FxQuote {
+				return createRandomFxQuote(t, account1.Owner, util.USD, util.EUR, 80_000_000)
+			},
+			amount: 20 * amount,
+			err:    ErrInsufficientFunds,
+		},
+		{
+			name: "TooSmall",
+			quote: func() FxQuote {
+				return createRandomFxQuote(t, account1.Owner, util.USD, util.EUR, 80_000_000)
+			},
+			amount: 1,
+			err:    ErrConversionTooSmall,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.ConvertTransferTx(context.Background(), ConvertTransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        tc.amount,
+				QuoteID:       tc.quote().ID,
+				Username:      account1.Owner,
+			})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	//? nothing moved
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	_, err = store.ConvertTransferTx(context.Background(), ConvertTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		QuoteID:       uuid.New(),
+		Username:      account1.Owner,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,198 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: hold.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const closeHold = `-- name: CloseHold :one
+UPDATE holds
+SET
+    status = $1,
+    transfer_id = $2,
+    captured_amount = $3,
+    closed_at = now()
+WHERE id = $4
+RETURNING id, owner, account_id, to_account_id, amount, status, expires_at, transfer_id, captured_amount, created_at, closed_at
+`
+
+type CloseHoldParams struct {
+	Status         string        `json:"status"`
+	TransferID     sql.NullInt64 `json:"transfer_id"`
+	CapturedAmount int64         `json:"captured_amount"`
+	ID             int64         `json:"id"`
+}
+
+func (q *Queries) CloseHold(ctx context.Context, arg CloseHoldParams) (Hold, error) {
+	row := q.db.QueryRowContext(ctx, closeHold,
+		arg.Status,
+		arg.TransferID,
+		arg.CapturedAmount,
+		arg.ID,
+	)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TransferID,
+		&i.CapturedAmount,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const createHold = `-- name: CreateHold :one
+INSERT INTO holds (
+    owner,
+    account_id,
+    to_account_id,
+    amount,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, owner, account_id, to_account_id, amount, status, expires_at, transfer_id, captured_amount, created_at, closed_at
+`
+
+type CreateHoldParams struct {
+	Owner       string    `json:"owner"`
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error) {
+	row := q.db.QueryRowContext(ctx, createHold,
+		arg.Owner,
+		arg.AccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ExpiresAt,
+	)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TransferID,
+		&i.CapturedAmount,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getHold = `-- name: GetHold :one
+SELECT id, owner, account_id, to_account_id, amount, status, expires_at, transfer_id, captured_amount, created_at, closed_at FROM holds
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetHold(ctx context.Context, id int64) (Hold, error) {
+	row := q.db.QueryRowContext(ctx, getHold, id)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TransferID,
+		&i.CapturedAmount,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getHoldForUpdate = `-- name: GetHoldForUpdate :one
+SELECT id, owner, account_id, to_account_id, amount, status, expires_at, transfer_id, captured_amount, created_at, closed_at FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetHoldForUpdate(ctx context.Context, id int64) (Hold, error) {
+	row := q.db.QueryRowContext(ctx, getHoldForUpdate, id)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TransferID,
+		&i.CapturedAmount,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const listExpiredHoldsForUpdate = `-- name: ListExpiredHoldsForUpdate :many
+SELECT id, owner, account_id, to_account_id, amount, status, expires_at, transfer_id, captured_amount, created_at, closed_at FROM holds
+WHERE status = 'active' AND expires_at <= $1
+ORDER BY account_id, id
+LIMIT $2
+FOR NO KEY UPDATE SKIP LOCKED
+`
+
+type ListExpiredHoldsForUpdateParams struct {
+	Now        time.Time `json:"now"`
+	LimitCount int32     `json:"limit_count"`
+}
+
+// Holds locked by a capture or release are skipped, so the expiry job does not wait on them.
+// They are ordered by account so their accounts are updated in the same order transfers lock them.
+func (q *Queries) ListExpiredHoldsForUpdate(ctx context.Context, arg ListExpiredHoldsForUpdateParams) ([]Hold, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiredHoldsForUpdate, arg.Now, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Hold{}
+	for rows.Next() {
+		var i Hold
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.AccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TransferID,
+			&i.CapturedAmount,
+			&i.CreatedAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
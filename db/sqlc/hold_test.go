@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func placeHold(t *testing.T, store Store, account Account, toAccount Account, amount int64, expiresAt time.Time) Hold {
+	result, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		Owner:       account.Owner,
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, HoldActive, result.Hold.Status)
+	require.Equal(t, amount, result.Hold.Amount)
+	require.Equal(t, account.HeldBalance+amount, result.Account.HeldBalance)
+	require.Equal(t, account.Balance, result.Account.Balance)
+	require.Equal(t, result.Account.Balance-result.Account.HeldBalance, result.Account.AvailableBalance)
+
+	return result.Hold
+}
+
+func TestPlaceHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	placeHold(t, store, account1, account2, 70, time.Now().Add(time.Hour))
+
+	//? held funds can be neither held again nor spent
+	_, err := store.PlaceHoldTx(ctx, PlaceHoldTxParams{
+		Owner:       account1.Owner,
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      31,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.TransferTx(ctx, TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 31})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	result, err := store.TransferTx(ctx, TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 30})
+	require.NoError(t, err)
+	require.Equal(t, int64(70), result.FromAccount.Balance)
+	require.Zero(t, result.FromAccount.AvailableBalance)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	hold := placeHold(t, store, account1, account2, 60, time.Now().Add(time.Hour))
+
+	_, err := store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID, Amount: 61})
+	require.ErrorIs(t, err, ErrCaptureTooLarge)
+
+	result, err := store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID, Amount: 45})
+	require.NoError(t, err)
+
+	require.Equal(t, HoldCaptured, result.Hold.Status)
+	require.Equal(t, int64(45), result.Hold.CapturedAmount)
+	require.Equal(t, result.Transfer.ID, result.Hold.TransferID.Int64)
+	require.True(t, result.Hold.ClosedAt.Valid)
+
+	require.Equal(t, int64(45), result.Transfer.Amount)
+	require.Equal(t, int64(-45), result.FromEntry.Amount)
+	require.Equal(t, int64(45), result.ToEntry.Amount)
+
+	//? the rest of a partially captured hold is available again
+	require.Equal(t, int64(55), result.FromAccount.Balance)
+	require.Zero(t, result.FromAccount.HeldBalance)
+	require.Equal(t, int64(55), result.FromAccount.AvailableBalance)
+	require.Equal(t, int64(45), result.ToAccount.Balance)
+
+	_, err = store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestReleaseHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	hold := placeHold(t, store, account1, account2, 60, time.Now().Add(time.Hour))
+
+	result, err := store.ReleaseHoldTx(ctx, ReleaseHoldTxParams{HoldID: hold.ID})
+	require.NoError(t, err)
+	require.Equal(t, HoldReleased, result.Hold.Status)
+	require.False(t, result.Hold.TransferID.Valid)
+	require.Equal(t, int64(100), result.Account.Balance)
+	require.Equal(t, int64(100), result.Account.AvailableBalance)
+
+	_, err = store.ReleaseHoldTx(ctx, ReleaseHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+
+	_, err = store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestExpireHoldsTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	expired := placeHold(t, store, account1, account2, 30, time.Now().Add(time.Second))
+	active := placeHold(t, store, account1, account2, 20, time.Now().Add(time.Hour))
+
+	time.Sleep(time.Second)
+
+	//? an expired hold cannot be captured even before the job has released it
+	_, err := store.CaptureHoldTx(ctx, CaptureHoldTxParams{HoldID: expired.ID})
+	require.ErrorIs(t, err, ErrHoldExpired)
+
+	//? other tests may leave expired holds behind, only the two created here are checked
+	for {
+		result, err := store.ExpireHoldsTx(ctx, ExpireHoldsTxParams{Limit: 100})
+		require.NoError(t, err)
+
+		if len(result.Holds) < 100 {
+			break
+		}
+	}
+
+	hold, err := store.GetHold(ctx, expired.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldExpired, hold.Status)
+
+	hold, err = store.GetHold(ctx, active.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldActive, hold.Status)
+
+	account, err := store.GetAccount(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), account.HeldBalance)
+	require.Equal(t, int64(80), account.AvailableBalance)
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrIdempotencyKeyReused is returned when a client sends an idempotency key it already used for a different request.
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// IdempotencyParams identifies a client supplied idempotency key.
+// Keys are scoped to the user so two users can never collide on the same key.
+type IdempotencyParams struct {
+	Username string
+	Key      string
+}
+
+// requestHash fingerprints an operation and its parameters so that a reused key can be matched against the original request.
+func requestHash(operation string, arg any) (string, error) {
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	sum := sha256.Sum256(append([]byte(operation+":"), data...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// execIdempotentTx executes fn within a database transaction like execTx, and records result under the idempotency key.
+// If the key was already used for the same request, fn is skipped and the recorded result is decoded into result instead.
+// result must be a pointer. When key is nil this is a plain execTx.
+func (store *SQLStore) execIdempotentTx(
+	ctx context.Context,
+	key *IdempotencyParams,
+	operation string,
+	arg any,
+	result any,
+	fn func(*Queries) error,
+) error {
+
+	if key == nil {
+		return store.execTx(ctx, fn)
+	}
+
+	hash, err := requestHash(operation, arg)
+	if err != nil {
+		return err
+	}
+
+	return store.execTx(ctx, func(q *Queries) error {
+
+		//? a concurrent retry with the same key blocks on this insert until the first transaction finishes
+		_, err := q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+			Username:    key.Username,
+			Key:         key.Key,
+			RequestHash: hash,
+		})
+
+		//* key already recorded: replay the original response
+		if err == sql.ErrNoRows {
+			recorded, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+				Username: key.Username,
+				Key:      key.Key,
+			})
+			if err != nil {
+				return err
+			}
+
+			if recorded.RequestHash != hash {
+				return ErrIdempotencyKeyReused
+			}
+
+			return json.Unmarshal(recorded.Response, result)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		//* first time this key is seen: run the operation and record its result in the same transaction
+		if err = fn(q); err != nil {
+			return err
+		}
+
+		response, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		_, err = q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+			Username: key.Username,
+			Key:      key.Key,
+			Response: response,
+		})
+
+		return err
+	})
+}
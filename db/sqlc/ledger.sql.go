@@ -0,0 +1,257 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: ledger.sql
+
+package db
+
+import (
+	"context"
+)
+
+const countAccounts = `-- name: CountAccounts :one
+SELECT COUNT(*) FROM accounts
+`
+
+func (q *Queries) CountAccounts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAccounts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countLegacyEntries = `-- name: CountLegacyEntries :one
+SELECT COUNT(*) FROM entries
+WHERE transfer_id IS NULL
+`
+
+func (q *Queries) CountLegacyEntries(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLegacyEntries)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const listBalanceDrifts = `-- name: ListBalanceDrifts :many
+SELECT
+    a.id AS account_id,
+    a.owner,
+    a.currency,
+    a.balance,
+    COALESCE(e.total, 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN (
+    SELECT account_id, SUM(amount) AS total
+    FROM entries
+    GROUP BY account_id
+) e ON e.account_id = a.id
+WHERE a.balance <> COALESCE(e.total, 0)
+ORDER BY a.id
+`
+
+type ListBalanceDriftsRow struct {
+	AccountID    int64  `json:"account_id"`
+	Owner        string `json:"owner"`
+	Currency     string `json:"currency"`
+	Balance      int64  `json:"balance"`
+	EntriesTotal int64  `json:"entries_total"`
+}
+
+func (q *Queries) ListBalanceDrifts(ctx context.Context) ([]ListBalanceDriftsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBalanceDrifts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBalanceDriftsRow{}
+	for rows.Next() {
+		var i ListBalanceDriftsRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Owner,
+			&i.Currency,
+			&i.Balance,
+			&i.EntriesTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHeldBalanceDrifts = `-- name: ListHeldBalanceDrifts :many
+SELECT
+    a.id AS account_id,
+    a.owner,
+    a.currency,
+    a.held_balance,
+    COALESCE(h.total, 0)::bigint AS holds_total
+FROM accounts a
+LEFT JOIN (
+    SELECT account_id, SUM(amount) AS total
+    FROM holds
+    WHERE status = 'active'
+    GROUP BY account_id
+) h ON h.account_id = a.id
+WHERE a.held_balance <> COALESCE(h.total, 0)
+ORDER BY a.id
+`
+
+type ListHeldBalanceDriftsRow struct {
+	AccountID   int64  `json:"account_id"`
+	Owner       string `json:"owner"`
+	Currency    string `json:"currency"`
+	HeldBalance int64  `json:"held_balance"`
+	HoldsTotal  int64  `json:"holds_total"`
+}
+
+func (q *Queries) ListHeldBalanceDrifts(ctx context.Context) ([]ListHeldBalanceDriftsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listHeldBalanceDrifts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListHeldBalanceDriftsRow{}
+	for rows.Next() {
+		var i ListHeldBalanceDriftsRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Owner,
+			&i.Currency,
+			&i.HeldBalance,
+			&i.HoldsTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrphanEntries = `-- name: ListOrphanEntries :many
+SELECT e.id, e.account_id, e.amount, e.transfer_id, e.created_at
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.transfer_id IS NOT NULL
+    AND (t.id IS NULL OR e.account_id NOT IN (t.from_account_id, t.to_account_id))
+ORDER BY e.id
+`
+
+// Entries that their transfer does not explain: booked to an account that is not a party of it.
+// Entries recorded before transfers were tracked have no transfer_id and are counted by CountLegacyEntries instead.
+func (q *Queries) ListOrphanEntries(ctx context.Context) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listOrphanEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.TransferID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnbalancedTransfers = `-- name: ListUnbalancedTransfers :many
+SELECT
+    t.id AS transfer_id,
+    t.from_account_id,
+    t.to_account_id,
+    t.amount,
+    COALESCE(c.to_amount, div(t.amount::numeric * rc.from_amount, rc.to_amount), t.amount)::bigint AS expected_credit,
+    COALESCE(e.entry_count, 0)::bigint AS entry_count,
+    COALESCE(e.debit_total, 0)::bigint AS debit_total,
+    COALESCE(e.credit_total, 0)::bigint AS credit_total
+FROM transfers t
+LEFT JOIN fx_conversions c ON c.transfer_id = t.id
+LEFT JOIN fx_conversions rc ON rc.transfer_id = t.reversal_of
+LEFT JOIN (
+    SELECT
+        en.transfer_id,
+        COUNT(*) AS entry_count,
+        SUM(en.amount) FILTER (WHERE en.account_id = tr.from_account_id AND en.amount < 0) AS debit_total,
+        SUM(en.amount) FILTER (WHERE en.account_id = tr.to_account_id AND en.amount > 0) AS credit_total
+    FROM entries en
+    JOIN transfers tr ON tr.id = en.transfer_id
+    GROUP BY en.transfer_id
+) e ON e.transfer_id = t.id
+WHERE COALESCE(e.entry_count, 0) <> 2
+    OR COALESCE(e.debit_total, 0) <> -t.amount
+    OR COALESCE(e.credit_total, 0) <> COALESCE(c.to_amount, div(t.amount::numeric * rc.from_amount, rc.to_amount), t.amount)
+ORDER BY t.id
+`
+
+type ListUnbalancedTransfersRow struct {
+	TransferID     int64 `json:"transfer_id"`
+	FromAccountID  int64 `json:"from_account_id"`
+	ToAccountID    int64 `json:"to_account_id"`
+	Amount         int64 `json:"amount"`
+	ExpectedCredit int64 `json:"expected_credit"`
+	EntryCount     int64 `json:"entry_count"`
+	DebitTotal     int64 `json:"debit_total"`
+	CreditTotal    int64 `json:"credit_total"`
+}
+
+// A transfer must debit the sender by its amount and credit the receiver by the same amount,
+// or by the converted amount recorded in fx_conversions for a cross-currency transfer.
+// A reversal of a cross-currency transfer credits the same share of the original debit, rounded down.
+func (q *Queries) ListUnbalancedTransfers(ctx context.Context) ([]ListUnbalancedTransfersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUnbalancedTransfers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUnbalancedTransfersRow{}
+	for rows.Next() {
+		var i ListUnbalancedTransfersRow
+		if err := rows.Scan(
+			&i.TransferID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.ExpectedCredit,
+			&i.EntryCount,
+			&i.DebitTotal,
+			&i.CreditTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
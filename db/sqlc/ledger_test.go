@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLedgerTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	//? test accounts are funded without entries, so they drift by their opening balance
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	transfer, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        40,
+	})
+	require.NoError(t, err)
+
+	misbooked, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	//? an entry recorded before migration 000007 has no transfer
+	legacy, err := testQueries.CreateEntry(ctx, CreateEntryParams{
+		AccountID: account2.ID,
+		Amount:    5,
+	})
+	require.NoError(t, err)
+
+	account3 := createRandomAccountWithBalance(t, 0)
+
+	orphan, err := testQueries.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  account3.ID,
+		Amount:     5,
+		TransferID: sql.NullInt64{Int64: misbooked.Transfer.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	result, err := store.VerifyLedgerTx(ctx)
+	require.NoError(t, err)
+	require.Positive(t, result.AccountsChecked)
+
+	drifts := map[int64]ListBalanceDriftsRow{}
+	for _, drift := range result.BalanceDrifts {
+		drifts[drift.AccountID] = drift
+	}
+
+	require.Equal(t, int64(100), drifts[account1.ID].Balance-drifts[account1.ID].EntriesTotal)
+
+	//? the legacy entry is not reflected in the balance of account2
+	require.Equal(t, int64(50), drifts[account2.ID].Balance)
+	require.Equal(t, int64(55), drifts[account2.ID].EntriesTotal)
+
+	for _, unbalanced := range result.UnbalancedTransfers {
+		require.NotEqual(t, transfer.Transfer.ID, unbalanced.TransferID)
+	}
+
+	//* legacy entries are counted, not reported as orphans
+	require.Positive(t, result.LegacyEntries)
+
+	var found bool
+	for _, entry := range result.OrphanEntries {
+		require.NotEqual(t, legacy.ID, entry.ID)
+
+		if entry.ID == orphan.ID {
+			found = true
+			require.Equal(t, misbooked.Transfer.ID, entry.TransferID.Int64)
+		}
+	}
+	require.True(t, found)
+}
@@ -0,0 +1,197 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: mfa.sql
+
+package db
+
+import (
+	"context"
+)
+
+const confirmUserMFA = `-- name: ConfirmUserMFA :one
+UPDATE user_mfa
+SET
+    confirmed_at = now(),
+    last_used_step = $1
+WHERE
+    username = $2
+    AND confirmed_at IS NULL
+RETURNING username, encrypted_secret, confirmed_at, last_used_step, created_at
+`
+
+type ConfirmUserMFAParams struct {
+	Step     int64  `json:"step"`
+	Username string `json:"username"`
+}
+
+func (q *Queries) ConfirmUserMFA(ctx context.Context, arg ConfirmUserMFAParams) (UserMfa, error) {
+	row := q.db.QueryRowContext(ctx, confirmUserMFA, arg.Step, arg.Username)
+	var i UserMfa
+	err := row.Scan(
+		&i.Username,
+		&i.EncryptedSecret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createMFARecoveryCode = `-- name: CreateMFARecoveryCode :one
+INSERT INTO mfa_recovery_codes (
+    username,
+    code_hash
+) VALUES (
+    $1, $2
+) RETURNING id, username, code_hash, used_at, created_at
+`
+
+type CreateMFARecoveryCodeParams struct {
+	Username string `json:"username"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) (MfaRecoveryCode, error) {
+	row := q.db.QueryRowContext(ctx, createMFARecoveryCode, arg.Username, arg.CodeHash)
+	var i MfaRecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.CodeHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteMFARecoveryCodes = `-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE username = $1
+`
+
+func (q *Queries) DeleteMFARecoveryCodes(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, deleteMFARecoveryCodes, username)
+	return err
+}
+
+const deleteUserMFA = `-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa
+WHERE username = $1
+`
+
+func (q *Queries) DeleteUserMFA(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, deleteUserMFA, username)
+	return err
+}
+
+const getUserMFA = `-- name: GetUserMFA :one
+SELECT username, encrypted_secret, confirmed_at, last_used_step, created_at FROM user_mfa
+WHERE username = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserMFA(ctx context.Context, username string) (UserMfa, error) {
+	row := q.db.QueryRowContext(ctx, getUserMFA, username)
+	var i UserMfa
+	err := row.Scan(
+		&i.Username,
+		&i.EncryptedSecret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertUserMFA = `-- name: UpsertUserMFA :one
+INSERT INTO user_mfa (
+    username,
+    encrypted_secret
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET
+    encrypted_secret = EXCLUDED.encrypted_secret,
+    last_used_step = 0,
+    created_at = now()
+WHERE user_mfa.confirmed_at IS NULL
+RETURNING username, encrypted_secret, confirmed_at, last_used_step, created_at
+`
+
+type UpsertUserMFAParams struct {
+	Username        string `json:"username"`
+	EncryptedSecret []byte `json:"encrypted_secret"`
+}
+
+// Enrolling again replaces a secret that was never confirmed, but never a confirmed one.
+func (q *Queries) UpsertUserMFA(ctx context.Context, arg UpsertUserMFAParams) (UserMfa, error) {
+	row := q.db.QueryRowContext(ctx, upsertUserMFA, arg.Username, arg.EncryptedSecret)
+	var i UserMfa
+	err := row.Scan(
+		&i.Username,
+		&i.EncryptedSecret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useMFARecoveryCode = `-- name: UseMFARecoveryCode :one
+UPDATE mfa_recovery_codes
+SET
+    used_at = now()
+WHERE
+    username = $1
+    AND code_hash = $2
+    AND used_at IS NULL
+RETURNING id, username, code_hash, used_at, created_at
+`
+
+type UseMFARecoveryCodeParams struct {
+	Username string `json:"username"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (MfaRecoveryCode, error) {
+	row := q.db.QueryRowContext(ctx, useMFARecoveryCode, arg.Username, arg.CodeHash)
+	var i MfaRecoveryCode
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.CodeHash,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const useMFAStep = `-- name: UseMFAStep :one
+UPDATE user_mfa
+SET
+    last_used_step = $1
+WHERE
+    username = $2
+    AND last_used_step < $1
+RETURNING username, encrypted_secret, confirmed_at, last_used_step, created_at
+`
+
+type UseMFAStepParams struct {
+	Step     int64  `json:"step"`
+	Username string `json:"username"`
+}
+
+// Only a step after the last accepted one is taken, so each code works once.
+func (q *Queries) UseMFAStep(ctx context.Context, arg UseMFAStepParams) (UserMfa, error) {
+	row := q.db.QueryRowContext(ctx, useMFAStep, arg.Step, arg.Username)
+	var i UserMfa
+	err := row.Scan(
+		&i.Username,
+		&i.EncryptedSecret,
+		&i.ConfirmedAt,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+	)
+	return i, err
+}
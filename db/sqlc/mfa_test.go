@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomUserMFA(t *testing.T, user User) UserMfa {
+	arg := UpsertUserMFAParams{
+		Username:        user.Username,
+		EncryptedSecret: []byte(util.RandomString(32)),
+	}
+
+	userMFA, err := testQueries.UpsertUserMFA(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, userMFA.Username)
+	require.Equal(t, arg.EncryptedSecret, userMFA.EncryptedSecret)
+	require.False(t, userMFA.ConfirmedAt.Valid)
+	require.Zero(t, userMFA.LastUsedStep)
+
+	return userMFA
+}
+
+func TestConfirmMFATx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	createRandomUserMFA(t, user)
+
+	//? enrolling again before confirming replaces the secret
+	pending := createRandomUserMFA(t, user)
+
+	hashes := []string{util.RandomString(64), util.RandomString(64)}
+
+	result, err := store.ConfirmMFATx(ctx, ConfirmMFATxParams{
+		Username:           user.Username,
+		Step:               100,
+		RecoveryCodeHashes: hashes,
+	})
+	require.NoError(t, err)
+	require.True(t, result.UserMFA.ConfirmedAt.Valid)
+	require.Equal(t, pending.EncryptedSecret, result.UserMFA.EncryptedSecret)
+	require.Equal(t, int64(100), result.UserMFA.LastUsedStep)
+
+	_, err = store.ConfirmMFATx(ctx, ConfirmMFATxParams{Username: user.Username, Step: 101})
+	require.ErrorIs(t, err, ErrMFAAlreadyConfirmed)
+
+	//! a confirmed secret cannot be replaced by enrolling again
+	_, err = testQueries.UpsertUserMFA(ctx, UpsertUserMFAParams{
+		Username:        user.Username,
+		EncryptedSecret: []byte(util.RandomString(32)),
+	})
+	require.Error(t, err)
+
+	//? the confirming step and older ones are rejected
+	_, err = testQueries.UseMFAStep(ctx, UseMFAStepParams{Username: user.Username, Step: 100})
+	require.Error(t, err)
+
+	userMFA, err := testQueries.UseMFAStep(ctx, UseMFAStepParams{Username: user.Username, Step: 101})
+	require.NoError(t, err)
+	require.Equal(t, int64(101), userMFA.LastUsedStep)
+
+	//? recovery codes work once
+	code, err := testQueries.UseMFARecoveryCode(ctx, UseMFARecoveryCodeParams{Username: user.Username, CodeHash: hashes[0]})
+	require.NoError(t, err)
+	require.True(t, code.UsedAt.Valid)
+
+	_, err = testQueries.UseMFARecoveryCode(ctx, UseMFARecoveryCodeParams{Username: user.Username, CodeHash: hashes[0]})
+	require.Error(t, err)
+
+	require.NoError(t, store.DisableMFATx(ctx, DisableMFATxParams{Username: user.Username, Audit: AuditContext{Actor: user.Username}}))
+
+	event := latestAuditEvent(t, AuditResourceUser, user.Username)
+	require.Equal(t, AuditActionUserMFADisable, event.Action)
+	require.Equal(t, user.Username, event.Actor)
+
+	_, err = testQueries.GetUserMFA(ctx, user.Username)
+	require.Error(t, err)
+
+	_, err = testQueries.UseMFARecoveryCode(ctx, UseMFARecoveryCodeParams{Username: user.Username, CodeHash: hashes[1]})
+	require.Error(t, err)
+}
@@ -5,6 +5,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,27 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	IsFrozen  bool      `json:"is_frozen"`
+	// sum of the active holds on the account
+	HeldBalance int64 `json:"held_balance"`
+	// ledger balance minus active holds, what the account can spend
+	AvailableBalance int64 `json:"available_balance"`
+}
+
+type AuditEvent struct {
+	ID int64 `json:"id"`
+	// Username that made the change, or system for background jobs. Not a foreign key, so events outlive the user
+	Actor        string `json:"actor"`
+	Action       string `json:"action"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	// Snapshot of the resource before the change, empty for new resources
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	ClientIp  string          `json:"client_ip"`
+	UserAgent string          `json:"user_agent"`
+	RequestID string          `json:"request_id"`
+	CreatedAt time.Time       `json:"created_at"`
 }
 
 type Entry struct {
@@ -23,17 +46,173 @@ type Entry struct {
 	AccountID int64 `json:"account_id"`
 	// can be negative or positive
 	Amount int64 `json:"amount"`
+	// transfer that created the entry, null for entries recorded before it was tracked
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	// time the entry was recorded, null for entries recorded before it was tracked. Those are left out of statements and counted in the opening balance
+	CreatedAt sql.NullTime `json:"created_at"`
 }
 
-type Session struct {
+type FxConversion struct {
+	TransferID   int64     `json:"transfer_id"`
+	QuoteID      uuid.UUID `json:"quote_id"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	// debited from the sender in from_currency
+	FromAmount int64 `json:"from_amount"`
+	// credited to the receiver in to_currency
+	ToAmount  int64     `json:"to_amount"`
+	Rate      int64     `json:"rate"`
+	SpreadBps int64     `json:"spread_bps"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FxQuote struct {
 	ID           uuid.UUID `json:"id"`
 	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	CreatedAt    time.Time `json:"created_at"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	// locked rate with the spread applied, scaled by 10^8
+	Rate      int64     `json:"rate"`
+	SpreadBps int64     `json:"spread_bps"`
+	IsUsed    bool      `json:"is_used"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FxRate struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	// mid-market units of to_currency per unit of from_currency, scaled by 10^8
+	Rate      int64     `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Hold struct {
+	ID          int64  `json:"id"`
+	Owner       string `json:"owner"`
+	AccountID   int64  `json:"account_id"`
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	// active, captured, released or expired
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// transfer made when the hold was captured
+	TransferID     sql.NullInt64 `json:"transfer_id"`
+	CapturedAmount int64         `json:"captured_amount"`
+	CreatedAt      time.Time     `json:"created_at"`
+	ClosedAt       sql.NullTime  `json:"closed_at"`
+}
+
+type IdempotencyKey struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+	// sha256 of the operation and its parameters
+	RequestHash string `json:"request_hash"`
+	// recorded result replayed to retries
+	Response  json.RawMessage `json:"response"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type MfaRecoveryCode struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// SHA-256 of the normalized code
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type Outbox struct {
+	ID int64 `json:"id"`
+	// Type of the asynq task, e.g. task:send_verify_email
+	TaskType string          `json:"task_type"`
+	Payload  json.RawMessage `json:"payload"`
+	Queue    string          `json:"queue"`
+	MaxRetry int32           `json:"max_retry"`
+	// Earliest time the task is processed, passed on to asynq
+	ProcessAt time.Time `json:"process_at"`
+	// asynq task ID, empty to derive one from the row ID so a row relayed twice is only queued once
+	TaskID string `json:"task_id"`
+	// Number of failed attempts to hand the task to asynq
+	Attempts  int32     `json:"attempts"`
+	LastError string    `json:"last_error"`
+	CreatedAt time.Time `json:"created_at"`
+	// Time the task was handed to asynq, NULL while it is pending
+	DispatchedAt sql.NullTime `json:"dispatched_at"`
+	// Earliest time the relay hands the task to asynq again, pushed back after every failed attempt
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// Time the relay gave up on the task after too many failed attempts, NULL while it is retried
+	FailedAt sql.NullTime `json:"failed_at"`
+}
+
+type PasswordReset struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	// SHA-256 of the emailed token, the token itself is never stored
+	TokenHash string    `json:"token_hash"`
+	IsUsed    bool      `json:"is_used"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	// once, daily, weekly, monthly or cron
+	Frequency      string `json:"frequency"`
+	CronExpression string `json:"cron_expression"`
+	// first run, anchors the recurrence
+	StartAt   time.Time `json:"start_at"`
+	NextRunAt time.Time `json:"next_run_at"`
+	// active, paused, completed, failed or cancelled
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64     `json:"id"`
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledFor        time.Time `json:"scheduled_for"`
+	Attempt             int32     `json:"attempt"`
+	// null when the attempt failed
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	Status     string        `json:"status"`
+	Error      string        `json:"error"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type Session struct {
+	ID           uuid.UUID    `json:"id"`
+	Username     string       `json:"username"`
+	RefreshToken string       `json:"refresh_token"`
+	UserAgent    string       `json:"user_agent"`
+	ClientIp     string       `json:"client_ip"`
+	IsBlocked    bool         `json:"is_blocked"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+	FamilyID     uuid.UUID    `json:"family_id"`
+	RotatedAt    sql.NullTime `json:"rotated_at"`
+}
+
+type StatementExport struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	AccountID int64     `json:"account_id"`
+	// csv or ofx
+	Format   string    `json:"format"`
+	FromTime time.Time `json:"from_time"`
+	ToTime   time.Time `json:"to_time"`
+	// pending, ready or failed
+	Status      string       `json:"status"`
+	Content     []byte       `json:"content"`
+	Error       string       `json:"error"`
+	CreatedAt   time.Time    `json:"created_at"`
+	CompletedAt sql.NullTime `json:"completed_at"`
 }
 
 type Transfer struct {
@@ -43,6 +222,8 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// Transfer refunded by this one - null for regular transfers
+	ReversalOf sql.NullInt64 `json:"reversal_of"`
 }
 
 type User struct {
@@ -52,4 +233,27 @@ type User struct {
 	Email             string    `json:"email"`
 	CreatedAt         time.Time `json:"created_at"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
+	Role              string    `json:"role"`
+}
+
+type UserMfa struct {
+	Username string `json:"username"`
+	// AES-GCM encrypted TOTP secret
+	EncryptedSecret []byte `json:"encrypted_secret"`
+	// null until the first code is confirmed, login only asks for codes once set
+	ConfirmedAt sql.NullTime `json:"confirmed_at"`
+	// TOTP step of the last accepted code, so codes cannot be replayed
+	LastUsedStep int64     `json:"last_used_step"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type VerifyEmail struct {
+	ID         int64     `json:"id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	SecretCode string    `json:"secret_code"`
+	IsUsed     bool      `json:"is_used"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiredAt  time.Time `json:"expired_at"`
 }
@@ -0,0 +1,163 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: outbox.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const createOutboxMessage = `-- name: CreateOutboxMessage :one
+INSERT INTO outbox (
+    task_type,
+    payload,
+    queue,
+    max_retry,
+    process_at,
+    task_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, task_type, payload, queue, max_retry, process_at, task_id, attempts, last_error, created_at, dispatched_at, next_attempt_at, failed_at
+`
+
+type CreateOutboxMessageParams struct {
+	TaskType  string          `json:"task_type"`
+	Payload   json.RawMessage `json:"payload"`
+	Queue     string          `json:"queue"`
+	MaxRetry  int32           `json:"max_retry"`
+	ProcessAt time.Time       `json:"process_at"`
+	TaskID    string          `json:"task_id"`
+}
+
+func (q *Queries) CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, createOutboxMessage,
+		arg.TaskType,
+		arg.Payload,
+		arg.Queue,
+		arg.MaxRetry,
+		arg.ProcessAt,
+		arg.TaskID,
+	)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.TaskType,
+		&i.Payload,
+		&i.Queue,
+		&i.MaxRetry,
+		&i.ProcessAt,
+		&i.TaskID,
+		&i.Attempts,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DispatchedAt,
+		&i.NextAttemptAt,
+		&i.FailedAt,
+	)
+	return i, err
+}
+
+const deleteDispatchedOutboxMessages = `-- name: DeleteDispatchedOutboxMessages :execrows
+DELETE FROM outbox
+WHERE dispatched_at < $1::timestamptz
+`
+
+func (q *Queries) DeleteDispatchedOutboxMessages(ctx context.Context, dispatchedBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteDispatchedOutboxMessages, dispatchedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listPendingOutboxMessagesForUpdate = `-- name: ListPendingOutboxMessagesForUpdate :many
+SELECT id, task_type, payload, queue, max_retry, process_at, task_id, attempts, last_error, created_at, dispatched_at, next_attempt_at, failed_at FROM outbox
+WHERE dispatched_at IS NULL
+    AND failed_at IS NULL
+    AND next_attempt_at <= now()
+ORDER BY attempts, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+// rows locked by another relay are skipped, so several workers can relay at once without sending a row twice.
+// Messages that failed before come after the fresh ones, so a batch of failing rows cannot hold back newer tasks.
+func (q *Queries) ListPendingOutboxMessagesForUpdate(ctx context.Context, limit int32) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingOutboxMessagesForUpdate, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskType,
+			&i.Payload,
+			&i.Queue,
+			&i.MaxRetry,
+			&i.ProcessAt,
+			&i.TaskID,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DispatchedAt,
+			&i.NextAttemptAt,
+			&i.FailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxMessageDispatched = `-- name: MarkOutboxMessageDispatched :exec
+UPDATE outbox
+SET dispatched_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxMessageDispatched(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markOutboxMessageDispatched, id)
+	return err
+}
+
+const recordOutboxMessageFailure = `-- name: RecordOutboxMessageFailure :exec
+UPDATE outbox
+SET
+    attempts = attempts + 1,
+    last_error = $1,
+    next_attempt_at = now() + make_interval(secs => $2::float8),
+    failed_at = CASE WHEN attempts + 1 >= $3::int THEN now() END
+WHERE id = $4
+`
+
+type RecordOutboxMessageFailureParams struct {
+	LastError         string  `json:"last_error"`
+	RetryDelaySeconds float64 `json:"retry_delay_seconds"`
+	MaxAttempts       int32   `json:"max_attempts"`
+	ID                int64   `json:"id"`
+}
+
+// the message is retried after the delay, or given up on once it reaches max_attempts
+func (q *Queries) RecordOutboxMessageFailure(ctx context.Context, arg RecordOutboxMessageFailureParams) error {
+	_, err := q.db.ExecContext(ctx, recordOutboxMessageFailure,
+		arg.LastError,
+		arg.RetryDelaySeconds,
+		arg.MaxAttempts,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomOutboxMessage(t *testing.T) Outbox {
+	arg := CreateOutboxMessageParams{
+		TaskType:  "task:" + util.RandomString(8),
+		Payload:   []byte(`{"username":"` + util.RandomOwner() + `"}`),
+		Queue:     "default",
+		MaxRetry:  10,
+		ProcessAt: time.Now(),
+	}
+
+	message, err := testQueries.CreateOutboxMessage(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.TaskType, message.TaskType)
+	require.JSONEq(t, string(arg.Payload), string(message.Payload))
+	require.Equal(t, arg.Queue, message.Queue)
+	require.Equal(t, arg.MaxRetry, message.MaxRetry)
+	require.Zero(t, message.Attempts)
+	require.False(t, message.DispatchedAt.Valid)
+
+	return message
+}
+
+func noRetryDelay(attempts int32) time.Duration {
+	return 0
+}
+
+func TestRelayOutboxTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	delivered := createRandomOutboxMessage(t)
+	failed := createRandomOutboxMessage(t)
+
+	relayed := map[int64]bool{}
+
+	//? other tests may leave pending messages behind, only the two created here are checked
+	for {
+		result, err := store.RelayOutboxTx(ctx, RelayOutboxTxParams{
+			Limit:       100,
+			RetryDelay:  noRetryDelay,
+			MaxAttempts: 10,
+			Dispatch: func(message Outbox) error {
+				if message.ID == failed.ID {
+					return errors.New("redis unavailable")
+				}
+
+				relayed[message.ID] = true
+				return nil
+			},
+		})
+		require.NoError(t, err)
+
+		if result.Dispatched == 0 {
+			break
+		}
+	}
+
+	require.True(t, relayed[delivered.ID])
+	require.False(t, relayed[failed.ID])
+
+	_, err := store.DeleteDispatchedOutboxMessages(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	//? the failed message stays pending with its error recorded, so the next relay retries it
+	var retried bool
+	_, err = store.RelayOutboxTx(ctx, RelayOutboxTxParams{
+		Limit:       100,
+		RetryDelay:  noRetryDelay,
+		MaxAttempts: 10,
+		Dispatch: func(message Outbox) error {
+			if message.ID == failed.ID {
+				retried = true
+				require.Positive(t, message.Attempts)
+				require.Equal(t, "redis unavailable", message.LastError)
+			}
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, retried)
+}
+
+func TestRelayOutboxTxFailingMessage(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	failing := createRandomOutboxMessage(t)
+
+	relay := func(retryDelay time.Duration) map[int64]bool {
+		relayed := map[int64]bool{}
+
+		_, err := store.RelayOutboxTx(ctx, RelayOutboxTxParams{
+			Limit:       100,
+			MaxAttempts: 2,
+			RetryDelay: func(attempts int32) time.Duration {
+				return retryDelay
+			},
+			Dispatch: func(message Outbox) error {
+				relayed[message.ID] = true
+				if message.ID == failing.ID {
+					return errors.New("task rejected")
+				}
+				return nil
+			},
+		})
+		require.NoError(t, err)
+
+		return relayed
+	}
+
+	//? the first failure holds the message back for an hour, the message after it is still relayed
+	require.True(t, relay(time.Hour)[failing.ID])
+
+	newer := createRandomOutboxMessage(t)
+
+	relayed := relay(time.Hour)
+	require.True(t, relayed[newer.ID])
+	require.False(t, relayed[failing.ID])
+
+	_, err := testDB.ExecContext(ctx, "UPDATE outbox SET next_attempt_at = now() WHERE id = $1", failing.ID)
+	require.NoError(t, err)
+
+	//? the second failure reaches MaxAttempts, so the message is given up on
+	require.True(t, relay(0)[failing.ID])
+	require.False(t, relay(0)[failing.ID])
+}
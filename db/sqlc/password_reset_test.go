@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPasswordReset(t *testing.T, user User) PasswordReset {
+	arg := CreatePasswordResetParams{
+		Username:  user.Username,
+		Email:     user.Email,
+		TokenHash: util.HashSecret(util.RandomString(32)),
+	}
+
+	passwordReset, err := testQueries.CreatePasswordReset(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, passwordReset.Username)
+	require.Equal(t, arg.Email, passwordReset.Email)
+	require.Equal(t, arg.TokenHash, passwordReset.TokenHash)
+	require.False(t, passwordReset.IsUsed)
+	require.True(t, passwordReset.ExpiredAt.After(passwordReset.CreatedAt))
+
+	return passwordReset
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+	passwordReset := createRandomPasswordReset(t, user)
+	otherReset := createRandomPasswordReset(t, user)
+
+	session := createRandomSession(t, user)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(8))
+	require.NoError(t, err)
+
+	result, err := store.ResetPasswordTx(ctx, ResetPasswordTxParams{
+		TokenHash:      passwordReset.TokenHash,
+		HashedPassword: hashedPassword,
+		Audit:          AuditContext{ClientIP: "10.0.0.1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, result.User.HashedPassword)
+	require.True(t, result.User.PasswordChangedAt.After(user.PasswordChangedAt))
+
+	//? the reset link proves the user, so they are the actor
+	event := latestAuditEvent(t, AuditResourceUser, user.Username)
+	require.Equal(t, AuditActionUserPasswordReset, event.Action)
+	require.Equal(t, user.Username, event.Actor)
+	require.Equal(t, "10.0.0.1", event.ClientIp)
+	require.NotContains(t, string(event.After), hashedPassword)
+
+	//? every session signed in with the old password is blocked
+	require.Len(t, result.BlockedSessions, 1)
+	require.Equal(t, session.ID, result.BlockedSessions[0].ID)
+	require.True(t, result.BlockedSessions[0].IsBlocked)
+
+	//? a token works once, and the other links sent to the user stop working too
+	_, err = store.ResetPasswordTx(ctx, ResetPasswordTxParams{TokenHash: passwordReset.TokenHash, HashedPassword: hashedPassword})
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+
+	_, err = store.ResetPasswordTx(ctx, ResetPasswordTxParams{TokenHash: otherReset.TokenHash, HashedPassword: hashedPassword})
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+}
+
+func TestResetPasswordTxUnknownToken(t *testing.T) {
+	store := NewStore(testDB)
+
+	_, err := store.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		TokenHash:      util.HashSecret(util.RandomString(32)),
+		HashedPassword: "hash",
+	})
+	require.ErrorIs(t, err, ErrInvalidPasswordReset)
+}
@@ -6,29 +6,116 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldBalance(ctx context.Context, arg AddAccountHeldBalanceParams) (Account, error)
+	BlockAllSessions(ctx context.Context, username string) ([]Session, error)
+	BlockOtherSessions(ctx context.Context, arg BlockOtherSessionsParams) ([]Session, error)
+	BlockSession(ctx context.Context, arg BlockSessionParams) (Session, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	CloseHold(ctx context.Context, arg CloseHoldParams) (Hold, error)
+	CompleteStatementExport(ctx context.Context, arg CompleteStatementExportParams) (StatementExport, error)
+	ConfirmUserMFA(ctx context.Context, arg ConfirmUserMFAParams) (UserMfa, error)
+	CountAccounts(ctx context.Context) (int64, error)
+	CountLegacyEntries(ctx context.Context) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFxConversion(ctx context.Context, arg CreateFxConversionParams) (FxConversion, error)
+	CreateFxQuote(ctx context.Context, arg CreateFxQuoteParams) (FxQuote, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	// returns no rows when the key has already been used by this user
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) (MfaRecoveryCode, error)
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (Outbox, error)
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateStatementExport(ctx context.Context, arg CreateStatementExportParams) (StatementExport, error)
 	CreateTranfer(ctx context.Context, arg CreateTranferParams) (Transfer, error)
+	CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (Transfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteDispatchedOutboxMessages(ctx context.Context, dispatchedBefore time.Time) (int64, error)
+	DeleteMFARecoveryCodes(ctx context.Context, username string) error
+	DeleteUserMFA(ctx context.Context, username string) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetFxConversion(ctx context.Context, transferID int64) (FxConversion, error)
+	GetFxQuote(ctx context.Context, id uuid.UUID) (FxQuote, error)
+	GetFxQuoteForUpdate(ctx context.Context, id uuid.UUID) (FxQuote, error)
+	GetFxRate(ctx context.Context, arg GetFxRateParams) (FxRate, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSessionForUpdate(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStatementExport(ctx context.Context, id uuid.UUID) (StatementExport, error)
 	GetTranfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetTransferReversal(ctx context.Context, transferID int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserMFA(ctx context.Context, username string) (UserMfa, error)
+	// Once the password is reset, any other link that was sent becomes useless.
+	InvalidatePasswordResets(ctx context.Context, username string) error
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	// every filter is optional and narrows the list when set
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListBalanceDrifts(ctx context.Context) ([]ListBalanceDriftsRow, error)
+	ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	// Holds locked by a capture or release are skipped, so the expiry job does not wait on them.
+	// They are ordered by account so their accounts are updated in the same order transfers lock them.
+	ListExpiredHoldsForUpdate(ctx context.Context, arg ListExpiredHoldsForUpdateParams) ([]Hold, error)
+	ListHeldBalanceDrifts(ctx context.Context) ([]ListHeldBalanceDriftsRow, error)
+	// Entries that their transfer does not explain: booked to an account that is not a party of it.
+	// Entries recorded before transfers were tracked have no transfer_id and are counted by CountLegacyEntries instead.
+	ListOrphanEntries(ctx context.Context) ([]Entry, error)
+	// rows locked by another relay are skipped, so several workers can relay at once without sending a row twice.
+	// Messages that failed before come after the fresh ones, so a batch of failing rows cannot hold back newer tasks.
+	ListPendingOutboxMessagesForUpdate(ctx context.Context, limit int32) ([]Outbox, error)
+	ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error)
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ListSessions(ctx context.Context, arg ListSessionsParams) ([]Session, error)
+	// entries without a created_at predate every range, the comparisons leave them out
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	// A transfer must debit the sender by its amount and credit the receiver by the same amount,
+	// or by the converted amount recorded in fx_conversions for a cross-currency transfer.
+	// A reversal of a cross-currency transfer credits the same share of the original debit, rounded down.
+	ListUnbalancedTransfers(ctx context.Context) ([]ListUnbalancedTransfersRow, error)
+	MarkFxQuoteUsed(ctx context.Context, id uuid.UUID) (FxQuote, error)
+	MarkOutboxMessageDispatched(ctx context.Context, id int64) error
+	// the message is retried after the delay, or given up on once it reaches max_attempts
+	RecordOutboxMessageFailure(ctx context.Context, arg RecordOutboxMessageFailureParams) error
+	RotateSession(ctx context.Context, id uuid.UUID) (Session, error)
+	// entries without a created_at are never counted, so they stay in the opening balance of every statement
+	SumEntriesSince(ctx context.Context, arg SumEntriesSinceParams) (int64, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountFrozen(ctx context.Context, arg UpdateAccountFrozenParams) (Account, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error)
+	UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpsertFxRate(ctx context.Context, arg UpsertFxRateParams) (FxRate, error)
+	// Enrolling again replaces a secret that was never confirmed, but never a confirmed one.
+	UpsertUserMFA(ctx context.Context, arg UpsertUserMFAParams) (UserMfa, error)
+	UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (MfaRecoveryCode, error)
+	// Only a step after the last accepted one is taken, so each code works once.
+	UseMFAStep(ctx context.Context, arg UseMFAStepParams) (UserMfa, error)
+	UsePasswordReset(ctx context.Context, tokenHash string) (PasswordReset, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	transfer, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        40,
+	})
+	require.NoError(t, err)
+
+	//? a refund cannot exceed what the transfer credited
+	_, err = store.ReverseTransferTx(ctx, ReverseTransferTxParams{TransferID: transfer.Transfer.ID, Amount: 41})
+	require.ErrorIs(t, err, ErrRefundTooLarge)
+
+	result, err := store.ReverseTransferTx(ctx, ReverseTransferTxParams{TransferID: transfer.Transfer.ID, Amount: 15})
+	require.NoError(t, err)
+
+	require.Equal(t, transfer.Transfer.ID, result.Original.ID)
+	require.Equal(t, transfer.Transfer.ID, result.Transfer.ReversalOf.Int64)
+	require.Equal(t, account2.ID, result.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, result.Transfer.ToAccountID)
+	require.Equal(t, int64(15), result.Transfer.Amount)
+
+	require.Equal(t, int64(-15), result.FromEntry.Amount)
+	require.Equal(t, int64(15), result.ToEntry.Amount)
+	require.Equal(t, int64(25), result.FromAccount.Balance)
+	require.Equal(t, int64(75), result.ToAccount.Balance)
+
+	//? a transfer is reversed once, even when the first refund was partial
+	_, err = store.ReverseTransferTx(ctx, ReverseTransferTxParams{TransferID: transfer.Transfer.ID})
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+
+	_, err = store.ReverseTransferTx(ctx, ReverseTransferTxParams{TransferID: result.Transfer.ID})
+	require.ErrorIs(t, err, ErrReverseReversal)
+}
+
+func TestReverseTransferTxFullRefund(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	transfer, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        40,
+	})
+	require.NoError(t, err)
+
+	result, err := store.ReverseTransferTx(ctx, ReverseTransferTxParams{TransferID: transfer.Transfer.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(40), result.Transfer.Amount)
+	require.Equal(t, account1.Balance, result.ToAccount.Balance)
+	require.Equal(t, account2.Balance, result.FromAccount.Balance)
+}
+
+func TestReverseTransferTxConversion(t *testing.T) {
+	store := NewStore(testDB)
+	ctx := context.Background()
+
+	amount := int64(100)
+	rate := int64(125_000_000)
+
+	account1 := createAccountInCurrency(t, amount, util.USD)
+	account2 := createAccountInCurrency(t, 0, util.CAD)
+	quote := createRandomFxQuote(t, account1.Owner, util.USD, util.CAD, rate)
+
+	transfer, err := store.ConvertTransferTx(ctx, ConvertTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		QuoteID:       quote.ID,
+		Username:      account1.Owner,
+	})
+	require.NoError(t, err)
+
+	//? the refund is taken in the receiver's currency and returned at the rate of the transfer
+	result, err := store.ReverseTransferTx(ctx, ReverseTransferTxParams{TransferID: transfer.Transfer.ID, Amount: 50})
+	require.NoError(t, err)
+	require.Equal(t, int64(-50), result.FromEntry.Amount)
+	require.Equal(t, int64(40), result.ToEntry.Amount)
+
+	ledger, err := store.VerifyLedgerTx(ctx)
+	require.NoError(t, err)
+
+	for _, unbalanced := range ledger.UnbalancedTransfers {
+		require.NotEqual(t, result.Transfer.ID, unbalanced.TransferID)
+	}
+}
+
+func TestReversalCredit(t *testing.T) {
+	conversion := FxConversion{FromAmount: 100, ToAmount: 125}
+
+	require.Equal(t, int64(100), reversalCredit(125, conversion))
+	require.Equal(t, int64(40), reversalCredit(50, conversion))
+	//? rounded down, the bank never returns more than the sender paid
+	require.Equal(t, int64(0), reversalCredit(1, conversion))
+}
@@ -0,0 +1,269 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomScheduledTransfer creates a daily standing order between two new accounts that is due now.
+// The payer can cover a run, so executing it succeeds unless the test changes the amount.
+func createRandomScheduledTransfer(t *testing.T) ScheduledTransfer {
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccount(t)
+
+	now := time.Now().Truncate(time.Second)
+
+	arg := CreateScheduledTransferParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        util.RandomInt(1, 1000),
+		Frequency:     "daily",
+		StartAt:       now,
+		NextRunAt:     now,
+	}
+
+	scheduledTransfer, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Owner, scheduledTransfer.Owner)
+	require.Equal(t, arg.FromAccountID, scheduledTransfer.FromAccountID)
+	require.Equal(t, arg.ToAccountID, scheduledTransfer.ToAccountID)
+	require.Equal(t, arg.Amount, scheduledTransfer.Amount)
+	require.Equal(t, arg.Frequency, scheduledTransfer.Frequency)
+	require.WithinDuration(t, arg.NextRunAt, scheduledTransfer.NextRunAt, time.Second)
+	//? new standing orders start active
+	require.Equal(t, ScheduledTransferActive, scheduledTransfer.Status)
+
+	return scheduledTransfer
+}
+
+func TestCreateScheduledTransfer(t *testing.T) {
+	createRandomScheduledTransfer(t)
+}
+
+func TestListDueScheduledTransfers(t *testing.T) {
+	scheduledTransfer := createRandomScheduledTransfer(t)
+
+	due, err := testQueries.ListDueScheduledTransfers(context.Background(), ListDueScheduledTransfersParams{
+		Now:        time.Now(),
+		LimitCount: 1000,
+	})
+	require.NoError(t, err)
+
+	found := false
+	for _, d := range due {
+		require.Equal(t, ScheduledTransferActive, d.Status)
+		found = found || d.ID == scheduledTransfer.ID
+	}
+	require.True(t, found)
+
+	//? paused standing orders are not due
+	_, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Status: sql.NullString{String: ScheduledTransferPaused, Valid: true},
+	})
+	require.NoError(t, err)
+
+	due, err = testQueries.ListDueScheduledTransfers(context.Background(), ListDueScheduledTransfersParams{
+		Now:        time.Now(),
+		LimitCount: 1000,
+	})
+	require.NoError(t, err)
+
+	for _, d := range due {
+		require.NotEqual(t, scheduledTransfer.ID, d.ID)
+	}
+}
+
+func TestExecuteScheduledRunTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	scheduledTransfer := createRandomScheduledTransfer(t)
+	nextRunAt := scheduledTransfer.NextRunAt.AddDate(0, 0, 1)
+
+	next := func(ScheduledTransfer) (time.Time, error) {
+		return nextRunAt, nil
+	}
+
+	fromAccount, err := testQueries.GetAccount(context.Background(), scheduledTransfer.FromAccountID)
+	require.NoError(t, err)
+
+	//? a failed attempt that will be retried is recorded and keeps the run due
+	_, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Amount: sql.NullInt64{Int64: fromAccount.Balance + 1, Valid: true},
+	})
+	require.NoError(t, err)
+
+	result, err := store.ExecuteScheduledRunTx(context.Background(), ExecuteScheduledRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledFor:        scheduledTransfer.NextRunAt,
+		Attempt:             1,
+		NextRunAt:           next,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Equal(t, ScheduledTransferRunFailed, result.Run.Status)
+	require.False(t, result.Run.TransferID.Valid)
+	require.True(t, scheduledTransfer.NextRunAt.Equal(result.ScheduledTransfer.NextRunAt))
+
+	_, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Amount: sql.NullInt64{Int64: scheduledTransfer.Amount, Valid: true},
+	})
+	require.NoError(t, err)
+
+	//* a successful attempt moves the money, settles the run and advances the standing order
+	result, err = store.ExecuteScheduledRunTx(context.Background(), ExecuteScheduledRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledFor:        scheduledTransfer.NextRunAt,
+		Attempt:             2,
+		NextRunAt:           next,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferRunSucceeded, result.Run.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Run.TransferID.Int64)
+	require.Equal(t, scheduledTransfer.Amount, result.Transfer.Transfer.Amount)
+	require.Equal(t, fromAccount.Balance-scheduledTransfer.Amount, result.Transfer.FromAccount.Balance)
+	require.WithinDuration(t, nextRunAt, result.ScheduledTransfer.NextRunAt, time.Second)
+	require.Equal(t, ScheduledTransferActive, result.ScheduledTransfer.Status)
+
+	//? a late duplicate of the same run is rejected without moving money
+	_, err = store.ExecuteScheduledRunTx(context.Background(), ExecuteScheduledRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledFor:        scheduledTransfer.NextRunAt,
+		Attempt:             3,
+		NextRunAt:           next,
+	})
+	require.ErrorIs(t, err, ErrScheduledRunSettled)
+
+	runs, err := testQueries.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               10,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+
+	//* a final failure without a next run finishes the standing order
+	_, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Amount: sql.NullInt64{Int64: fromAccount.Balance + 1, Valid: true},
+	})
+	require.NoError(t, err)
+
+	result, err = store.ExecuteScheduledRunTx(context.Background(), ExecuteScheduledRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledFor:        result.ScheduledTransfer.NextRunAt,
+		Attempt:             4,
+		FinalAttempt:        true,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Equal(t, ScheduledTransferFailed, result.ScheduledTransfer.Status)
+}
+
+func TestExecuteScheduledRunTxPaused(t *testing.T) {
+	store := NewStore(testDB)
+
+	scheduledTransfer := createRandomScheduledTransfer(t)
+
+	fromAccount, err := testQueries.GetAccount(context.Background(), scheduledTransfer.FromAccountID)
+	require.NoError(t, err)
+
+	//? the owner pauses the standing order after the run was enqueued
+	_, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Status: sql.NullString{String: ScheduledTransferPaused, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = store.ExecuteScheduledRunTx(context.Background(), ExecuteScheduledRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledFor:        scheduledTransfer.NextRunAt,
+		Attempt:             1,
+	})
+	require.ErrorIs(t, err, ErrScheduledRunSettled)
+
+	//* no money moved and no run was recorded
+	account, err := testQueries.GetAccount(context.Background(), scheduledTransfer.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance, account.Balance)
+
+	runs, err := testQueries.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, runs)
+}
+
+func TestUpdateScheduledTransferTxAfterCompletedRun(t *testing.T) {
+	store := NewStore(testDB)
+
+	scheduledTransfer := createRandomScheduledTransfer(t)
+
+	//? the owner reads the standing order while it is still active
+	read, err := testQueries.GetScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferActive, read.Status)
+
+	//* the last run is executed before the owner's change is written, which completes the standing order
+	result, err := store.ExecuteScheduledRunTx(context.Background(), ExecuteScheduledRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledFor:        scheduledTransfer.NextRunAt,
+		Attempt:             1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCompleted, result.ScheduledTransfer.Status)
+
+	update := func(ScheduledTransfer) (UpdateScheduledTransferParams, error) {
+		return UpdateScheduledTransferParams{
+			Amount: sql.NullInt64{Int64: scheduledTransfer.Amount + 1, Valid: true},
+			Status: sql.NullString{String: ScheduledTransferActive, Valid: true},
+		}, nil
+	}
+
+	_, err = store.UpdateScheduledTransferTx(context.Background(), UpdateScheduledTransferTxParams{
+		ID:              scheduledTransfer.ID,
+		AllowedStatuses: []string{ScheduledTransferActive, ScheduledTransferPaused},
+		Update:          update,
+	})
+	require.ErrorIs(t, err, ErrScheduledTransferStatus)
+
+	//! the finished standing order is not reopened, so its run cannot be paid a second time
+	got, err := testQueries.GetScheduledTransfer(context.Background(), scheduledTransfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCompleted, got.Status)
+	require.Equal(t, scheduledTransfer.Amount, got.Amount)
+
+	_, err = store.ExecuteScheduledRunTx(context.Background(), ExecuteScheduledRunTxParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledFor:        scheduledTransfer.NextRunAt,
+		Attempt:             1,
+	})
+	require.ErrorIs(t, err, ErrScheduledRunSettled)
+}
+
+func TestUpdateScheduledTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	scheduledTransfer := createRandomScheduledTransfer(t)
+
+	result, err := store.UpdateScheduledTransferTx(context.Background(), UpdateScheduledTransferTxParams{
+		ID:              scheduledTransfer.ID,
+		AllowedStatuses: []string{ScheduledTransferActive, ScheduledTransferPaused},
+		Update: func(locked ScheduledTransfer) (UpdateScheduledTransferParams, error) {
+			require.Equal(t, scheduledTransfer.ID, locked.ID)
+			return UpdateScheduledTransferParams{
+				Status: sql.NullString{String: ScheduledTransferPaused, Valid: true},
+			}, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferPaused, result.ScheduledTransfer.Status)
+	require.Equal(t, scheduledTransfer.Amount, result.ScheduledTransfer.Amount)
+}
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T, user User) Session {
+	id := uuid.New()
+
+	arg := CreateSessionParams{
+		ID:           id,
+		Username:     user.Username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    util.RandomString(10),
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		FamilyID:     id,
+	}
+
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.False(t, session.IsBlocked)
+
+	return session
+}
+
+func TestListSessions(t *testing.T) {
+	user := createRandomUser(t)
+
+	var sessions []Session
+	for range 3 {
+		sessions = append(sessions, createRandomSession(t, user))
+	}
+
+	//? expired sessions are not listed
+	expiredID := uuid.New()
+	expired, err := testQueries.CreateSession(context.Background(), CreateSessionParams{
+		ID:           expiredID,
+		Username:     user.Username,
+		RefreshToken: util.RandomString(32),
+		ExpiresAt:    time.Now().Add(-time.Minute),
+		FamilyID:     expiredID,
+	})
+	require.NoError(t, err)
+
+	listed, err := testQueries.ListSessions(context.Background(), ListSessionsParams{
+		Username: user.Username,
+		Limit:    10,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, listed, len(sessions))
+
+	for _, session := range listed {
+		require.Equal(t, user.Username, session.Username)
+		require.NotEqual(t, expired.ID, session.ID)
+	}
+}
+
+func TestBlockSession(t *testing.T) {
+	user := createRandomUser(t)
+	other := createRandomUser(t)
+	session := createRandomSession(t, user)
+
+	//? a session cannot be blocked on behalf of another user
+	_, err := testQueries.BlockSession(context.Background(), BlockSessionParams{
+		ID:       session.ID,
+		Username: other.Username,
+	})
+	require.Error(t, err)
+
+	blocked, err := testQueries.BlockSession(context.Background(), BlockSessionParams{
+		ID:       session.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+}
+
+func TestBlockOtherSessions(t *testing.T) {
+	user := createRandomUser(t)
+	current := createRandomSession(t, user)
+	first := createRandomSession(t, user)
+	second := createRandomSession(t, user)
+
+	revoked, err := testQueries.BlockOtherSessions(context.Background(), BlockOtherSessionsParams{
+		Username:  user.Username,
+		CurrentID: current.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, revoked, 2)
+
+	for _, session := range []Session{first, second} {
+		got, err := testQueries.GetSession(context.Background(), session.ID)
+		require.NoError(t, err)
+		require.True(t, got.IsBlocked)
+	}
+
+	got, err := testQueries.GetSession(context.Background(), current.ID)
+	require.NoError(t, err)
+	require.False(t, got.IsBlocked)
+}
+
+func renewRandomSession(t *testing.T, store Store, session Session) (RenewSessionTxResult, error) {
+	return store.RenewSessionTx(context.Background(), RenewSessionTxParams{
+		SessionID:    session.ID,
+		Username:     session.Username,
+		RefreshToken: session.RefreshToken,
+		NewSession: CreateSessionParams{
+			ID:           uuid.New(),
+			RefreshToken: util.RandomString(32),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		},
+	})
+}
+
+func TestRenewSessionTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	session := createRandomSession(t, user)
+
+	result, err := renewRandomSession(t, store, session)
+	require.NoError(t, err)
+	require.NotEqual(t, session.ID, result.Session.ID)
+	require.Equal(t, session.FamilyID, result.Session.FamilyID)
+	require.Equal(t, user.Username, result.Session.Username)
+
+	rotated, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, rotated.RotatedAt.Valid)
+	require.False(t, rotated.IsBlocked)
+
+	//? the rotated session is not listed as a separate device
+	listed, err := testQueries.ListSessions(context.Background(), ListSessionsParams{
+		Username: user.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, result.Session.ID, listed[0].ID)
+
+	// the new refresh token can be renewed in turn
+	_, err = renewRandomSession(t, store, result.Session)
+	require.NoError(t, err)
+}
+
+func TestRenewSessionTxReuse(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	session := createRandomSession(t, user)
+
+	result, err := renewRandomSession(t, store, session)
+	require.NoError(t, err)
+
+	//! presenting the old refresh token again revokes the whole family, including the newest session
+	_, err = renewRandomSession(t, store, session)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	latest, err := testQueries.GetSession(context.Background(), result.Session.ID)
+	require.NoError(t, err)
+	require.True(t, latest.IsBlocked)
+
+	_, err = renewRandomSession(t, store, latest)
+	require.ErrorIs(t, err, ErrSessionBlocked)
+}
+
+func TestRenewSessionTxMismatch(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	session := createRandomSession(t, user)
+	session.RefreshToken = util.RandomString(32)
+
+	_, err := renewRandomSession(t, store, session)
+	require.ErrorIs(t, err, ErrSessionMismatch)
+}
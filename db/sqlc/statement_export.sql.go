@@ -0,0 +1,127 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: statement_export.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const completeStatementExport = `-- name: CompleteStatementExport :one
+UPDATE statement_exports
+SET
+    status = $1,
+    content = $2,
+    error = $3,
+    completed_at = now()
+WHERE id = $4
+RETURNING id, username, account_id, format, from_time, to_time, status, content, error, created_at, completed_at
+`
+
+type CompleteStatementExportParams struct {
+	Status  string    `json:"status"`
+	Content []byte    `json:"content"`
+	Error   string    `json:"error"`
+	ID      uuid.UUID `json:"id"`
+}
+
+func (q *Queries) CompleteStatementExport(ctx context.Context, arg CompleteStatementExportParams) (StatementExport, error) {
+	row := q.db.QueryRowContext(ctx, completeStatementExport,
+		arg.Status,
+		arg.Content,
+		arg.Error,
+		arg.ID,
+	)
+	var i StatementExport
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.AccountID,
+		&i.Format,
+		&i.FromTime,
+		&i.ToTime,
+		&i.Status,
+		&i.Content,
+		&i.Error,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const createStatementExport = `-- name: CreateStatementExport :one
+INSERT INTO statement_exports (
+    id,
+    username,
+    account_id,
+    format,
+    from_time,
+    to_time
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, username, account_id, format, from_time, to_time, status, content, error, created_at, completed_at
+`
+
+type CreateStatementExportParams struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	AccountID int64     `json:"account_id"`
+	Format    string    `json:"format"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+func (q *Queries) CreateStatementExport(ctx context.Context, arg CreateStatementExportParams) (StatementExport, error) {
+	row := q.db.QueryRowContext(ctx, createStatementExport,
+		arg.ID,
+		arg.Username,
+		arg.AccountID,
+		arg.Format,
+		arg.FromTime,
+		arg.ToTime,
+	)
+	var i StatementExport
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.AccountID,
+		&i.Format,
+		&i.FromTime,
+		&i.ToTime,
+		&i.Status,
+		&i.Content,
+		&i.Error,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getStatementExport = `-- name: GetStatementExport :one
+SELECT id, username, account_id, format, from_time, to_time, status, content, error, created_at, completed_at FROM statement_exports
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetStatementExport(ctx context.Context, id uuid.UUID) (StatementExport, error) {
+	row := q.db.QueryRowContext(ctx, getStatementExport, id)
+	var i StatementExport
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.AccountID,
+		&i.Format,
+		&i.FromTime,
+		&i.ToTime,
+		&i.Status,
+		&i.Content,
+		&i.Error,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
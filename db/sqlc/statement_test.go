@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatementTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	amount := int64(10)
+
+	account1 := createRandomAccountWithBalance(t, 100*amount)
+	account2 := createRandomAccount(t)
+
+	//? a transfer before the range only shows up in the opening balance
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	from := time.Now()
+
+	n := 3
+	for range n {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+	}
+
+	to := time.Now().Add(time.Second)
+
+	result, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  from,
+		ToTime:    to,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account1.ID, result.Account.ID)
+	require.Equal(t, account1.Balance-amount, result.OpeningBalance)
+	require.Equal(t, account1.Balance-int64(n+1)*amount, result.ClosingBalance)
+	require.Len(t, result.Entries, n)
+
+	for _, entry := range result.Entries {
+		require.Equal(t, -amount, entry.Amount)
+		require.True(t, entry.TransferID.Valid)
+		//? the counterparty is the other side of the transfer
+		require.Equal(t, account2.ID, entry.CounterpartyAccountID)
+		require.Equal(t, account2.Owner, entry.CounterpartyOwner)
+		require.True(t, entry.CreatedAt.Valid)
+		require.False(t, entry.CreatedAt.Time.Before(from))
+	}
+}
+
+func TestAccountStatementTxUndatedEntry(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createRandomAccountWithBalance(t, 1000)
+
+	//? entries recorded before created_at was tracked have no time
+	_, err := testDB.ExecContext(context.Background(),
+		"INSERT INTO entries (account_id, amount, created_at) VALUES ($1, $2, NULL)", account.ID, 1000)
+	require.NoError(t, err)
+
+	result, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account.ID,
+		FromTime:  time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		ToTime:    time.Now().Add(time.Second),
+	})
+	require.NoError(t, err)
+
+	//? the undated entry is left out of the lines and stays part of the opening balance
+	require.Empty(t, result.Entries)
+	require.Equal(t, account.Balance, result.OpeningBalance)
+	require.Equal(t, account.Balance, result.ClosingBalance)
+}
@@ -11,7 +11,28 @@ type Store interface {
 	//! interfaces can only embed other interfaces (no structs or other concrete types)
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ConvertTransferTx(ctx context.Context, arg ConvertTransferTxParams) (ConvertTransferTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (PlaceHoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (ReleaseHoldTxResult, error)
+	ExpireHoldsTx(ctx context.Context, arg ExpireHoldsTxParams) (ExpireHoldsTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error)
+	SetAccountFrozenTx(ctx context.Context, arg SetAccountFrozenTxParams) (SetAccountFrozenTxResult, error)
+	UpdateScheduledTransferTx(ctx context.Context, arg UpdateScheduledTransferTxParams) (UpdateScheduledTransferTxResult, error)
+	ExecuteScheduledRunTx(ctx context.Context, arg ExecuteScheduledRunTxParams) (ExecuteScheduledRunTxResult, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
+	CreateStatementExportTx(ctx context.Context, arg CreateStatementExportTxParams) (CreateStatementExportTxResult, error)
+	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error)
+	RenewSessionTx(ctx context.Context, arg RenewSessionTxParams) (RenewSessionTxResult, error)
+	ConfirmMFATx(ctx context.Context, arg ConfirmMFATxParams) (ConfirmMFATxResult, error)
+	DisableMFATx(ctx context.Context, arg DisableMFATxParams) error
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error)
+	RelayOutboxTx(ctx context.Context, arg RelayOutboxTxParams) (RelayOutboxTxResult, error)
+	VerifyLedgerTx(ctx context.Context) (VerifyLedgerTxResult, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions.
@@ -34,7 +55,17 @@ func NewStore(db *sql.DB) Store {
 // add a method to the Store struct
 // ? execTx executes a function within a database transaction
 func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+	return store.execTxWithOptions(ctx, nil, fn)
+}
+
+// execReadTx executes a read-only function within a repeatable read transaction,
+// so every query in fn sees the same snapshot of the database.
+func (store *SQLStore) execReadTx(ctx context.Context, fn func(*Queries) error) error {
+	return store.execTxWithOptions(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, fn)
+}
+
+func (store *SQLStore) execTxWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -2,20 +2,23 @@ package db
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTransferTx(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-
 	n := 5
 	amount := int64(10)
 
+	//? sender must be able to cover all n transfers
+	account1 := createRandomAccountWithBalance(t, int64(n)*amount+util.RandomMoney())
+	account2 := createRandomAccount(t)
+
 	//? channels to retrieve the result and errors from separate goroutines into the main thread
 	errors := make(chan error)
 	results := make(chan TransferTxResult)
@@ -125,12 +128,13 @@ func TestTransferTx(t *testing.T) {
 func TestTransferTxDeadlock(t *testing.T) {
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-
 	n := 10
 	amount := int64(10)
 
+	//? either account may send up to n/2 transfers before receiving any money back
+	account1 := createRandomAccountWithBalance(t, int64(n)*amount)
+	account2 := createRandomAccountWithBalance(t, int64(n)*amount)
+
 	//? channels to retrieve the errors from separate goroutines into the main thread
 	errors := make(chan error)
 
@@ -173,4 +177,214 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
 
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	n := 10
+	amount := int64(10)
+	affordable := 3
+
+	//? sender can only afford some of the concurrent transfers
+	account1 := createRandomAccountWithBalance(t, int64(affordable)*amount)
+	account2 := createRandomAccount(t)
+
+	errs := make(chan error)
+
+	for range n {
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			})
+
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	rejected := 0
+
+	for range n {
+		err := <-errs
+
+		if err == nil {
+			succeeded++
+			continue
+		}
+
+		//! any failure other than insufficient funds is a bug
+		require.True(t, errors.Is(err, ErrInsufficientFunds), "unexpected error: %v", err)
+		rejected++
+	}
+
+	//? no interleaving may let more transfers through than the balance covers
+	require.Equal(t, affordable, succeeded)
+	require.Equal(t, n-affordable, rejected)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := testQueries.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+
+	require.Zero(t, updatedAccount1.Balance)
+	require.Equal(t, account2.Balance+int64(affordable)*amount, updatedAccount2.Balance)
+}
+
+func TestTransferTxFrozenAccount(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	_, err := testQueries.UpdateAccountFrozen(context.Background(), UpdateAccountFrozenParams{
+		ID:       account2.ID,
+		IsFrozen: true,
+	})
+	require.NoError(t, err)
+
+	//? a frozen account cannot receive money either
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
+
+func TestTransferTxNoOverdraft(t *testing.T) {
+	store := NewStore(testDB)
+
+	n := 20
+
+	//? small balances so that many transfers in both directions get rejected
+	account1 := createRandomAccountWithBalance(t, 50)
+	account2 := createRandomAccountWithBalance(t, 50)
+
+	type txOutcome struct {
+		result TransferTxResult
+		err    error
+	}
 
+	outcomes := make(chan txOutcome)
+
+	for i := range n {
+		fromAccountID := account1.ID
+		toAccountID := account2.ID
+
+		if i%2 == 1 {
+			fromAccountID = account2.ID
+			toAccountID = account1.ID
+		}
+
+		amount := util.RandomInt(10, 40)
+
+		go func() {
+			result, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: fromAccountID,
+				ToAccountID:   toAccountID,
+				Amount:        amount,
+			})
+
+			outcomes <- txOutcome{result, err}
+		}()
+	}
+
+	for range n {
+		outcome := <-outcomes
+
+		if outcome.err != nil {
+			require.True(t, errors.Is(outcome.err, ErrInsufficientFunds), "unexpected error: %v", outcome.err)
+			continue
+		}
+
+		//? every committed transfer must leave the sender with a non-negative balance
+		require.GreaterOrEqual(t, outcome.result.FromAccount.Balance, int64(0))
+		require.Equal(t, outcome.result.Transfer.FromAccountID, outcome.result.FromAccount.ID)
+		require.Equal(t, outcome.result.Transfer.ToAccountID, outcome.result.ToAccount.ID)
+	}
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := testQueries.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+
+	//? money is neither created nor destroyed, and neither account went negative
+	require.GreaterOrEqual(t, updatedAccount1.Balance, int64(0))
+	require.GreaterOrEqual(t, updatedAccount2.Balance, int64(0))
+	require.Equal(t, account1.Balance+account2.Balance, updatedAccount1.Balance+updatedAccount2.Balance)
+}
+
+func TestTransferTxIdempotencyKey(t *testing.T) {
+	store := NewStore(testDB)
+
+	amount := int64(10)
+
+	account1 := createRandomAccountWithBalance(t, 10*amount)
+	account2 := createRandomAccount(t)
+
+	arg := TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		IdempotencyKey: &IdempotencyParams{
+			Username: account1.Owner,
+			Key:      util.RandomString(16),
+		},
+	}
+
+	n := 5
+	errs := make(chan error)
+	results := make(chan TransferTxResult)
+
+	//? simulate a client retrying the same request concurrently
+	for range n {
+		go func() {
+			result, err := store.TransferTx(context.Background(), arg)
+			errs <- err
+			results <- result
+		}()
+	}
+
+	var first TransferTxResult
+
+	for i := range n {
+		require.NoError(t, <-errs)
+		result := <-results
+
+		if i == 0 {
+			first = result
+			continue
+		}
+
+		//? every retry must get back the originally recorded transfer
+		require.Equal(t, first.Transfer.ID, result.Transfer.ID)
+		require.Equal(t, first.FromEntry.ID, result.FromEntry.ID)
+		require.Equal(t, first.ToEntry.ID, result.ToEntry.ID)
+		require.Equal(t, first.FromAccount.Balance, result.FromAccount.Balance)
+	}
+
+	//? money moved exactly once
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-amount, updatedAccount1.Balance)
+
+	//? reusing the key with a different body is rejected
+	arg.Amount = 2 * amount
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	//? the same key is independent for a different user
+	arg.IdempotencyKey = &IdempotencyParams{
+		Username: account2.Owner,
+		Key:      arg.IdempotencyKey.Key,
+	}
+	arg.FromAccountID, arg.ToAccountID = account2.ID, account1.ID
+	arg.Amount = amount
+	_, err = store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+}
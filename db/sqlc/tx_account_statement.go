@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// AccountStatementTxParams selects the entries of an account created in [FromTime, ToTime).
+type AccountStatementTxParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+type AccountStatementTxResult struct {
+	Account        Account                   `json:"account"`
+	OpeningBalance int64                     `json:"opening_balance"`
+	ClosingBalance int64                     `json:"closing_balance"`
+	Entries        []ListStatementEntriesRow `json:"entries"`
+}
+
+// AccountStatementTx reads the entries of an account in a date range along with the balances at the start and end of the range.
+// Balances are derived from the current balance by backing out later entries, all read from one consistent snapshot.
+func (store *SQLStore) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+
+	var result AccountStatementTxResult
+
+	err := store.execReadTx(ctx, func(q *Queries) error {
+
+		var err error
+
+		result.Account, err = q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		sinceFrom, err := q.SumEntriesSince(ctx, SumEntriesSinceParams{
+			AccountID: arg.AccountID,
+			Since:     arg.FromTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		sinceTo, err := q.SumEntriesSince(ctx, SumEntriesSinceParams{
+			AccountID: arg.AccountID,
+			Since:     arg.ToTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.OpeningBalance = result.Account.Balance - sinceFrom
+		result.ClosingBalance = result.Account.Balance - sinceTo
+
+		result.Entries, err = q.ListStatementEntries(ctx, ListStatementEntriesParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.FromTime,
+			ToTime:    arg.ToTime,
+		})
+
+		return err
+	})
+
+	return result, err
+}
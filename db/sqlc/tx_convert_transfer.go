@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+)
+
+// Errors returned by ConvertTransferTx when the locked quote cannot be used for the transfer.
+var (
+	ErrQuoteExpired       = errors.New("fx quote has expired")
+	ErrQuoteUsed          = errors.New("fx quote has already been used")
+	ErrQuoteMismatch      = errors.New("fx quote does not match the transfer")
+	ErrConversionTooSmall = errors.New("amount is too small to convert")
+)
+
+// ConvertTransferTxParams contains the input parameters of the cross-currency transfer transaction.
+type ConvertTransferTxParams struct {
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	// Amount is debited from the sender in the sender's currency.
+	Amount  int64     `json:"amount"`
+	QuoteID uuid.UUID `json:"quote_id"`
+	// Username must own the quote.
+	Username       string             `json:"username"`
+	IdempotencyKey *IdempotencyParams `json:"-"`
+	Audit          AuditContext       `json:"-"`
+}
+
+// ConvertTransferTxResult is the result of the cross-currency transfer transaction.
+type ConvertTransferTxResult struct {
+	TransferTxResult
+	Conversion FxConversion `json:"conversion"`
+}
+
+// ConvertTransferTx moves money between two accounts holding different currencies at the rate locked by an FX quote.
+// It debits the sender in one currency, credits the receiver in the other, records the rate and spread that were used,
+// and consumes the quote, all within a single database transaction.
+func (store *SQLStore) ConvertTransferTx(ctx context.Context, arg ConvertTransferTxParams) (ConvertTransferTxResult, error) {
+
+	var result ConvertTransferTxResult
+
+	err := store.execIdempotentTx(ctx, arg.IdempotencyKey, "convert_transfer", arg, &result, func(q *Queries) error {
+
+		//? lock the quote first so two transfers cannot consume the same quote
+		quote, err := q.GetFxQuoteForUpdate(ctx, arg.QuoteID)
+		if err != nil {
+			return err
+		}
+
+		if quote.Username != arg.Username {
+			return fmt.Errorf("%w: quote belongs to another user", ErrQuoteMismatch)
+		}
+
+		if quote.IsUsed {
+			return ErrQuoteUsed
+		}
+
+		if time.Now().After(quote.ExpiresAt) {
+			return ErrQuoteExpired
+		}
+
+		fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkNotFrozen(fromAccount, toAccount); err != nil {
+			return err
+		}
+
+		if fromAccount.Currency != quote.FromCurrency || toAccount.Currency != quote.ToCurrency {
+			return fmt.Errorf("%w: quote converts %s to %s but accounts hold %s and %s",
+				ErrQuoteMismatch, quote.FromCurrency, quote.ToCurrency, fromAccount.Currency, toAccount.Currency)
+		}
+
+		if err = checkAvailableFunds(fromAccount, arg.Amount); err != nil {
+			return err
+		}
+
+		toAmount := util.ConvertAmount(arg.Amount, quote.Rate)
+		if toAmount <= 0 {
+			return ErrConversionTooSmall
+		}
+
+		result.Transfer, err = q.CreateTranfer(ctx, CreateTranferParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		//? sender is debited in the source currency
+		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  arg.FromAccountID,
+			Amount:     -arg.Amount,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		//? receiver is credited the converted amount in the target currency
+		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  arg.ToAccountID,
+			Amount:     toAmount,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.FromAccountID < arg.ToAccountID {
+			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, toAmount)
+		} else {
+			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, toAmount, arg.FromAccountID, -arg.Amount)
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Conversion, err = q.CreateFxConversion(ctx, CreateFxConversionParams{
+			TransferID:   result.Transfer.ID,
+			QuoteID:      quote.ID,
+			FromCurrency: quote.FromCurrency,
+			ToCurrency:   quote.ToCurrency,
+			FromAmount:   arg.Amount,
+			ToAmount:     toAmount,
+			Rate:         quote.Rate,
+			SpreadBps:    quote.SpreadBps,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.MarkFxQuoteUsed(ctx, quote.ID); err != nil {
+			return err
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionTransferConvert, AuditResourceTransfer,
+			strconv.FormatInt(result.Transfer.ID, 10),
+			auditAccounts{FromAccount: fromAccount, ToAccount: toAccount},
+			result,
+		)
+	})
+
+	return result, err
+}
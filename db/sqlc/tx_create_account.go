@@ -0,0 +1,31 @@
+package db
+
+import "context"
+
+type CreateAccountTxParams struct {
+	CreateAccountParams
+	// IdempotencyKey is optional. When set, a retry with the same key returns the account created by the first request.
+	IdempotencyKey *IdempotencyParams `json:"-"`
+}
+
+type CreateAccountTxResult struct {
+	Account Account `json:"account"`
+}
+
+// CreateAccountTx creates an account within a single database transaction.
+// The account and the idempotency record are committed together, so a retried request never opens a second account.
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error) {
+
+	var result CreateAccountTxResult
+
+	err := store.execIdempotentTx(ctx, arg.IdempotencyKey, "create_account", arg, &result, func(q *Queries) error {
+
+		var err error
+
+		result.Account, err = q.CreateAccount(ctx, arg.CreateAccountParams)
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,33 @@
+package db
+
+import "context"
+
+type CreateSessionTxParams struct {
+	CreateSessionParams
+	Audit AuditContext
+}
+
+type CreateSessionTxResult struct {
+	Session Session
+}
+
+// CreateSessionTx starts the session of a login and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error) {
+
+	var result CreateSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		var err error
+
+		result.Session, err = q.CreateSession(ctx, arg.CreateSessionParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionSessionCreate, AuditResourceSession,
+			result.Session.ID.String(), nil, newAuditSession(result.Session))
+	})
+
+	return result, err
+}
@@ -0,0 +1,47 @@
+package db
+
+import "context"
+
+// Statuses of a statement export.
+const (
+	StatementExportPending = "pending"
+	StatementExportReady   = "ready"
+	StatementExportFailed  = "failed"
+)
+
+type CreateStatementExportTxParams struct {
+	CreateStatementExportParams
+	// OutboxMessages returns the background job that renders the export, which is written to the outbox within the same transaction.
+	OutboxMessages func(export StatementExport) ([]CreateOutboxMessageParams, error)
+}
+
+type CreateStatementExportTxResult struct {
+	StatementExport StatementExport
+}
+
+// CreateStatementExportTx records a pending statement export and schedules its background job within a single database transaction.
+// The job is only relayed if the export is committed, and the export is only committed with its job, so no export stays pending forever.
+func (store *SQLStore) CreateStatementExportTx(ctx context.Context, arg CreateStatementExportTxParams) (CreateStatementExportTxResult, error) {
+
+	var result CreateStatementExportTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		var err error
+
+		result.StatementExport, err = q.CreateStatementExport(ctx, arg.CreateStatementExportParams)
+
+		if err != nil {
+			return err
+		}
+
+		messages, err := arg.OutboxMessages(result.StatementExport)
+		if err != nil {
+			return err
+		}
+
+		return writeOutbox(ctx, q, messages)
+	})
+
+	return result, err
+}
@@ -4,16 +4,17 @@ import "context"
 
 type CreateUserTxParams struct {
 	CreateUserParams
-	// AfterCreateUser callback executes post-creation logic (e.g., send verify email task scheduling)
-	// within the same transaction, ensuring atomicity.
-	AfterCreateUser func(user User) error
+	// OutboxMessages returns the background tasks of the new user (e.g., the verify email task),
+	// which are written to the outbox within the same transaction. It is optional.
+	OutboxMessages func(user User) ([]CreateOutboxMessageParams, error)
+	Audit          AuditContext
 }
 
 type CreateUserTxResult struct {
 	User User
 }
 
-// CreateUserTx creates a user and executes post-creation logic (e.g., email verification task scheduling)
+// CreateUserTx creates a user and queues its background tasks (e.g., the email verification)
 // within a single database transaction. If any step fails, the entire transaction is rolled back.
 func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
 
@@ -31,8 +32,23 @@ func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams)
 			return err
 		}
 
-		// Execute post-creation hook (e.g., schedule verify email task) within same transaction.
-		return arg.AfterCreateUser(result.User)
+		err = recordAuditEvent(ctx, q, arg.Audit, AuditActionUserCreate, AuditResourceUser,
+			result.User.Username, nil, newAuditUser(result.User))
+		if err != nil {
+			return err
+		}
+
+		if arg.OutboxMessages == nil {
+			return nil
+		}
+
+		// Queue the background tasks within the same transaction, they are only relayed if the user is committed.
+		messages, err := arg.OutboxMessages(result.User)
+		if err != nil {
+			return err
+		}
+
+		return writeOutbox(ctx, q, messages)
 
 	})
 
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"strconv"
+)
+
+type SetAccountFrozenTxParams struct {
+	AccountID int64        `json:"account_id"`
+	IsFrozen  bool         `json:"is_frozen"`
+	Audit     AuditContext `json:"-"`
+}
+
+type SetAccountFrozenTxResult struct {
+	Account Account `json:"account"`
+}
+
+// SetAccountFrozenTx freezes or unfreezes an account and records the change in the audit log within a single database transaction.
+func (store *SQLStore) SetAccountFrozenTx(ctx context.Context, arg SetAccountFrozenTxParams) (SetAccountFrozenTxResult, error) {
+
+	var result SetAccountFrozenTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		//? lock the account so the audit snapshot is the state the change was made on
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.UpdateAccountFrozen(ctx, UpdateAccountFrozenParams{
+			ID:       arg.AccountID,
+			IsFrozen: arg.IsFrozen,
+		})
+		if err != nil {
+			return err
+		}
+
+		action := AuditActionAccountUnfreeze
+		if arg.IsFrozen {
+			action = AuditActionAccountFreeze
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, action, AuditResourceAccount,
+			strconv.FormatInt(account.ID, 10),
+			account,
+			result.Account,
+		)
+	})
+
+	return result, err
+}
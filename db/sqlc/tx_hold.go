@@ -0,0 +1,301 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Statuses of a hold. Only active holds reserve funds on their account.
+const (
+	HoldActive   = "active"
+	HoldCaptured = "captured"
+	HoldReleased = "released"
+	HoldExpired  = "expired"
+)
+
+// Errors returned by the hold transactions when a hold cannot be captured or released.
+var (
+	ErrHoldNotActive   = errors.New("hold is no longer active")
+	ErrHoldExpired     = errors.New("hold has expired")
+	ErrCaptureTooLarge = errors.New("capture exceeds the held amount")
+)
+
+// PlaceHoldTxParams contains the input parameters of the place hold transaction.
+type PlaceHoldTxParams struct {
+	Owner       string `json:"owner"`
+	AccountID   int64  `json:"account_id"`
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	// ExpiresAt is computed from the time of the request, so it is left out of the idempotency hash.
+	ExpiresAt      time.Time          `json:"-"`
+	IdempotencyKey *IdempotencyParams `json:"-"`
+	Audit          AuditContext       `json:"-"`
+}
+
+// PlaceHoldTxResult is the result of the place hold transaction.
+type PlaceHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// PlaceHoldTx reserves funds on an account for a later transfer to another account.
+// The held amount no longer counts towards the available balance of the account until the hold is captured, released or expires.
+func (store *SQLStore) PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (PlaceHoldTxResult, error) {
+
+	var result PlaceHoldTxResult
+
+	err := store.execIdempotentTx(ctx, arg.IdempotencyKey, "place_hold", arg, &result, func(q *Queries) error {
+
+		//? the payee is locked as well so it cannot be frozen between the check and the hold
+		account, toAccount, err := lockAccounts(ctx, q, arg.AccountID, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkNotFrozen(account, toAccount); err != nil {
+			return err
+		}
+
+		if err = checkAvailableFunds(account, arg.Amount); err != nil {
+			return err
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			Owner:       arg.Owner,
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionHoldPlace, AuditResourceHold,
+			strconv.FormatInt(result.Hold.ID, 10),
+			auditHold{Account: &account},
+			auditHold{Hold: &result.Hold, Account: &result.Account},
+		)
+	})
+
+	return result, err
+}
+
+// CaptureHoldTxParams contains the input parameters of the capture hold transaction.
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Amount is transferred to the payee, at most the held amount. Zero captures the full hold.
+	Amount         int64              `json:"amount"`
+	IdempotencyKey *IdempotencyParams `json:"-"`
+	Audit          AuditContext       `json:"-"`
+}
+
+// CaptureHoldTxResult is the result of the capture hold transaction.
+// The embedded transfer moved the captured amount from the held account to the payee.
+type CaptureHoldTxResult struct {
+	TransferTxResult
+	Hold Hold `json:"hold"`
+}
+
+// CaptureHoldTx turns an active hold into a transfer to its payee within a single database transaction.
+// The whole hold is lifted, so whatever is left of it after a partial capture is available to the account again.
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+
+	var result CaptureHoldTxResult
+
+	err := store.execIdempotentTx(ctx, arg.IdempotencyKey, "capture_hold", arg, &result, func(q *Queries) error {
+
+		//? lock the hold first so it cannot be captured twice, or released or expired while it is captured
+		hold, err := q.GetHoldForUpdate(ctx, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkHoldActive(hold); err != nil {
+			return err
+		}
+
+		//? the expiry job may not have run yet
+		if time.Now().After(hold.ExpiresAt) {
+			return fmt.Errorf("%w: hold [%d] expired at %s", ErrHoldExpired, hold.ID, hold.ExpiresAt.Format(time.RFC3339))
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = hold.Amount
+		}
+
+		if amount > hold.Amount {
+			return fmt.Errorf("%w: hold [%d] is for %d, capture is %d", ErrCaptureTooLarge, hold.ID, hold.Amount, amount)
+		}
+
+		//? lock both accounts in the order transfers do before lifting the hold, so the transfer below cannot deadlock
+		fromAccount, toAccount, err := lockAccounts(ctx, q, hold.AccountID, hold.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{ID: hold.AccountID, Amount: -hold.Amount}); err != nil {
+			return err
+		}
+
+		result.TransferTxResult, _, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.CloseHold(ctx, CloseHoldParams{
+			ID:             hold.ID,
+			Status:         HoldCaptured,
+			TransferID:     sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+			CapturedAmount: amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionHoldCapture, AuditResourceHold,
+			strconv.FormatInt(hold.ID, 10),
+			auditHold{Hold: &hold, Account: &fromAccount, ToAccount: &toAccount},
+			auditHold{Hold: &result.Hold, Account: &result.FromAccount, ToAccount: &result.ToAccount},
+		)
+	})
+
+	return result, err
+}
+
+// ReleaseHoldTxParams contains the input parameters of the release hold transaction.
+type ReleaseHoldTxParams struct {
+	HoldID int64        `json:"hold_id"`
+	Audit  AuditContext `json:"-"`
+}
+
+// ReleaseHoldTxResult is the result of the release hold transaction.
+type ReleaseHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// ReleaseHoldTx lifts an active hold without moving any money, making the held funds available to the account again.
+func (store *SQLStore) ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (ReleaseHoldTxResult, error) {
+
+	var result ReleaseHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		hold, err := q.GetHoldForUpdate(ctx, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkHoldActive(hold); err != nil {
+			return err
+		}
+
+		result.Hold, result.Account, err = liftHold(ctx, q, hold, HoldReleased)
+		if err != nil {
+			return err
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionHoldRelease, AuditResourceHold,
+			strconv.FormatInt(hold.ID, 10),
+			auditHold{Hold: &hold},
+			auditHold{Hold: &result.Hold, Account: &result.Account},
+		)
+	})
+
+	return result, err
+}
+
+// ExpireHoldsTxParams contains the input parameters of the expire holds transaction.
+type ExpireHoldsTxParams struct {
+	// Limit caps the holds expired by one transaction.
+	Limit int32 `json:"limit"`
+}
+
+// ExpireHoldsTxResult is the result of the expire holds transaction.
+type ExpireHoldsTxResult struct {
+	Holds []Hold `json:"holds"`
+}
+
+// ExpireHoldsTx releases a batch of active holds whose expiry time has passed and records each of them in the audit log.
+// Holds that a capture or release is working on are skipped, the next run picks them up if they are still active.
+func (store *SQLStore) ExpireHoldsTx(ctx context.Context, arg ExpireHoldsTxParams) (ExpireHoldsTxResult, error) {
+
+	var result ExpireHoldsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		holds, err := q.ListExpiredHoldsForUpdate(ctx, ListExpiredHoldsForUpdateParams{
+			Now:        time.Now(),
+			LimitCount: arg.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Holds = make([]Hold, 0, len(holds))
+
+		for _, hold := range holds {
+			expired, account, err := liftHold(ctx, q, hold, HoldExpired)
+			if err != nil {
+				return err
+			}
+
+			err = recordAuditEvent(ctx, q, AuditContext{}, AuditActionHoldExpire, AuditResourceHold,
+				strconv.FormatInt(hold.ID, 10),
+				auditHold{Hold: &hold},
+				auditHold{Hold: &expired, Account: &account},
+			)
+			if err != nil {
+				return err
+			}
+
+			result.Holds = append(result.Holds, expired)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// checkHoldActive returns ErrHoldNotActive if the hold has already been captured, released or expired.
+func checkHoldActive(hold Hold) error {
+	if hold.Status != HoldActive {
+		return fmt.Errorf("%w: hold [%d] is %s", ErrHoldNotActive, hold.ID, hold.Status)
+	}
+
+	return nil
+}
+
+// liftHold lifts an active hold from its account without moving any money and records it with the given status.
+func liftHold(ctx context.Context, q *Queries, hold Hold, status string) (Hold, Account, error) {
+	account, err := q.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{ID: hold.AccountID, Amount: -hold.Amount})
+	if err != nil {
+		return Hold{}, Account{}, err
+	}
+
+	closed, err := q.CloseHold(ctx, CloseHoldParams{ID: hold.ID, Status: status})
+	if err != nil {
+		return Hold{}, Account{}, err
+	}
+
+	return closed, account, nil
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrMFAAlreadyConfirmed is returned when two-factor authentication is confirmed a second time.
+var ErrMFAAlreadyConfirmed = errors.New("two-factor authentication is already enabled")
+
+type ConfirmMFATxParams struct {
+	Username string `json:"username"`
+	// Step is the TOTP step of the code that confirmed the secret, so it cannot be used to log in.
+	Step int64 `json:"step"`
+	// RecoveryCodeHashes replace any recovery codes left from an earlier enrollment.
+	RecoveryCodeHashes []string `json:"recovery_code_hashes"`
+}
+
+type ConfirmMFATxResult struct {
+	UserMFA UserMfa `json:"user_mfa"`
+}
+
+// ConfirmMFATx turns on two-factor authentication and stores a fresh set of recovery codes within a single database transaction.
+func (store *SQLStore) ConfirmMFATx(ctx context.Context, arg ConfirmMFATxParams) (ConfirmMFATxResult, error) {
+
+	var result ConfirmMFATxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		var err error
+
+		//? only matches a secret that has not been confirmed yet
+		result.UserMFA, err = q.ConfirmUserMFA(ctx, ConfirmUserMFAParams{
+			Username: arg.Username,
+			Step:     arg.Step,
+		})
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrMFAAlreadyConfirmed
+			}
+			return err
+		}
+
+		if err = q.DeleteMFARecoveryCodes(ctx, arg.Username); err != nil {
+			return err
+		}
+
+		for _, codeHash := range arg.RecoveryCodeHashes {
+			_, err = q.CreateMFARecoveryCode(ctx, CreateMFARecoveryCodeParams{
+				Username: arg.Username,
+				CodeHash: codeHash,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+type DisableMFATxParams struct {
+	Username string       `json:"username"`
+	Audit    AuditContext `json:"-"`
+}
+
+// DisableMFATx turns off two-factor authentication and removes its recovery codes within a single database transaction.
+func (store *SQLStore) DisableMFATx(ctx context.Context, arg DisableMFATxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		userMFA, err := q.GetUserMFA(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		if err = q.DeleteMFARecoveryCodes(ctx, arg.Username); err != nil {
+			return err
+		}
+
+		if err = q.DeleteUserMFA(ctx, arg.Username); err != nil {
+			return err
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionUserMFADisable, AuditResourceUser,
+			arg.Username,
+			auditMFA{Enabled: userMFA.ConfirmedAt.Valid, ConfirmedAt: userMFA.ConfirmedAt},
+			auditMFA{},
+		)
+	})
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type RelayOutboxTxParams struct {
+	// Limit is the number of pending messages relayed at most
+	Limit int32
+	// Dispatch hands a message to the task queue. A message it fails is kept pending and retried after RetryDelay.
+	Dispatch func(message Outbox) error
+	// RetryDelay returns how long a message waits after its attempts-th failure
+	RetryDelay func(attempts int32) time.Duration
+	// MaxAttempts is the number of failures after which a message is given up on and no longer relayed
+	MaxAttempts int32
+}
+
+type RelayOutboxTxResult struct {
+	Dispatched int
+	Failed     int
+}
+
+// RelayOutboxTx hands the pending outbox messages that are due to the task queue and marks them dispatched within a single database transaction.
+// The messages stay locked until the transaction ends, so concurrent relays skip them instead of sending them twice.
+// A message is dispatched before the transaction commits, so a failed commit sends it again: delivery is at least once.
+// Messages that never failed are relayed first, so messages that keep failing cannot fill every batch.
+func (store *SQLStore) RelayOutboxTx(ctx context.Context, arg RelayOutboxTxParams) (RelayOutboxTxResult, error) {
+
+	var result RelayOutboxTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		messages, err := q.ListPendingOutboxMessagesForUpdate(ctx, arg.Limit)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if dispatchErr := arg.Dispatch(message); dispatchErr != nil {
+				result.Failed++
+
+				err = q.RecordOutboxMessageFailure(ctx, RecordOutboxMessageFailureParams{
+					ID:                message.ID,
+					LastError:         dispatchErr.Error(),
+					RetryDelaySeconds: arg.RetryDelay(message.Attempts + 1).Seconds(),
+					MaxAttempts:       arg.MaxAttempts,
+				})
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if err = q.MarkOutboxMessageDispatched(ctx, message.ID); err != nil {
+				return err
+			}
+
+			result.Dispatched++
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// writeOutbox adds background tasks to the outbox within the transaction of q,
+// so they are only relayed if the change they belong to is committed.
+func writeOutbox(ctx context.Context, q *Queries, messages []CreateOutboxMessageParams) error {
+	for _, message := range messages {
+		if _, err := q.CreateOutboxMessage(ctx, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
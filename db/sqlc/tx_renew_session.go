@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned by RenewSessionTx when a refresh token cannot be exchanged for a new one.
+var (
+	ErrSessionBlocked     = errors.New("blocked session")
+	ErrSessionExpired     = errors.New("expired session")
+	ErrSessionMismatch    = errors.New("mismatched session token")
+	ErrRefreshTokenReused = errors.New("refresh token has already been used, every session of this login has been revoked")
+)
+
+type RenewSessionTxParams struct {
+	// SessionID, Username and RefreshToken identify the presented refresh token.
+	SessionID    uuid.UUID `json:"session_id"`
+	Username     string    `json:"username"`
+	RefreshToken string    `json:"refresh_token"`
+	// NewSession describes the rotated refresh token. Its family is taken from the presented session.
+	NewSession CreateSessionParams `json:"new_session"`
+	Audit      AuditContext        `json:"-"`
+}
+
+type RenewSessionTxResult struct {
+	Session Session `json:"session"`
+	// User is read within the transaction so the new tokens carry the user's current role.
+	User User `json:"user"`
+}
+
+// RenewSessionTx exchanges a refresh token for a new one within a single database transaction.
+// Each refresh token can be used once: the presented session is marked rotated and a new session joins its family.
+// Presenting a rotated refresh token again is treated as theft and blocks the whole family,
+// in which case the block is committed and ErrRefreshTokenReused is returned.
+func (store *SQLStore) RenewSessionTx(ctx context.Context, arg RenewSessionTxParams) (RenewSessionTxResult, error) {
+
+	var result RenewSessionTxResult
+	var reused bool
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		//? lock the session so two concurrent renewals with the same token cannot both rotate it
+		session, err := q.GetSessionForUpdate(ctx, arg.SessionID)
+		if err != nil {
+			return err
+		}
+
+		if session.Username != arg.Username || session.RefreshToken != arg.RefreshToken {
+			return ErrSessionMismatch
+		}
+
+		if session.IsBlocked {
+			return ErrSessionBlocked
+		}
+
+		//! the token was already exchanged, so either the client or an attacker holds a copy of it
+		if session.RotatedAt.Valid {
+			reused = true
+			_, err = q.BlockSessionFamily(ctx, session.FamilyID)
+			return err
+		}
+
+		if time.Now().After(session.ExpiresAt) {
+			return ErrSessionExpired
+		}
+
+		if _, err = q.RotateSession(ctx, session.ID); err != nil {
+			return err
+		}
+
+		newSession := arg.NewSession
+		newSession.Username = session.Username
+		newSession.FamilyID = session.FamilyID
+
+		result.Session, err = q.CreateSession(ctx, newSession)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.GetUser(ctx, session.Username)
+		if err != nil {
+			return err
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionSessionRenew, AuditResourceSession,
+			result.Session.ID.String(), newAuditSession(session), newAuditSession(result.Session))
+	})
+
+	if err == nil && reused {
+		return result, ErrRefreshTokenReused
+	}
+
+	return result, err
+}
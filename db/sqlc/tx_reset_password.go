@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrInvalidPasswordReset is returned when a password reset token is unknown, expired, already used,
+// or was sent to an address the user no longer has.
+var ErrInvalidPasswordReset = errors.New("invalid or expired password reset token")
+
+type ResetPasswordTxParams struct {
+	TokenHash      string `json:"token_hash"`
+	HashedPassword string `json:"hashed_password"`
+	// Audit describes where the request came from. The actor is the user the token was sent to.
+	Audit AuditContext `json:"-"`
+	// OutboxMessages returns the background tasks of the user whose password was reset (e.g., revoking their tokens),
+	// which are written to the outbox within the same transaction. It is optional.
+	OutboxMessages func(user User) ([]CreateOutboxMessageParams, error) `json:"-"`
+}
+
+type ResetPasswordTxResult struct {
+	User User `json:"user"`
+	// BlockedSessions were signed in with the old password and are now revoked.
+	BlockedSessions []Session `json:"blocked_sessions"`
+}
+
+// ResetPasswordTx consumes a password reset token, sets the new password and signs out every session of the user
+// within a single database transaction.
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (ResetPasswordTxResult, error) {
+
+	var result ResetPasswordTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		//? only matches an unused, unexpired token, and marks it used so it works once
+		passwordReset, err := q.UsePasswordReset(ctx, arg.TokenHash)
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrInvalidPasswordReset
+			}
+			return err
+		}
+
+		user, err := q.GetUser(ctx, passwordReset.Username)
+		if err != nil {
+			return err
+		}
+
+		//? the user changed their address after the link was sent
+		if user.Email != passwordReset.Email {
+			return ErrInvalidPasswordReset
+		}
+
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username: user.Username,
+			HashedPassword: sql.NullString{
+				String: arg.HashedPassword,
+				Valid:  true,
+			},
+			PasswordChangedAt: sql.NullTime{
+				Time:  time.Now(),
+				Valid: true,
+			},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if err = q.InvalidatePasswordResets(ctx, result.User.Username); err != nil {
+			return err
+		}
+
+		result.BlockedSessions, err = q.BlockAllSessions(ctx, result.User.Username)
+		if err != nil {
+			return err
+		}
+
+		audit := arg.Audit
+		audit.Actor = user.Username
+
+		err = recordAuditEvent(ctx, q, audit, AuditActionUserPasswordReset, AuditResourceUser,
+			user.Username,
+			newAuditUser(user),
+			newAuditUser(result.User),
+		)
+		if err != nil || arg.OutboxMessages == nil {
+			return err
+		}
+
+		messages, err := arg.OutboxMessages(result.User)
+		if err != nil {
+			return err
+		}
+
+		return writeOutbox(ctx, q, messages)
+	})
+
+	return result, err
+}
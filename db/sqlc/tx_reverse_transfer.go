@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Errors returned by ReverseTransferTx when the transfer cannot be refunded.
+var (
+	ErrTransferAlreadyReversed = errors.New("transfer has already been reversed")
+	ErrReverseReversal         = errors.New("a reversal cannot be reversed")
+	ErrRefundTooLarge          = errors.New("refund exceeds the transfer amount")
+)
+
+// ReverseTransferTxParams contains the input parameters of the transfer reversal transaction.
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount is taken back from the receiver in the receiver's currency, at most what the transfer credited.
+	// Zero refunds the full amount.
+	Amount int64 `json:"amount"`
+	// IdempotencyKey is optional. When set, a retry with the same key replays the original result.
+	IdempotencyKey *IdempotencyParams `json:"-"`
+	Audit          AuditContext       `json:"-"`
+}
+
+// ReverseTransferTxResult is the result of the transfer reversal transaction.
+// The embedded transfer is the compensating one, moving money from the original receiver back to the original sender.
+type ReverseTransferTxResult struct {
+	TransferTxResult
+	Original Transfer `json:"original"`
+}
+
+// ReverseTransferTx refunds a transfer, fully or partially, with a compensating transfer linked to it through reversal_of.
+// The refund is debited from the original receiver and credited to the original sender within a single database transaction.
+// A cross-currency transfer is refunded at the rate it was converted at, so a full refund returns exactly what the sender paid.
+// Every transfer can be reversed once.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+
+	var result ReverseTransferTxResult
+
+	err := store.execIdempotentTx(ctx, arg.IdempotencyKey, "reverse_transfer", arg, &result, func(q *Queries) error {
+
+		var err error
+
+		//? lock the original first so two reversals of the same transfer cannot both pass the check below
+		result.Original, err = q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		if result.Original.ReversalOf.Valid {
+			return ErrReverseReversal
+		}
+
+		_, err = q.GetTransferReversal(ctx, result.Original.ID)
+		if err == nil {
+			return fmt.Errorf("%w: transfer [%d]", ErrTransferAlreadyReversed, result.Original.ID)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		//? the receiver was credited the converted amount of a cross-currency transfer
+		credited := result.Original.Amount
+		conversion, err := q.GetFxConversion(ctx, result.Original.ID)
+		isConversion := err == nil
+
+		if isConversion {
+			credited = conversion.ToAmount
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		refund := arg.Amount
+		if refund == 0 {
+			refund = credited
+		}
+
+		if refund > credited {
+			return fmt.Errorf("%w: transfer [%d] credited %d, refund is %d", ErrRefundTooLarge, result.Original.ID, credited, refund)
+		}
+
+		returned := refund
+		if isConversion {
+			returned = reversalCredit(refund, conversion)
+			if returned <= 0 {
+				return ErrConversionTooSmall
+			}
+		}
+
+		//? money flows back: the original receiver sends, the original sender receives
+		fromAccountID := result.Original.ToAccountID
+		toAccountID := result.Original.FromAccountID
+
+		fromAccount, toAccount, err := lockAccounts(ctx, q, fromAccountID, toAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkNotFrozen(fromAccount, toAccount); err != nil {
+			return err
+		}
+
+		if err = checkAvailableFunds(fromAccount, refund); err != nil {
+			return err
+		}
+
+		result.Transfer, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        refund,
+			ReversalOf:    sql.NullInt64{Int64: result.Original.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  fromAccountID,
+			Amount:     -refund,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  toAccountID,
+			Amount:     returned,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if fromAccountID < toAccountID {
+			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, fromAccountID, -refund, toAccountID, returned)
+		} else {
+			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, toAccountID, returned, fromAccountID, -refund)
+		}
+		if err != nil {
+			return err
+		}
+
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionTransferReverse, AuditResourceTransfer,
+			strconv.FormatInt(result.Original.ID, 10),
+			auditAccounts{FromAccount: fromAccount, ToAccount: toAccount},
+			auditAccounts{FromAccount: result.FromAccount, ToAccount: result.ToAccount},
+		)
+	})
+
+	return result, err
+}
+
+// reversalCredit returns what the original sender gets back when refund is taken from the receiver of a cross-currency transfer:
+// the same share of what they paid, rounded down. The ledger check applies the same rule to reversals of conversions.
+func reversalCredit(refund int64, conversion FxConversion) int64 {
+	//? big.Int avoids overflowing int64 on refund * from_amount
+	credit := new(big.Int).Mul(big.NewInt(refund), big.NewInt(conversion.FromAmount))
+	credit.Quo(credit, big.NewInt(conversion.ToAmount))
+	return credit.Int64()
+}
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Statuses of a standing order. Only active standing orders are picked up by the worker.
+const (
+	ScheduledTransferActive    = "active"
+	ScheduledTransferPaused    = "paused"
+	ScheduledTransferCompleted = "completed"
+	ScheduledTransferFailed    = "failed"
+	ScheduledTransferCancelled = "cancelled"
+)
+
+// Outcomes of a single attempt to execute a standing order.
+const (
+	ScheduledTransferRunSucceeded = "succeeded"
+	ScheduledTransferRunFailed    = "failed"
+)
+
+// ErrScheduledRunSettled is returned when a standing order already moved past the run being executed,
+// because another attempt settled the run or the owner paused, cancelled or rescheduled the standing order.
+var ErrScheduledRunSettled = errors.New("scheduled transfer run has already been settled")
+
+// ErrScheduledTransferStatus is returned when the owner changes a standing order whose status no longer allows it,
+// e.g. one that completed or failed while the change was being prepared.
+var ErrScheduledTransferStatus = errors.New("scheduled transfer cannot be changed in its current status")
+
+type UpdateScheduledTransferTxParams struct {
+	ID int64 `json:"id"`
+	// AllowedStatuses are the statuses the standing order may be in for the update to apply.
+	AllowedStatuses []string `json:"allowed_statuses"`
+	// Update builds the change from the locked standing order, so it sees the outcome of any run executed meanwhile.
+	Update func(scheduledTransfer ScheduledTransfer) (UpdateScheduledTransferParams, error) `json:"-"`
+}
+
+type UpdateScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer `json:"scheduled_transfer"`
+}
+
+// UpdateScheduledTransferTx changes a standing order on behalf of its owner within a single database transaction.
+// The standing order is locked before its status is checked, so a run executed at the same time is either
+// finished before the check or starts after the change, and a finished standing order is never reopened.
+func (store *SQLStore) UpdateScheduledTransferTx(ctx context.Context, arg UpdateScheduledTransferTxParams) (UpdateScheduledTransferTxResult, error) {
+
+	var result UpdateScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		scheduledTransfer, err := q.GetScheduledTransferForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(arg.AllowedStatuses, scheduledTransfer.Status) {
+			return fmt.Errorf("%w: scheduled transfer [%d] is %s", ErrScheduledTransferStatus, scheduledTransfer.ID, scheduledTransfer.Status)
+		}
+
+		update, err := arg.Update(scheduledTransfer)
+		if err != nil {
+			return err
+		}
+
+		update.ID = scheduledTransfer.ID
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, update)
+		return err
+	})
+
+	return result, err
+}
+
+type ExecuteScheduledRunTxParams struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledFor        time.Time `json:"scheduled_for"`
+	Attempt             int32     `json:"attempt"`
+	// FinalAttempt is true when a failed attempt will not be retried, so the run is settled whatever its outcome.
+	FinalAttempt bool `json:"final_attempt"`
+	// NextRunAt computes the run after this one from the locked standing order once the run is settled.
+	// A zero time finishes the standing order.
+	NextRunAt func(scheduledTransfer ScheduledTransfer) (time.Time, error) `json:"-"`
+	Audit     AuditContext                                                 `json:"-"`
+}
+
+type ExecuteScheduledRunTxResult struct {
+	ScheduledTransfer ScheduledTransfer    `json:"scheduled_transfer"`
+	Run               ScheduledTransferRun `json:"run"`
+	// Transfer is set when the attempt succeeded.
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// ExecuteScheduledRunTx executes one attempt of a standing order run, records its outcome and, once the run is settled,
+// moves the standing order on to its next run within a single database transaction.
+// The standing order stays locked throughout, and UpdateScheduledTransferTx takes the same lock,
+// so the owner cannot pause, cancel or reschedule it while money is moving.
+// An attempt that fails for lack of funds or a frozen account is still recorded, and its error is returned after the commit.
+func (store *SQLStore) ExecuteScheduledRunTx(ctx context.Context, arg ExecuteScheduledRunTxParams) (ExecuteScheduledRunTxResult, error) {
+
+	var result ExecuteScheduledRunTxResult
+	var transferErr error
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		//? lock the standing order before the accounts, so concurrent attempts of the same run and owner updates wait for this one
+		scheduledTransfer, err := q.GetScheduledTransferForUpdate(ctx, arg.ScheduledTransferID)
+		if err != nil {
+			return err
+		}
+
+		if scheduledTransfer.Status != ScheduledTransferActive || !scheduledTransfer.NextRunAt.Equal(arg.ScheduledFor) {
+			return fmt.Errorf("%w: scheduled transfer [%d] is %s, next run at %s",
+				ErrScheduledRunSettled, scheduledTransfer.ID, scheduledTransfer.Status, scheduledTransfer.NextRunAt.Format(time.RFC3339))
+		}
+
+		var before auditAccounts
+
+		result.Transfer, before, transferErr = transfer(ctx, q, TransferTxParams{
+			FromAccountID: scheduledTransfer.FromAccountID,
+			ToAccountID:   scheduledTransfer.ToAccountID,
+			Amount:        scheduledTransfer.Amount,
+		})
+
+		//! only failures caught before anything is written leave the transaction usable for recording the run
+		if transferErr != nil && !errors.Is(transferErr, ErrInsufficientFunds) && !errors.Is(transferErr, ErrAccountFrozen) {
+			return transferErr
+		}
+
+		run := CreateScheduledTransferRunParams{
+			ScheduledTransferID: arg.ScheduledTransferID,
+			ScheduledFor:        arg.ScheduledFor,
+			Attempt:             arg.Attempt,
+			Status:              ScheduledTransferRunSucceeded,
+		}
+
+		if transferErr != nil {
+			run.Status = ScheduledTransferRunFailed
+			run.Error = transferErr.Error()
+		} else {
+			run.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+
+			err = recordAuditEvent(ctx, q, arg.Audit, AuditActionTransferCreate, AuditResourceTransfer,
+				strconv.FormatInt(result.Transfer.Transfer.ID, 10),
+				before,
+				auditAccounts{FromAccount: result.Transfer.FromAccount, ToAccount: result.Transfer.ToAccount},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, run)
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer = scheduledTransfer
+
+		//* the run will be attempted again
+		if transferErr != nil && !arg.FinalAttempt {
+			return nil
+		}
+
+		update := UpdateScheduledTransferParams{
+			ID: arg.ScheduledTransferID,
+		}
+
+		var nextRunAt time.Time
+		if arg.NextRunAt != nil {
+			if nextRunAt, err = arg.NextRunAt(scheduledTransfer); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case !nextRunAt.IsZero():
+			update.NextRunAt = sql.NullTime{Time: nextRunAt, Valid: true}
+		case transferErr == nil:
+			update.Status = sql.NullString{String: ScheduledTransferCompleted, Valid: true}
+		default:
+			update.Status = sql.NullString{String: ScheduledTransferFailed, Valid: true}
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, update)
+		return err
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	return result, transferErr
+}
@@ -1,12 +1,30 @@
 package db
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInsufficientFunds is returned by TransferTx when the sender's available balance cannot cover the transfer amount.
+// The whole transaction is rolled back when it is returned.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrAccountFrozen is returned by the transfer transactions when a banker has frozen either account.
+var ErrAccountFrozen = errors.New("account is frozen")
 
 // TransferTxParams contains the input parameters of the transfer transaction.
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	// IdempotencyKey is optional. When set, a retry with the same key replays the original result.
+	IdempotencyKey *IdempotencyParams `json:"-"`
+	// Audit describes who requested the transfer. It is left out of the idempotency hash,
+	// so a retry from another IP or user agent still replays the original result.
+	Audit AuditContext `json:"-"`
 }
 
 // TransferTxResults is the result of the transfer transcation.
@@ -24,63 +42,130 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 
 	var result TransferTxResult
 
-	err := store.execTx(ctx, func(q *Queries) error {
+	err := store.execIdempotentTx(ctx, arg.IdempotencyKey, "transfer", arg, &result, func(q *Queries) error {
 
-		//* keep track of latest error
+		var before auditAccounts
 		var err error
 
-		result.Transfer, err = q.CreateTranfer(ctx, CreateTranferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
+		result, before, err = transfer(ctx, q, arg)
 		if err != nil {
 			return err
 		}
 
-		//? update entry for sender (negative amount)
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+		return recordAuditEvent(ctx, q, arg.Audit, AuditActionTransferCreate, AuditResourceTransfer,
+			strconv.FormatInt(result.Transfer.ID, 10),
+			before,
+			auditAccounts{FromAccount: result.FromAccount, ToAccount: result.ToAccount},
+		)
+	})
 
-		if err != nil {
-			return err
-		}
+	return result, err
+}
 
-		//? update entry for receiver (positive amount)
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+// transfer moves money between two accounts of the same currency within the transaction of q.
+// It is shared by TransferTx and CaptureHoldTx and returns the accounts as they were before the transfer for the audit log.
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (result TransferTxResult, before auditAccounts, err error) {
 
-		if err != nil {
-			return err
-		}
+	//? lock both account rows before reading the sender's balance so that concurrent
+	//? transfers cannot both pass the balance check and overdraw the account
+	fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return
+	}
 
-		//! Avoid deadlock : always lock the record with the smaller Primary Key
-		//? sender account has the smaller id so perform update on its row first. Sender;s balance is balance is decremented
-		if arg.FromAccountID < arg.ToAccountID {
+	before = auditAccounts{FromAccount: fromAccount, ToAccount: toAccount}
 
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
+	if err = checkNotFrozen(fromAccount, toAccount); err != nil {
+		return
+	}
+
+	if err = checkAvailableFunds(fromAccount, arg.Amount); err != nil {
+		return
+	}
 
-			if err != nil {
-				return err
-			}
-			//? receiver account has the smaller id so perform update on its row first. Receiver's balance is incremented
-		} else {
+	result.Transfer, err = q.CreateTranfer(ctx, CreateTranferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+	})
+	if err != nil {
+		return
+	}
 
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+	//? update entry for sender (negative amount)
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.FromAccountID,
+		Amount:     -arg.Amount,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+	})
 
-			if err != nil {
-				return err
-			}
-		}
+	if err != nil {
+		return
+	}
 
-		return nil
+	//? update entry for receiver (positive amount)
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.ToAccountID,
+		Amount:     arg.Amount,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
 	})
 
-	return result, err
+	if err != nil {
+		return
+	}
+
+	//! Avoid deadlock : always lock the record with the smaller Primary Key
+	//? sender account has the smaller id so perform update on its row first. Sender;s balance is balance is decremented
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
+		return
+	}
+
+	//? receiver account has the smaller id so perform update on its row first. Receiver's balance is incremented
+	result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+	return
+}
+
+// lockAccounts locks the rows of both transfer accounts with SELECT ... FOR NO KEY UPDATE and returns them.
+// The row with the smaller primary key is always locked first to avoid deadlocks.
+func lockAccounts(ctx context.Context, q *Queries, fromAccountID int64, toAccountID int64) (fromAccount Account, toAccount Account, err error) {
+	if fromAccountID < toAccountID {
+		if fromAccount, err = q.GetAccountForUpdate(ctx, fromAccountID); err != nil {
+			return
+		}
+
+		toAccount, err = q.GetAccountForUpdate(ctx, toAccountID)
+		return
+	}
+
+	if toAccount, err = q.GetAccountForUpdate(ctx, toAccountID); err != nil {
+		return
+	}
+
+	fromAccount, err = q.GetAccountForUpdate(ctx, fromAccountID)
+	return
+}
+
+// checkAvailableFunds returns ErrInsufficientFunds if the available balance of the account, its balance less the active holds,
+// cannot cover amount. Every transaction that debits an account checks it, so held funds cannot be spent twice.
+func checkAvailableFunds(account Account, amount int64) error {
+	if account.AvailableBalance < amount {
+		return fmt.Errorf("%w: account [%d] has available balance %d, debit needs %d", ErrInsufficientFunds, account.ID, account.AvailableBalance, amount)
+	}
+
+	return nil
+}
+
+// checkNotFrozen returns ErrAccountFrozen if any of the accounts has been frozen.
+// Frozen accounts can neither send nor receive money.
+func checkNotFrozen(accounts ...Account) error {
+	for _, account := range accounts {
+		if account.IsFrozen {
+			return fmt.Errorf("%w: account [%d]", ErrAccountFrozen, account.ID)
+		}
+	}
+
+	return nil
 }
 
 func addMoney(
@@ -0,0 +1,56 @@
+package db
+
+import "context"
+
+type UpdateUserTxParams struct {
+	UpdateUserParams
+	Audit AuditContext
+	// OutboxMessages returns the background tasks of the updated user (e.g., verifying a new email address),
+	// which are written to the outbox within the same transaction. It is optional.
+	OutboxMessages func(user User) ([]CreateOutboxMessageParams, error)
+}
+
+type UpdateUserTxResult struct {
+	User User
+}
+
+// UpdateUserTx updates a user and records the change in the audit log within a single database transaction.
+// A change of role is recorded as its own action, so privilege changes are easy to find.
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error) {
+
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		//? lock the user so the before snapshot is the row that is actually updated
+		before, err := q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUser(ctx, arg.UpdateUserParams)
+		if err != nil {
+			return err
+		}
+
+		action := AuditActionUserUpdate
+		if result.User.Role != before.Role {
+			action = AuditActionUserRoleChange
+		}
+
+		err = recordAuditEvent(ctx, q, arg.Audit, action, AuditResourceUser,
+			result.User.Username, newAuditUser(before), newAuditUser(result.User))
+		if err != nil || arg.OutboxMessages == nil {
+			return err
+		}
+
+		messages, err := arg.OutboxMessages(result.User)
+		if err != nil {
+			return err
+		}
+
+		return writeOutbox(ctx, q, messages)
+	})
+
+	return result, err
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrInvalidVerifyEmail is returned when a verification link is unknown, expired, already used,
+// or was sent to an address the user no longer has.
+var ErrInvalidVerifyEmail = errors.New("invalid or expired email verification link")
+
+type VerifyEmailTxParams struct {
+	EmailID    int64
+	SecretCode string
+}
+
+type VerifyEmailTxResult struct {
+	User        User
+	VerifyEmail VerifyEmail
+}
+
+// VerifyEmailTx consumes a verification link and marks the user's email address as verified within a single database transaction.
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
+
+	var result VerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		var err error
+
+		//? only matches an unused, unexpired link with the right secret, and marks it used so it works once
+		result.VerifyEmail, err = q.UpdateVerifyEmail(ctx, UpdateVerifyEmailParams{
+			ID:         arg.EmailID,
+			SecretCode: arg.SecretCode,
+		})
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrInvalidVerifyEmail
+			}
+			return err
+		}
+
+		result.User, err = q.GetUser(ctx, result.VerifyEmail.Username)
+		if err != nil {
+			return err
+		}
+
+		//? the user changed their address after the link was sent
+		if result.User.Email != result.VerifyEmail.Email {
+			return ErrInvalidVerifyEmail
+		}
+
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username: result.User.Username,
+			IsEmailVerified: sql.NullBool{
+				Bool:  true,
+				Valid: true,
+			},
+		})
+
+		return err
+	})
+
+	return result, err
+}
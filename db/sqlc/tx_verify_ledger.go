@@ -0,0 +1,58 @@
+package db
+
+import "context"
+
+// VerifyLedgerTxResult lists every place where the stored balances and transfers disagree with the entries.
+type VerifyLedgerTxResult struct {
+	AccountsChecked     int64                        `json:"accounts_checked"`
+	BalanceDrifts       []ListBalanceDriftsRow       `json:"balance_drifts"`
+	HeldBalanceDrifts   []ListHeldBalanceDriftsRow   `json:"held_balance_drifts"`
+	UnbalancedTransfers []ListUnbalancedTransfersRow `json:"unbalanced_transfers"`
+	OrphanEntries       []Entry                      `json:"orphan_entries"`
+	// LegacyEntries counts the entries recorded before transfers were tracked. They cannot be checked against a transfer,
+	// but their amounts still count towards the balances.
+	LegacyEntries int64 `json:"legacy_entries"`
+}
+
+// VerifyLedgerTx recomputes the balance of every account from its entries and its held balance from its active holds,
+// and checks that every transfer is booked as exactly one debit and one matching credit. The checks read one consistent snapshot,
+// so transfers committed while they run cannot show up as drift.
+func (store *SQLStore) VerifyLedgerTx(ctx context.Context) (VerifyLedgerTxResult, error) {
+
+	var result VerifyLedgerTxResult
+
+	err := store.execReadTx(ctx, func(q *Queries) error {
+
+		var err error
+
+		result.AccountsChecked, err = q.CountAccounts(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.BalanceDrifts, err = q.ListBalanceDrifts(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.HeldBalanceDrifts, err = q.ListHeldBalanceDrifts(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.UnbalancedTransfers, err = q.ListUnbalancedTransfers(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.OrphanEntries, err = q.ListOrphanEntries(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.LegacyEntries, err = q.CountLegacyEntries(ctx)
+		return err
+	})
+
+	return result, err
+}
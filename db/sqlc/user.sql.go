@@ -16,7 +16,7 @@ INSERT INTO users(
     hashed_password,
     full_name,
     email
-) VALUES ($1, $2, $3, $4) RETURNING username, hashed_password, full_name, email, created_at, password_changed_at
+) VALUES ($1, $2, $3, $4) RETURNING username, hashed_password, full_name, email, created_at, password_changed_at, is_email_verified, role
 `
 
 type CreateUserParams struct {
@@ -41,12 +41,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.CreatedAt,
 		&i.PasswordChangedAt,
+		&i.IsEmailVerified,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, created_at, password_changed_at FROM users
+SELECT username, hashed_password, full_name, email, created_at, password_changed_at, is_email_verified, role FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -60,6 +62,51 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Email,
 		&i.CreatedAt,
 		&i.PasswordChangedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT username, hashed_password, full_name, email, created_at, password_changed_at, is_email_verified, role FROM users
+WHERE email = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.CreatedAt,
+		&i.PasswordChangedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, created_at, password_changed_at, is_email_verified, role FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.CreatedAt,
+		&i.PasswordChangedAt,
+		&i.IsEmailVerified,
+		&i.Role,
 	)
 	return i, err
 }
@@ -71,10 +118,12 @@ SET
     hashed_password = COALESCE($1, hashed_password),
     password_changed_at = COALESCE($2, password_changed_at),
     full_name =  COALESCE($3, full_name ),
-    email = COALESCE($4, email)
+    email = COALESCE($4, email),
+    is_email_verified = COALESCE($5, is_email_verified),
+    role = COALESCE($6, role)
 WHERE
-    username = $5
-RETURNING username, hashed_password, full_name, email, created_at, password_changed_at
+    username = $7
+RETURNING username, hashed_password, full_name, email, created_at, password_changed_at, is_email_verified, role
 `
 
 type UpdateUserParams struct {
@@ -82,6 +131,8 @@ type UpdateUserParams struct {
 	PasswordChangedAt sql.NullTime   `json:"password_changed_at"`
 	FullName          sql.NullString `json:"full_name"`
 	Email             sql.NullString `json:"email"`
+	IsEmailVerified   sql.NullBool   `json:"is_email_verified"`
+	Role              sql.NullString `json:"role"`
 	Username          string         `json:"username"`
 }
 
@@ -91,6 +142,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.PasswordChangedAt,
 		arg.FullName,
 		arg.Email,
+		arg.IsEmailVerified,
+		arg.Role,
 		arg.Username,
 	)
 	var i User
@@ -101,6 +154,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.CreatedAt,
 		&i.PasswordChangedAt,
+		&i.IsEmailVerified,
+		&i.Role,
 	)
 	return i, err
 }
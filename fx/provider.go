@@ -0,0 +1,90 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+)
+
+// Rate is the mid-market exchange rate from one currency to another, scaled by util.FXRateScale.
+type Rate struct {
+	FromCurrency string
+	ToCurrency   string
+	Rate         int64
+}
+
+// RateProvider is a source of mid-market exchange rates.
+type RateProvider interface {
+	// FetchRates returns a rate for every ordered pair of supported currencies.
+	FetchRates(ctx context.Context) ([]Rate, error)
+}
+
+// rateFile is the on-disk format read by FileRateProvider.
+// Every rate is the number of units of that currency per unit of the base currency.
+type rateFile struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// FileRateProvider reads exchange rates from a JSON file. It is meant for tests and local development.
+type FileRateProvider struct {
+	path string
+}
+
+// NewFileRateProvider creates a RateProvider that reads rates from the JSON file at path.
+func NewFileRateProvider(path string) RateProvider {
+	return &FileRateProvider{path: path}
+}
+
+func (provider *FileRateProvider) FetchRates(ctx context.Context) ([]Rate, error) {
+	data, err := os.ReadFile(provider.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate file: %w", err)
+	}
+
+	var file rateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rate file: %w", err)
+	}
+
+	// the base currency is implicitly worth exactly one unit of itself
+	baseRates := map[string]float64{file.Base: 1}
+	for currency, rate := range file.Rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("invalid rate for %s: %v", currency, rate)
+		}
+		baseRates[currency] = rate
+	}
+
+	//? derive cross rates for every pair of supported currencies through the base currency
+	var rates []Rate
+	for _, from := range util.SupportedCurrencies {
+		for _, to := range util.SupportedCurrencies {
+			if from == to {
+				continue
+			}
+
+			fromRate, ok := baseRates[from]
+			if !ok {
+				return nil, fmt.Errorf("missing rate for %s", from)
+			}
+
+			toRate, ok := baseRates[to]
+			if !ok {
+				return nil, fmt.Errorf("missing rate for %s", to)
+			}
+
+			rates = append(rates, Rate{
+				FromCurrency: from,
+				ToCurrency:   to,
+				Rate:         int64(math.Round(toRate / fromRate * util.FXRateScale)),
+			})
+		}
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,43 @@
+package fx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRateProvider(t *testing.T) {
+	provider := NewFileRateProvider("testdata/rates.json")
+
+	rates, err := provider.FetchRates(context.Background())
+	require.NoError(t, err)
+
+	//? one rate for every ordered pair of distinct supported currencies
+	n := len(util.SupportedCurrencies)
+	require.Len(t, rates, n*(n-1))
+
+	got := make(map[string]int64)
+	for _, rate := range rates {
+		require.NotEqual(t, rate.FromCurrency, rate.ToCurrency)
+		require.True(t, util.IsSupportedCurrency(rate.FromCurrency))
+		require.True(t, util.IsSupportedCurrency(rate.ToCurrency))
+		got[rate.FromCurrency+rate.ToCurrency] = rate.Rate
+	}
+
+	// testdata: 1 USD = 0.8 EUR = 1.25 CAD
+	require.Equal(t, int64(80_000_000), got[util.USD+util.EUR])
+	require.Equal(t, int64(125_000_000), got[util.EUR+util.USD])
+	require.Equal(t, int64(125_000_000), got[util.USD+util.CAD])
+	//? cross rate derived through the base currency
+	require.Equal(t, int64(156_250_000), got[util.EUR+util.CAD])
+}
+
+func TestFileRateProviderMissingFile(t *testing.T) {
+	provider := NewFileRateProvider("testdata/does_not_exist.json")
+
+	rates, err := provider.FetchRates(context.Background())
+	require.Error(t, err)
+	require.Nil(t, rates)
+}
@@ -0,0 +1,29 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+)
+
+// SyncRates fetches the latest rates from the provider and stores them in the fx_rates table.
+func SyncRates(ctx context.Context, store db.Store, provider RateProvider) error {
+	rates, err := provider.FetchRates(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch rates: %w", err)
+	}
+
+	for _, rate := range rates {
+		_, err := store.UpsertFxRate(ctx, db.UpsertFxRateParams{
+			FromCurrency: rate.FromCurrency,
+			ToCurrency:   rate.ToCurrency,
+			Rate:         rate.Rate,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store rate %s/%s: %w", rate.FromCurrency, rate.ToCurrency, err)
+		}
+	}
+
+	return nil
+}
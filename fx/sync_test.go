@@ -0,0 +1,47 @@
+package fx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSyncRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	provider := NewFileRateProvider("testdata/rates.json")
+
+	n := len(util.SupportedCurrencies)
+	store.EXPECT().
+		UpsertFxRate(gomock.Any(), gomock.Any()).
+		Times(n*(n-1)).
+		Return(db.FxRate{}, nil)
+
+	err := SyncRates(context.Background(), store, provider)
+	require.NoError(t, err)
+}
+
+func TestSyncRatesStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	provider := NewFileRateProvider("testdata/rates.json")
+
+	//? stop at the first failed write
+	store.EXPECT().
+		UpsertFxRate(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.FxRate{}, sql.ErrConnDone)
+
+	err := SyncRates(context.Background(), store, provider)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
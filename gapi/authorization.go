@@ -27,7 +27,11 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 		return nil, fmt.Errorf("missing authorization header")
 	}
 
-	authHeader := values[0]
+	return server.verifyAuthorizationHeader(values[0])
+}
+
+// verifyAuthorizationHeader verifies the access token of a "Bearer <token>" authorization header.
+func (server *Server) verifyAuthorizationHeader(authHeader string) (*token.Payload, error) {
 	fields := strings.Fields(authHeader)
 
 	if len(fields) < 2 {
@@ -42,7 +46,7 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 
 	accessToken := fields[1]
 
-	payload, err := server.tokenMaker.VerifyToken(accessToken)
+	payload, err := server.tokenMaker.VerifyToken(accessToken, token.TokenTypeAccessToken)
 
 	if err != nil {
 		return nil, fmt.Errorf("invalid access token")
@@ -3,6 +3,8 @@ package gapi
 import (
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
 	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/statement"
+	"github.com/VihangaFTW/Go-Backend/token"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -13,5 +15,195 @@ func convertUser(user db.User) *pb.User {
 		Email:             user.Email,
 		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
 		CreatedAt:         timestamppb.New(user.CreatedAt),
+		IsEmailVerified:   user.IsEmailVerified,
+		Role:              user.Role,
+	}
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		CreatedAt:        timestamppb.New(account.CreatedAt),
+		IsFrozen:         account.IsFrozen,
+		HeldBalance:      account.HeldBalance,
+		AvailableBalance: account.AvailableBalance,
+	}
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	converted := &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+	}
+
+	if transfer.ReversalOf.Valid {
+		converted.ReversalOf = &transfer.ReversalOf.Int64
+	}
+
+	return converted
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:        entry.ID,
+		AccountId: entry.AccountID,
+		Amount:    entry.Amount,
+	}
+}
+
+func convertFxQuote(quote db.FxQuote) *pb.FxQuote {
+	return &pb.FxQuote{
+		Id:           quote.ID.String(),
+		FromCurrency: quote.FromCurrency,
+		ToCurrency:   quote.ToCurrency,
+		Rate:         quote.Rate,
+		SpreadBps:    quote.SpreadBps,
+		ExpiresAt:    timestamppb.New(quote.ExpiresAt),
+	}
+}
+
+func convertFxConversion(conversion db.FxConversion) *pb.FxConversion {
+	return &pb.FxConversion{
+		QuoteId:      conversion.QuoteID.String(),
+		FromCurrency: conversion.FromCurrency,
+		ToCurrency:   conversion.ToCurrency,
+		FromAmount:   conversion.FromAmount,
+		ToAmount:     conversion.ToAmount,
+		Rate:         conversion.Rate,
+		SpreadBps:    conversion.SpreadBps,
+	}
+}
+
+func convertTransferTxResult(result db.TransferTxResult) *pb.CreateTransferResponse {
+	return &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+}
+
+func convertHold(hold db.Hold) *pb.Hold {
+	converted := &pb.Hold{
+		Id:             hold.ID,
+		Owner:          hold.Owner,
+		AccountId:      hold.AccountID,
+		ToAccountId:    hold.ToAccountID,
+		Amount:         hold.Amount,
+		Status:         hold.Status,
+		ExpiresAt:      timestamppb.New(hold.ExpiresAt),
+		CapturedAmount: hold.CapturedAmount,
+		CreatedAt:      timestamppb.New(hold.CreatedAt),
+	}
+
+	if hold.TransferID.Valid {
+		converted.TransferId = &hold.TransferID.Int64
+	}
+
+	if hold.ClosedAt.Valid {
+		converted.ClosedAt = timestamppb.New(hold.ClosedAt.Time)
+	}
+
+	return converted
+}
+
+func convertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	return &pb.ScheduledTransfer{
+		Id:             scheduledTransfer.ID,
+		FromAccountId:  scheduledTransfer.FromAccountID,
+		ToAccountId:    scheduledTransfer.ToAccountID,
+		Amount:         scheduledTransfer.Amount,
+		Frequency:      scheduledTransfer.Frequency,
+		CronExpression: scheduledTransfer.CronExpression,
+		StartAt:        timestamppb.New(scheduledTransfer.StartAt),
+		NextRunAt:      timestamppb.New(scheduledTransfer.NextRunAt),
+		Status:         scheduledTransfer.Status,
+		CreatedAt:      timestamppb.New(scheduledTransfer.CreatedAt),
+	}
+}
+
+func convertScheduledTransferRun(run db.ScheduledTransferRun) *pb.ScheduledTransferRun {
+	return &pb.ScheduledTransferRun{
+		Id:           run.ID,
+		ScheduledFor: timestamppb.New(run.ScheduledFor),
+		Attempt:      run.Attempt,
+		TransferId:   run.TransferID.Int64,
+		Status:       run.Status,
+		Error:        run.Error,
+		CreatedAt:    timestamppb.New(run.CreatedAt),
+	}
+}
+
+func convertStatementLine(line statement.Line) *pb.StatementLine {
+	return &pb.StatementLine{
+		EntryId:               line.EntryID,
+		TransferId:            line.TransferID,
+		Amount:                line.Amount,
+		Balance:               line.Balance,
+		CounterpartyAccountId: line.CounterpartyAccountID,
+		CounterpartyOwner:     line.CounterpartyOwner,
+		CreatedAt:             timestamppb.New(line.CreatedAt),
+	}
+}
+
+func convertStatementExport(export db.StatementExport) *pb.StatementExport {
+	response := &pb.StatementExport{
+		Id:        export.ID.String(),
+		AccountId: export.AccountID,
+		Format:    export.Format,
+		FromTime:  timestamppb.New(export.FromTime),
+		ToTime:    timestamppb.New(export.ToTime),
+		Status:    export.Status,
+		Error:     export.Error,
+		CreatedAt: timestamppb.New(export.CreatedAt),
+	}
+
+	if export.CompletedAt.Valid {
+		response.CompletedAt = timestamppb.New(export.CompletedAt.Time)
+	}
+
+	return response
+}
+
+func convertSession(session db.Session) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		CreatedAt: timestamppb.New(session.CreatedAt),
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+	}
+}
+
+func convertAuditEvent(event db.AuditEvent) *pb.AuditEvent {
+	return &pb.AuditEvent{
+		Id:           event.ID,
+		Actor:        event.Actor,
+		Action:       event.Action,
+		ResourceType: event.ResourceType,
+		ResourceId:   event.ResourceID,
+		Before:       string(event.Before),
+		After:        string(event.After),
+		ClientIp:     event.ClientIp,
+		UserAgent:    event.UserAgent,
+		RequestId:    event.RequestID,
+		CreatedAt:    timestamppb.New(event.CreatedAt),
+	}
+}
+
+func convertTokenKey(key token.PublicKey) *pb.TokenKey {
+	return &pb.TokenKey{
+		Kid:       key.KeyID,
+		Version:   key.Version,
+		PublicKey: key.Key,
+		Status:    key.Status,
 	}
 }
@@ -1,9 +1,14 @@
 package gapi
 
 import (
+	"errors"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/mfa"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
@@ -41,3 +46,30 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
 }
+
+// mfaError maps the errors returned by the mfa.Authenticator to gRPC status errors.
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, mfa.ErrInvalidCode):
+		return unauthenticatedError(err)
+	case errors.Is(err, mfa.ErrNotEnrolled), errors.Is(err, mfa.ErrAlreadyEnabled):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+
+	return status.Errorf(codes.Internal, "%s", err)
+}
+
+// resourceExhaustedError attaches the time to wait as RetryInfo details, which gRPC clients use to back off.
+func resourceExhaustedError(retryAfter time.Duration, format string, args ...any) error {
+	statusExhausted := status.Newf(codes.ResourceExhausted, format, args...)
+
+	statusDetails, err := statusExhausted.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+
+	if err != nil {
+		return statusExhausted.Err()
+	}
+
+	return statusDetails.Err()
+}
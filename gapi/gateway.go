@@ -0,0 +1,120 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewGatewayClient returns a client that calls the server in process, for the HTTP gateway to register with.
+// Registering the server itself would call the handlers directly and skip every gRPC interceptor,
+// so the gateway goes through the same interceptors as a network client instead.
+func NewGatewayClient(server *Server, interceptors ...grpc.UnaryServerInterceptor) pb.SimpleBankClient {
+	conn := &inProcessConn{
+		server:      server,
+		interceptor: chainUnaryInterceptors(interceptors),
+		methods:     make(map[string]grpc.MethodDesc),
+	}
+
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		conn.methods["/"+pb.SimpleBank_ServiceDesc.ServiceName+"/"+method.MethodName] = method
+	}
+
+	return pb.NewSimpleBankClient(conn)
+}
+
+// inProcessConn implements grpc.ClientConnInterface by dispatching calls to the server's method handlers.
+type inProcessConn struct {
+	server      *Server
+	interceptor grpc.UnaryServerInterceptor
+	methods     map[string]grpc.MethodDesc
+}
+
+func (conn *inProcessConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	desc, ok := conn.methods[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+
+	//? the gateway forwards HTTP headers as outgoing metadata, which the handlers read as incoming metadata
+	md, _ := metadata.FromOutgoingContext(ctx)
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	//? captures the headers set by handlers, e.g. the Content-Disposition of file downloads
+	stream := &inProcessStream{method: method}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	dec := func(req any) error {
+		proto.Merge(req.(proto.Message), args.(proto.Message))
+		return nil
+	}
+
+	resp, err := desc.Handler(conn.server, ctx, dec, conn.interceptor)
+
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case grpc.HeaderCallOption:
+			*opt.HeaderAddr = stream.header
+		case grpc.TrailerCallOption:
+			*opt.TrailerAddr = stream.trailer
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	proto.Merge(reply.(proto.Message), resp.(proto.Message))
+	return nil
+}
+
+func (conn *inProcessConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "streaming method %s is not available through the gateway", method)
+}
+
+// inProcessStream is the grpc.ServerTransportStream of a single in process call.
+type inProcessStream struct {
+	method  string
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (stream *inProcessStream) Method() string {
+	return stream.method
+}
+
+func (stream *inProcessStream) SetHeader(md metadata.MD) error {
+	stream.header = metadata.Join(stream.header, md)
+	return nil
+}
+
+func (stream *inProcessStream) SendHeader(md metadata.MD) error {
+	return stream.SetHeader(md)
+}
+
+func (stream *inProcessStream) SetTrailer(md metadata.MD) error {
+	stream.trailer = metadata.Join(stream.trailer, md)
+	return nil
+}
+
+// chainUnaryInterceptors combines interceptors into one that runs them in the given order,
+// like grpc.ChainUnaryInterceptor does for a grpc.Server.
+func chainUnaryInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,176 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methodPolicy describes who may call an RPC.
+// A zero value is not a valid policy: every method must be listed in methodPolicies explicitly.
+type methodPolicy struct {
+	// public methods skip authentication entirely, e.g. signing up or logging in
+	public bool
+	// roles, when not empty, restricts the method to users holding one of these roles
+	roles []string
+	// scope, when set, restricts the method to roles that are granted this permission
+	scope policy.Permission
+}
+
+func publicMethod() methodPolicy {
+	return methodPolicy{public: true}
+}
+
+func authenticated() methodPolicy {
+	return methodPolicy{}
+}
+
+func requireRole(roles ...string) methodPolicy {
+	return methodPolicy{roles: roles}
+}
+
+func requireScope(scope policy.Permission) methodPolicy {
+	return methodPolicy{scope: scope}
+}
+
+// methodPolicies lists the policy of every RPC by its full method name.
+// An RPC missing from this table is rejected, so a new RPC must be added here before it can be called.
+var methodPolicies = map[string]methodPolicy{
+	pb.SimpleBank_CreateUser_FullMethodName:       publicMethod(),
+	pb.SimpleBank_LoginUser_FullMethodName:        publicMethod(),
+	pb.SimpleBank_VerifyEmail_FullMethodName:      publicMethod(),
+	pb.SimpleBank_RenewAccessToken_FullMethodName: publicMethod(),
+	pb.SimpleBank_LogoutUser_FullMethodName:       publicMethod(),
+	pb.SimpleBank_ListTokenKeys_FullMethodName:    publicMethod(),
+	//? authenticated by the mfa token in the request, which is not an access token
+	pb.SimpleBank_VerifyLoginMFA_FullMethodName: publicMethod(),
+	//? authenticated by the emailed token in the request
+	pb.SimpleBank_RequestPasswordReset_FullMethodName: publicMethod(),
+	pb.SimpleBank_ResetPassword_FullMethodName:        publicMethod(),
+
+	pb.SimpleBank_UpdateUser_FullMethodName:               authenticated(),
+	pb.SimpleBank_CreateAccount_FullMethodName:            authenticated(),
+	pb.SimpleBank_GetAccount_FullMethodName:               authenticated(),
+	pb.SimpleBank_ListAccounts_FullMethodName:             authenticated(),
+	pb.SimpleBank_CreateTransfer_FullMethodName:           authenticated(),
+	pb.SimpleBank_ReverseTransfer_FullMethodName:          authenticated(),
+	pb.SimpleBank_PlaceHold_FullMethodName:                authenticated(),
+	pb.SimpleBank_CaptureHold_FullMethodName:              authenticated(),
+	pb.SimpleBank_ReleaseHold_FullMethodName:              authenticated(),
+	pb.SimpleBank_GetHold_FullMethodName:                  authenticated(),
+	pb.SimpleBank_CreateFxQuote_FullMethodName:            authenticated(),
+	pb.SimpleBank_CreateScheduledTransfer_FullMethodName:  authenticated(),
+	pb.SimpleBank_GetScheduledTransfer_FullMethodName:     authenticated(),
+	pb.SimpleBank_ListScheduledTransfers_FullMethodName:   authenticated(),
+	pb.SimpleBank_UpdateScheduledTransfer_FullMethodName:  authenticated(),
+	pb.SimpleBank_DeleteScheduledTransfer_FullMethodName:  authenticated(),
+	pb.SimpleBank_GetAccountStatement_FullMethodName:      authenticated(),
+	pb.SimpleBank_DownloadAccountStatement_FullMethodName: authenticated(),
+	pb.SimpleBank_CreateStatementExport_FullMethodName:    authenticated(),
+	pb.SimpleBank_GetStatementExport_FullMethodName:       authenticated(),
+	pb.SimpleBank_DownloadStatementExport_FullMethodName:  authenticated(),
+	pb.SimpleBank_ListSessions_FullMethodName:             authenticated(),
+	pb.SimpleBank_RevokeSession_FullMethodName:            authenticated(),
+	pb.SimpleBank_RevokeOtherSessions_FullMethodName:      authenticated(),
+	pb.SimpleBank_EnrollMFA_FullMethodName:                authenticated(),
+	pb.SimpleBank_ConfirmMFA_FullMethodName:               authenticated(),
+	pb.SimpleBank_DisableMFA_FullMethodName:               authenticated(),
+
+	pb.SimpleBank_FreezeAccount_FullMethodName:   requireScope(policy.FreezeAccount),
+	pb.SimpleBank_UnfreezeAccount_FullMethodName: requireScope(policy.FreezeAccount),
+	pb.SimpleBank_ListAuditEvents_FullMethodName: requireScope(policy.ReadAuditLog),
+}
+
+// AuthUnaryInterceptor authenticates unary RPCs according to methodPolicies
+// and passes the verified token payload on to the handler through the context.
+func (server *Server) AuthUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+
+	ctx, err := server.authorizeMethod(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// AuthStreamInterceptor applies the same policies as AuthUnaryInterceptor to streaming RPCs.
+func (server *Server) AuthStreamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+
+	ctx, err := server.authorizeMethod(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authorizedStream carries the context with the token payload, as grpc.ServerStream has no way to replace its context.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *authorizedStream) Context() context.Context {
+	return stream.ctx
+}
+
+// authorizeMethod checks the caller against the policy of the method.
+// The returned error is already a gRPC status error.
+func (server *Server) authorizeMethod(ctx context.Context, method string) (context.Context, error) {
+	methodPolicy, ok := methodPolicies[method]
+
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no access policy for method %s", method)
+	}
+
+	if methodPolicy.public {
+		return ctx, nil
+	}
+
+	authPayload, err := server.authorizeUser(ctx)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if len(methodPolicy.roles) > 0 && !slices.Contains(methodPolicy.roles, authPayload.Role) {
+		return nil, status.Errorf(codes.PermissionDenied, "role %s cannot call %s", authPayload.Role, method)
+	}
+
+	if methodPolicy.scope != "" && !policy.Allowed(authPayload, methodPolicy.scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", methodPolicy.scope)
+	}
+
+	return context.WithValue(ctx, authPayloadKey{}, authPayload), nil
+}
+
+type authPayloadKey struct{}
+
+// authPayloadFromContext returns the token payload verified by the auth interceptor.
+// Handlers of public methods have no payload, which is reported as an authentication error.
+func authPayloadFromContext(ctx context.Context) (*token.Payload, error) {
+	authPayload, ok := ctx.Value(authPayloadKey{}).(*token.Payload)
+
+	if !ok {
+		return nil, unauthenticatedError(fmt.Errorf("missing access token"))
+	}
+
+	return authPayload, nil
+}
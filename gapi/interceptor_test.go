@@ -0,0 +1,191 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer(t *testing.T, store db.Store) *Server {
+	baseMaker, err := token.NewPasetoMaker(token.TestingHexKey)
+	require.NoError(t, err)
+
+	tokenMaker := token.NewRevocationMaker(baseMaker, token.NewMemoryDenyList(), time.Hour)
+
+	loginGuard := lockout.NewGuard(lockout.NewMemoryCounter())
+
+	return &Server{store: store, tokenMaker: tokenMaker, tokenRevoker: tokenMaker, loginGuard: loginGuard}
+}
+
+// newContextWithBearerToken returns an outgoing context that authenticates as the given user.
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, role string, tokenType token.TokenType) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(username, role, tokenType, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	md := metadata.Pairs(authorizationHeader, fmt.Sprintf("%s %s", authorizationType, accessToken))
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
+
+// incoming turns the metadata of an outgoing context into incoming metadata, as seen by an interceptor.
+func incoming(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestEveryMethodHasPolicy(t *testing.T) {
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		fullMethod := "/" + pb.SimpleBank_ServiceDesc.ServiceName + "/" + method.MethodName
+		require.Contains(t, methodPolicies, fullMethod)
+	}
+
+	for _, stream := range pb.SimpleBank_ServiceDesc.Streams {
+		fullMethod := "/" + pb.SimpleBank_ServiceDesc.ServiceName + "/" + stream.StreamName
+		require.Contains(t, methodPolicies, fullMethod)
+	}
+}
+
+func TestAuthorizeMethod(t *testing.T) {
+	server := newTestServer(t, nil)
+	username := util.RandomOwner()
+
+	//? a method without a policy is rejected even with a valid token
+	ctx := incoming(newContextWithBearerToken(t, server.tokenMaker, username, util.AdminRole, token.TokenTypeAccessToken))
+	_, err := server.authorizeMethod(ctx, "/pb.SimpleBank/Unknown")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.authorizeMethod(context.Background(), pb.SimpleBank_LoginUser_FullMethodName)
+	require.NoError(t, err)
+
+	_, err = server.authorizeMethod(context.Background(), pb.SimpleBank_GetAccount_FullMethodName)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = incoming(newContextWithBearerToken(t, server.tokenMaker, username, util.DepositorRole, token.TokenTypeRefreshToken))
+	_, err = server.authorizeMethod(ctx, pb.SimpleBank_GetAccount_FullMethodName)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = incoming(newContextWithBearerToken(t, server.tokenMaker, username, util.DepositorRole, token.TokenTypeAccessToken))
+	ctx, err = server.authorizeMethod(ctx, pb.SimpleBank_GetAccount_FullMethodName)
+	require.NoError(t, err)
+
+	authPayload, err := authPayloadFromContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, username, authPayload.Username)
+}
+
+func TestAuthorizeRevokedToken(t *testing.T) {
+	server := newTestServer(t, nil)
+	username := util.RandomOwner()
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(username, util.DepositorRole, token.TokenTypeAccessToken, uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	md := metadata.Pairs(authorizationHeader, fmt.Sprintf("%s %s", authorizationType, accessToken))
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	_, err = server.authorizeMethod(ctx, pb.SimpleBank_GetAccount_FullMethodName)
+	require.NoError(t, err)
+
+	//? signing out revokes the family, which rejects the access token before it expires
+	require.NoError(t, server.tokenRevoker.RevokeFamily(context.Background(), accessPayload.FamilyID, accessPayload.ExpiresAt))
+
+	_, err = server.authorizeMethod(ctx, pb.SimpleBank_GetAccount_FullMethodName)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthorizeMethodRolesAndScopes(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	methodPolicies["/pb.SimpleBank/AdminOnly"] = requireRole(util.AdminRole)
+	defer delete(methodPolicies, "/pb.SimpleBank/AdminOnly")
+
+	testcases := []struct {
+		name   string
+		method string
+		role   string
+		code   codes.Code
+	}{
+		{
+			name:   "DepositorCannotFreeze",
+			method: pb.SimpleBank_FreezeAccount_FullMethodName,
+			role:   util.DepositorRole,
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "BankerCanFreeze",
+			method: pb.SimpleBank_UnfreezeAccount_FullMethodName,
+			role:   util.BankerRole,
+			code:   codes.OK,
+		},
+		{
+			name:   "BankerIsNotAdmin",
+			method: "/pb.SimpleBank/AdminOnly",
+			role:   util.BankerRole,
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "Admin",
+			method: "/pb.SimpleBank/AdminOnly",
+			role:   util.AdminRole,
+			code:   codes.OK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := incoming(newContextWithBearerToken(t, server.tokenMaker, util.RandomOwner(), tc.role, token.TokenTypeAccessToken))
+
+			_, err := server.authorizeMethod(ctx, tc.method)
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func TestGatewayClientRunsInterceptors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	client := NewGatewayClient(server, server.AuthUnaryInterceptor)
+
+	account := db.Account{ID: util.RandomInt(1, 1000), Owner: util.RandomOwner(), Currency: util.USD, IsFrozen: true}
+
+	banker := util.RandomOwner()
+
+	store.EXPECT().
+		SetAccountFrozenTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.SetAccountFrozenTxParams) (db.SetAccountFrozenTxResult, error) {
+			require.Equal(t, account.ID, arg.AccountID)
+			require.True(t, arg.IsFrozen)
+			require.Equal(t, banker, arg.Audit.Actor)
+			return db.SetAccountFrozenTxResult{Account: account}, nil
+		})
+
+	//? without a token the handler is never reached
+	_, err := client.FreezeAccount(context.Background(), &pb.FreezeAccountRequest{Id: account.ID})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, account.Owner, util.DepositorRole, token.TokenTypeAccessToken)
+	_, err = client.FreezeAccount(ctx, &pb.FreezeAccountRequest{Id: account.ID})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx = newContextWithBearerToken(t, server.tokenMaker, banker, util.BankerRole, token.TokenTypeAccessToken)
+	res, err := client.FreezeAccount(ctx, &pb.FreezeAccountRequest{Id: account.ID})
+	require.NoError(t, err)
+	require.True(t, res.GetAccount().GetIsFrozen())
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -19,6 +20,9 @@ func GrpcLogger(
 	handler grpc.UnaryHandler,
 ) (resp any, err error) {
 
+	//? the request ID is added before the handler runs, so its audit events carry the logged ID
+	ctx, requestID := withRequestID(ctx)
+
 	//* measure time for handling request
 	startTime := time.Now()
 	result, err := handler(ctx, req)
@@ -40,6 +44,7 @@ func GrpcLogger(
 	logger.
 		Str("protocol", "grpc").
 		Str("method", info.FullMethod).
+		Str("request_id", requestID).
 		Int("status_code", int(statusCode)).
 		Str("status_text", statusCode.String()).
 		Str("duration", fmt.Sprintf("%dms", duration.Milliseconds())).
@@ -74,6 +79,11 @@ func (rec *ResponseRecorder) Write(body []byte) (int, error){
 func HttpLogger(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 
+		//? forwarded to the gRPC handler by the gateway, and sent back so clients can quote it
+		requestID := util.RequestID(req.Header.Get(util.RequestIDHeader))
+		req.Header.Set(util.RequestIDHeader, requestID)
+		res.Header().Set(util.RequestIDHeader, requestID)
+
 		startTime := time.Now()
 
 		rec := &ResponseRecorder{
@@ -95,6 +105,7 @@ func HttpLogger(handler http.Handler) http.Handler {
 			Str("protocol", "http").
 			Str("method", req.Method).
 			Str("path", req.RequestURI).
+			Str("request_id", requestID).
 			Int("status_code", rec.StatusCode).
 			Str("status_text", http.StatusText(rec.StatusCode)).
 			Str("duration", fmt.Sprintf("%dms", duration.Milliseconds())).
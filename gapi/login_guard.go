@@ -0,0 +1,114 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/worker"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const retryAfterHeader = "retry-after"
+
+// errIncorrectCredentials is returned for an unknown username and for a wrong password alike,
+// so the login does not reveal which usernames exist.
+var errIncorrectCredentials = status.Errorf(codes.Unauthenticated, "incorrect username or password")
+
+// loginAttempt identifies a login attempt by the username and the IP of the client.
+func (server *Server) loginAttempt(ctx context.Context, username string) lockout.Attempt {
+	return lockout.Attempt{
+		Username: username,
+		ClientIP: clientIP(server.extractMetadata(ctx).ClientIp),
+	}
+}
+
+// checkLoginAttempt returns a ResourceExhausted error while the username or the client IP has to wait.
+func (server *Server) checkLoginAttempt(ctx context.Context, attempt lockout.Attempt) error {
+	retryAfter, err := server.loginGuard.Check(ctx, attempt)
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check login attempts: %s", err)
+	}
+
+	if retryAfter > 0 {
+		return tooManyAttemptsError(ctx, retryAfter)
+	}
+
+	return nil
+}
+
+// loginFailed records a failed login attempt and returns the error to send back.
+// userExists tells whether the username belongs to a user, who is emailed once the login gets locked.
+func (server *Server) loginFailed(ctx context.Context, attempt lockout.Attempt, userExists bool, failure error) error {
+	result, err := server.loginGuard.Failure(ctx, attempt)
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to record login attempt: %s", err)
+	}
+
+	if result.LockedOut && userExists {
+		taskPayload := &worker.PayloadSendLockoutEmail{
+			Username:    attempt.Username,
+			LockedUntil: time.Now().Add(result.RetryAfter),
+		}
+
+		//? the lockout is already in place, a failure only loses the notification
+		message, err := worker.OutboxTaskSendLockoutEmail(taskPayload, asynq.MaxRetry(10), asynq.Queue(worker.QueueCritical))
+		if err == nil {
+			_, err = server.store.CreateOutboxMessage(ctx, message)
+		}
+
+		if err != nil {
+			log.Error().Err(err).Str("username", attempt.Username).Msg("failed to queue lockout email task")
+		}
+	}
+
+	return failure
+}
+
+// loginSucceeded forgets the failed attempts of the username.
+func (server *Server) loginSucceeded(ctx context.Context, attempt lockout.Attempt) error {
+	if err := server.loginGuard.Success(ctx, attempt); err != nil {
+		return status.Errorf(codes.Internal, "failed to reset login attempts: %s", err)
+	}
+
+	return nil
+}
+
+// tooManyAttemptsError tells the client how long to wait, in the RetryInfo details for gRPC clients
+// and in the Retry-After header for HTTP clients of the gateway.
+func tooManyAttemptsError(ctx context.Context, retryAfter time.Duration) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+
+	//? fails outside of a call, e.g. in tests, where there is no header to set
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, fmt.Sprint(seconds)))
+
+	return resourceExhaustedError(time.Duration(seconds)*time.Second, "too many failed login attempts, retry in %d seconds", seconds)
+}
+
+// clientIP strips the port from the peer address of gRPC clients.
+// The gateway sends the X-Forwarded-For header, whose last address is the one the gateway saw itself;
+// the addresses before it are set by the client and cannot be trusted.
+func clientIP(address string) string {
+	if i := strings.LastIndex(address, ","); i >= 0 {
+		address = address[i+1:]
+	}
+
+	address = strings.TrimSpace(address)
+
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+
+	return address
+}
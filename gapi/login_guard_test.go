@@ -0,0 +1,123 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserIncorrectCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	hashedPassword, err := util.HashPassword("secret")
+	require.NoError(t, err)
+
+	user := db.User{Username: util.RandomOwner(), HashedPassword: hashedPassword}
+	missingUsername := util.RandomOwner()
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(missingUsername)).Times(1).Return(db.User{}, sql.ErrNoRows)
+
+	_, wrongPassword := server.LoginUser(context.Background(), &pb.LoginUserRequest{Username: user.Username, Password: "wrong secret"})
+	_, missingUser := server.LoginUser(context.Background(), &pb.LoginUserRequest{Username: missingUsername, Password: "secret"})
+
+	//! both failures look the same, so the response does not reveal whether the user exists
+	require.Equal(t, codes.Unauthenticated, status.Code(wrongPassword))
+	require.Equal(t, wrongPassword.Error(), missingUser.Error())
+}
+
+func TestLoginUserLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	server := newTestServer(t, store)
+	server.loginGuard = lockout.NewGuardWithPolicies(lockout.NewMemoryCounter(), lockout.Policy{
+		FreeAttempts:    1,
+		LockoutAttempts: 2,
+		LockoutDuration: time.Minute,
+		Window:          time.Hour,
+	}, lockout.ClientIPPolicy)
+
+	hashedPassword, err := util.HashPassword("secret")
+	require.NoError(t, err)
+
+	user := db.User{Username: util.RandomOwner(), HashedPassword: hashedPassword}
+	req := &pb.LoginUserRequest{Username: user.Username, Password: "wrong secret"}
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(2).Return(user, nil)
+	store.EXPECT().CreateOutboxMessage(gomock.Any(), gomock.Any()).Times(1).Return(db.Outbox{}, nil)
+
+	for range 2 {
+		_, err = server.LoginUser(context.Background(), req)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+
+	_, err = server.LoginUser(context.Background(), &pb.LoginUserRequest{Username: user.Username, Password: "secret"})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+
+	//? grpc clients find the wait in the error details
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.InDelta(t, time.Minute.Seconds(), retryInfo.GetRetryDelay().AsDuration().Seconds(), 1)
+}
+
+func TestLoginAttemptClientIP(t *testing.T) {
+	server := &Server{}
+
+	testCases := []struct {
+		name     string
+		md       metadata.MD
+		expected string
+	}{
+		{
+			name:     "NoMetadata",
+			md:       metadata.MD{},
+			expected: "",
+		},
+		{
+			name:     "Gateway",
+			md:       metadata.Pairs(xForwardedForHeader, "203.0.113.7"),
+			expected: "203.0.113.7",
+		},
+		{
+			//! the addresses sent by the client come first and are ignored
+			name:     "SpoofedForwardedFor",
+			md:       metadata.Pairs(xForwardedForHeader, "198.51.100.1, 203.0.113.7"),
+			expected: "203.0.113.7",
+		},
+		{
+			name:     "IPv6WithPort",
+			md:       metadata.Pairs(xForwardedForHeader, "[2001:db8::1]:443"),
+			expected: "2001:db8::1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+
+			attempt := server.loginAttempt(ctx, "alice")
+			require.Equal(t, "alice", attempt.Username)
+			require.Equal(t, tc.expected, attempt.ClientIP)
+		})
+	}
+}
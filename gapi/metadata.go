@@ -2,7 +2,13 @@ package gapi
 
 import (
 	"context"
+	"strings"
 
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
@@ -10,12 +16,16 @@ import (
 type MetaData struct {
 	UserAgent string
 	ClientIp  string
+	RequestID string
 }
 
 const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	userAgentHeader            = "user-agent"
-	xForwardedForHeader         = "x-forwarded-host"
+	xForwardedForHeader        = "x-forwarded-for"
+	idempotencyKeyHeader       = "idempotency-key"
+	contentDispositionHeader   = "content-disposition"
+	requestIDHeader            = "x-request-id"
 )
 
 func (server *Server) extractMetadata(ctx context.Context) *MetaData {
@@ -37,6 +47,15 @@ func (server *Server) extractMetadata(ctx context.Context) *MetaData {
 		if clientIps := md.Get(xForwardedForHeader); len(clientIps) > 0 {
 			mtdt.ClientIp = clientIps[0]
 		}
+
+		// set by the logger interceptor, or forwarded by the gateway
+		if requestIDs := md.Get(requestIDHeader); len(requestIDs) > 0 {
+			mtdt.RequestID = requestIDs[0]
+		}
+	}
+
+	if mtdt.RequestID == "" {
+		mtdt.RequestID = util.RequestID("")
 	}
 
 	// grpc client ip address can be found from the context using the peer package
@@ -46,3 +65,83 @@ func (server *Server) extractMetadata(ctx context.Context) *MetaData {
 
 	return mtdt
 }
+
+// auditContext describes the request for the audit events recorded by the transactions it runs.
+func (server *Server) auditContext(ctx context.Context, actor string) db.AuditContext {
+	mtdt := server.extractMetadata(ctx)
+
+	return db.AuditContext{
+		Actor:     actor,
+		//! only the address the gateway or the gRPC server saw is recorded, the rest of X-Forwarded-For comes from the client
+		ClientIP:  clientIP(mtdt.ClientIp),
+		UserAgent: mtdt.UserAgent,
+		RequestID: mtdt.RequestID,
+	}
+}
+
+// withRequestID makes sure the incoming metadata carries a request ID, so the logs and the audit events of the request share it.
+func withRequestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+
+	var sent string
+	if requestIDs := md.Get(requestIDHeader); len(requestIDs) > 0 {
+		sent = requestIDs[0]
+	}
+
+	requestID := util.RequestID(sent)
+	md.Set(requestIDHeader, requestID)
+
+	return metadata.NewIncomingContext(ctx, md), requestID
+}
+
+// extractIdempotencyKey reads the optional idempotency-key metadata and scopes it to the given user.
+// It returns nil when the client did not send a key.
+func (server *Server) extractIdempotencyKey(ctx context.Context, username string) (*db.IdempotencyParams, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	keys := md.Get(idempotencyKeyHeader)
+	if len(keys) == 0 || keys[0] == "" {
+		return nil, nil
+	}
+
+	if err := validator.ValidateIdempotencyKey(keys[0]); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(idempotencyKeyHeader, err)})
+	}
+
+	return &db.IdempotencyParams{
+		Username: username,
+		Key:      keys[0],
+	}, nil
+}
+
+// IncomingHeaderMatcher forwards the Idempotency-Key and X-Request-Id HTTP headers to gRPC metadata
+// on top of the headers that grpc-gateway forwards by default.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, idempotencyKeyHeader) {
+		return idempotencyKeyHeader, true
+	}
+
+	if strings.EqualFold(key, requestIDHeader) {
+		return requestIDHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// OutgoingHeaderMatcher sends the Content-Disposition metadata of file downloads and the Retry-After metadata of throttled logins
+// as plain HTTP headers instead of the Grpc-Metadata- prefixed header that grpc-gateway uses by default.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, contentDispositionHeader) {
+		return contentDispositionHeader, true
+	}
+
+	if strings.EqualFold(key, retryAfterHeader) {
+		return retryAfterHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
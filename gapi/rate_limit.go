@@ -0,0 +1,192 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodRateLimitGroups lists the RPCs that do not fall into the reads or default groups by their name.
+var methodRateLimitGroups = map[string]ratelimit.Group{
+	pb.SimpleBank_LoginUser_FullMethodName:            ratelimit.GroupLogin,
+	pb.SimpleBank_VerifyLoginMFA_FullMethodName:       ratelimit.GroupLogin,
+	pb.SimpleBank_RequestPasswordReset_FullMethodName: ratelimit.GroupLogin,
+	pb.SimpleBank_ResetPassword_FullMethodName:        ratelimit.GroupLogin,
+
+	pb.SimpleBank_CreateTransfer_FullMethodName:          ratelimit.GroupTransfers,
+	pb.SimpleBank_ReverseTransfer_FullMethodName:         ratelimit.GroupTransfers,
+	pb.SimpleBank_PlaceHold_FullMethodName:               ratelimit.GroupTransfers,
+	pb.SimpleBank_CaptureHold_FullMethodName:             ratelimit.GroupTransfers,
+	pb.SimpleBank_CreateScheduledTransfer_FullMethodName: ratelimit.GroupTransfers,
+}
+
+// gatewayRateLimitGroups lists the same endpoints by the HTTP method and path the gateway serves them at.
+var gatewayRateLimitGroups = map[string]ratelimit.Group{
+	"POST /v1/login_user":             ratelimit.GroupLogin,
+	"POST /v1/login_user/mfa":         ratelimit.GroupLogin,
+	"POST /v1/request_password_reset": ratelimit.GroupLogin,
+	"POST /v1/reset_password":         ratelimit.GroupLogin,
+
+	"POST /v1/transfers":           ratelimit.GroupTransfers,
+	"POST /v1/scheduled_transfers": ratelimit.GroupTransfers,
+	"POST /v1/holds":               ratelimit.GroupTransfers,
+}
+
+// methodRateLimitGroup returns the group whose limit applies to an RPC.
+// RPCs named Get, List or Download only read data.
+func methodRateLimitGroup(method string) ratelimit.Group {
+	if group, ok := methodRateLimitGroups[method]; ok {
+		return group
+	}
+
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Download"} {
+		if strings.HasPrefix(name, prefix) {
+			return ratelimit.GroupReads
+		}
+	}
+
+	return ratelimit.GroupDefault
+}
+
+// gatewayRateLimitGroup returns the group whose limit applies to a request of the gateway.
+func gatewayRateLimitGroup(method string, path string) ratelimit.Group {
+	if group, ok := gatewayRateLimitGroups[method+" "+path]; ok {
+		return group
+	}
+
+	//? POST /v1/transfers/{transfer_id}/reverse and /v1/holds/{hold_id}/capture carry the resource in their path
+	if method == http.MethodPost && strings.HasPrefix(path, "/v1/transfers/") && strings.HasSuffix(path, "/reverse") {
+		return ratelimit.GroupTransfers
+	}
+
+	if method == http.MethodPost && strings.HasPrefix(path, "/v1/holds/") && strings.HasSuffix(path, "/capture") {
+		return ratelimit.GroupTransfers
+	}
+
+	if method == http.MethodGet {
+		return ratelimit.GroupReads
+	}
+
+	return ratelimit.GroupDefault
+}
+
+// RateLimitUnaryInterceptor limits unary RPCs per user, or per client IP for public methods.
+// It must run after the auth interceptor, which provides the user.
+// The RateLimit headers are sent as trailers.
+func (server *Server) RateLimitUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+
+	trailer, err := server.rateLimit(ctx, methodRateLimitGroup(info.FullMethod))
+
+	//? fails outside of a call, e.g. in tests, where there is no trailer to set
+	_ = grpc.SetTrailer(ctx, trailer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// RateLimitStreamInterceptor applies the same limits as RateLimitUnaryInterceptor to streaming RPCs.
+func (server *Server) RateLimitStreamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+
+	trailer, err := server.rateLimit(stream.Context(), methodRateLimitGroup(info.FullMethod))
+	stream.SetTrailer(trailer)
+
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+// rateLimit takes a token for the caller and returns the RateLimit headers as metadata.
+// The returned error is already a gRPC status error.
+func (server *Server) rateLimit(ctx context.Context, group ratelimit.Group) (metadata.MD, error) {
+	key := ratelimit.ClientIPKey(clientIP(server.extractMetadata(ctx).ClientIp))
+
+	if authPayload, err := authPayloadFromContext(ctx); err == nil {
+		key = ratelimit.UserKey(authPayload.Username)
+	}
+
+	result, err := server.rateLimiter.Allow(ctx, group, key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check rate limit: %s", err)
+	}
+
+	//? metadata keys are lower case
+	trailer := metadata.MD{}
+	for name, value := range result.Headers() {
+		trailer.Set(name, value)
+	}
+
+	if !result.Allowed {
+		return trailer, resourceExhaustedError(result.RetryAfter, "too many requests, retry in %d seconds", int64(math.Ceil(result.RetryAfter.Seconds())))
+	}
+
+	return trailer, nil
+}
+
+// HttpRateLimiter limits the requests of the gateway per user, or per client IP when there is no valid access token.
+// It wraps the whole gateway, so RPCs called through it are counted once, here, and not by the interceptors.
+func (server *Server) HttpRateLimiter(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		//! the X-Forwarded-For header is set by the client, only the address of the connection can be trusted
+		key := ratelimit.ClientIPKey(clientIP(req.RemoteAddr))
+
+		if authPayload, err := server.verifyAuthorizationHeader(req.Header.Get(authorizationHeader)); err == nil {
+			key = ratelimit.UserKey(authPayload.Username)
+		}
+
+		result, err := server.rateLimiter.Allow(req.Context(), gatewayRateLimitGroup(req.Method, req.URL.Path), key)
+		if err != nil {
+			writeGatewayError(res, http.StatusInternalServerError, codes.Internal, fmt.Sprintf("failed to check rate limit: %s", err))
+			return
+		}
+
+		for name, value := range result.Headers() {
+			res.Header().Set(name, value)
+		}
+
+		if !result.Allowed {
+			message := fmt.Sprintf("too many requests, retry in %d seconds", int64(math.Ceil(result.RetryAfter.Seconds())))
+			writeGatewayError(res, http.StatusTooManyRequests, codes.ResourceExhausted, message)
+			return
+		}
+
+		handler.ServeHTTP(res, req)
+	})
+}
+
+// writeGatewayError responds with the JSON body grpc-gateway uses for errors, so clients can handle every error the same way.
+func writeGatewayError(res http.ResponseWriter, httpStatus int, code codes.Code, message string) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(httpStatus)
+
+	_ = json.NewEncoder(res).Encode(map[string]any{
+		"code":    int(code),
+		"message": message,
+		"details": []any{},
+	})
+}
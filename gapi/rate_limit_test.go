@@ -0,0 +1,141 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/ratelimit"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newTestRateLimiter allows two requests a minute in every group.
+func newTestRateLimiter() *ratelimit.Limiter {
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+
+	return ratelimit.NewLimiter(ratelimit.NewMemoryBackend(), map[ratelimit.Group]ratelimit.Limit{
+		ratelimit.GroupLogin:     limit,
+		ratelimit.GroupTransfers: limit,
+		ratelimit.GroupReads:     limit,
+		ratelimit.GroupDefault:   limit,
+	})
+}
+
+func TestMethodRateLimitGroup(t *testing.T) {
+	require.Equal(t, ratelimit.GroupLogin, methodRateLimitGroup(pb.SimpleBank_LoginUser_FullMethodName))
+	require.Equal(t, ratelimit.GroupTransfers, methodRateLimitGroup(pb.SimpleBank_CreateTransfer_FullMethodName))
+	require.Equal(t, ratelimit.GroupTransfers, methodRateLimitGroup(pb.SimpleBank_ReverseTransfer_FullMethodName))
+	require.Equal(t, ratelimit.GroupTransfers, methodRateLimitGroup(pb.SimpleBank_CaptureHold_FullMethodName))
+	require.Equal(t, ratelimit.GroupReads, methodRateLimitGroup(pb.SimpleBank_GetHold_FullMethodName))
+	require.Equal(t, ratelimit.GroupReads, methodRateLimitGroup(pb.SimpleBank_ListAccounts_FullMethodName))
+	require.Equal(t, ratelimit.GroupReads, methodRateLimitGroup(pb.SimpleBank_DownloadStatementExport_FullMethodName))
+	require.Equal(t, ratelimit.GroupDefault, methodRateLimitGroup(pb.SimpleBank_CreateAccount_FullMethodName))
+
+	require.Equal(t, ratelimit.GroupLogin, gatewayRateLimitGroup(http.MethodPost, "/v1/login_user"))
+	require.Equal(t, ratelimit.GroupTransfers, gatewayRateLimitGroup(http.MethodPost, "/v1/transfers"))
+	require.Equal(t, ratelimit.GroupTransfers, gatewayRateLimitGroup(http.MethodPost, "/v1/transfers/7/reverse"))
+	require.Equal(t, ratelimit.GroupTransfers, gatewayRateLimitGroup(http.MethodPost, "/v1/holds"))
+	require.Equal(t, ratelimit.GroupTransfers, gatewayRateLimitGroup(http.MethodPost, "/v1/holds/7/capture"))
+	require.Equal(t, ratelimit.GroupDefault, gatewayRateLimitGroup(http.MethodPost, "/v1/holds/7/release"))
+	require.Equal(t, ratelimit.GroupReads, gatewayRateLimitGroup(http.MethodGet, "/v1/accounts/1"))
+	require.Equal(t, ratelimit.GroupDefault, gatewayRateLimitGroup(http.MethodPost, "/v1/accounts"))
+}
+
+func TestRateLimitUnaryInterceptor(t *testing.T) {
+	server := newTestServer(t, nil)
+	server.rateLimiter = newTestRateLimiter()
+
+	info := &grpc.UnaryServerInfo{FullMethod: pb.SimpleBank_GetAccount_FullMethodName}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+
+	//? the auth interceptor runs first and provides the user
+	ctx := incoming(newContextWithBearerToken(t, server.tokenMaker, util.RandomOwner(), util.DepositorRole, token.TokenTypeAccessToken))
+	ctx, err := server.authorizeMethod(ctx, info.FullMethod)
+	require.NoError(t, err)
+
+	for range 2 {
+		resp, err := server.RateLimitUnaryInterceptor(ctx, nil, info, handler)
+		require.NoError(t, err)
+		require.Equal(t, "ok", resp)
+	}
+
+	_, err = server.RateLimitUnaryInterceptor(ctx, nil, info, handler)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.InDelta(t, 30*time.Second, retryInfo.GetRetryDelay().AsDuration(), float64(time.Second))
+
+	//? anonymous callers are counted by their IP instead
+	anonymous := metadata.NewIncomingContext(context.Background(), metadata.Pairs(xForwardedForHeader, "203.0.113.7"))
+	_, err = server.RateLimitUnaryInterceptor(anonymous, nil, info, handler)
+	require.NoError(t, err)
+}
+
+func TestHttpRateLimiter(t *testing.T) {
+	server := newTestServer(t, nil)
+	server.rateLimiter = newTestRateLimiter()
+
+	handler := server.HttpRateLimiter(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusOK)
+	}))
+
+	accessToken, _, err := server.tokenMaker.CreateToken(util.RandomOwner(), util.DepositorRole, token.TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	serve := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/accounts", nil)
+		req.RemoteAddr = "203.0.113.7:54321"
+
+		if authorization != "" {
+			req.Header.Set(authorizationHeader, authorization)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	bearer := fmt.Sprintf("%s %s", authorizationType, accessToken)
+
+	recorder := serve(bearer)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "2", recorder.Header().Get(ratelimit.HeaderLimit))
+	require.Equal(t, "1", recorder.Header().Get(ratelimit.HeaderRemaining))
+
+	recorder = serve(bearer)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = serve(bearer)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "30", recorder.Header().Get(ratelimit.HeaderRetryAfter))
+	require.Contains(t, recorder.Body.String(), `"code":8`)
+
+	//? without a valid token the client IP is counted, which still has its own bucket
+	recorder = serve("")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = serve(fmt.Sprintf("%s %s", authorizationType, "not-a-token"))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = serve("")
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+}
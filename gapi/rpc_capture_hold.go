@@ -0,0 +1,103 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CaptureHold(ctx context.Context, req *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCaptureHoldRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, payee, err := server.holdWithPayee(ctx, req.GetHoldId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// only the payee collects the funds the payer promised them
+	if !policy.CanCaptureHold(authPayload, payee) {
+		return nil, status.Errorf(codes.PermissionDenied, "hold is not payable to the authenticated user")
+	}
+
+	if err = server.requireVerifiedEmail(ctx, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	key, err := server.extractIdempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID:         req.GetHoldId(),
+		Amount:         req.GetAmount(),
+		IdempotencyKey: key,
+		Audit:          server.auditContext(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, holdError(err)
+	}
+
+	response := &pb.CaptureHoldResponse{
+		Hold:        convertHold(result.Hold),
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+
+	return response, nil
+}
+
+// holdError maps the errors returned by the hold transactions to gRPC status errors.
+func holdError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrHoldNotActive),
+		errors.Is(err, db.ErrHoldExpired),
+		errors.Is(err, db.ErrCaptureTooLarge),
+		errors.Is(err, db.ErrInsufficientFunds),
+		errors.Is(err, db.ErrAccountFrozen):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	case errors.Is(err, db.ErrIdempotencyKeyReused):
+		return status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to update hold: %s", err)
+}
+
+func validateCaptureHoldRequest(req *pb.CaptureHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetHoldId()); err != nil {
+		violations = append(violations, fieldViolation("hold_id", err))
+	}
+
+	if req.Amount != nil {
+		if err := validator.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	return
+}
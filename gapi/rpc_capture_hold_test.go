@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCaptureHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	client := NewGatewayClient(server, server.AuthUnaryInterceptor)
+
+	payer := util.RandomOwner()
+	payee := db.User{Username: util.RandomOwner(), IsEmailVerified: true}
+
+	hold := db.Hold{
+		ID:          util.RandomInt(1, 1000),
+		Owner:       payer,
+		AccountID:   1,
+		ToAccountID: 2,
+		Amount:      100,
+		Status:      db.HoldActive,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	payeeAccount := db.Account{ID: hold.ToAccountID, Owner: payee.Username, Currency: util.USD}
+
+	transfer := db.Transfer{ID: util.RandomInt(1, 1000), FromAccountID: hold.AccountID, ToAccountID: hold.ToAccountID, Amount: 60}
+
+	captured := hold
+	captured.Status = db.HoldCaptured
+	captured.CapturedAmount = transfer.Amount
+	captured.TransferID = sql.NullInt64{Int64: transfer.ID, Valid: true}
+
+	req := &pb.CaptureHoldRequest{HoldId: hold.ID, Amount: proto.Int64(60)}
+
+	store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(2).Return(hold, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(hold.ToAccountID)).Times(2).Return(payeeAccount, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(payee.Username)).Times(1).Return(payee, nil)
+
+	store.EXPECT().
+		CaptureHoldTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg db.CaptureHoldTxParams) (db.CaptureHoldTxResult, error) {
+			require.Equal(t, hold.ID, arg.HoldID)
+			require.Equal(t, int64(60), arg.Amount)
+			require.Equal(t, payee.Username, arg.Audit.Actor)
+
+			return db.CaptureHoldTxResult{TransferTxResult: db.TransferTxResult{Transfer: transfer}, Hold: captured}, nil
+		})
+
+	//! the payer cannot collect the hold they placed
+	ctx := newContextWithBearerToken(t, server.tokenMaker, payer, util.DepositorRole, token.TokenTypeAccessToken)
+	_, err := client.CaptureHold(ctx, req)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx = newContextWithBearerToken(t, server.tokenMaker, payee.Username, util.DepositorRole, token.TokenTypeAccessToken)
+	res, err := client.CaptureHold(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, db.HoldCaptured, res.GetHold().GetStatus())
+	require.Equal(t, transfer.ID, res.GetHold().GetTransferId())
+	require.Equal(t, int64(60), res.GetTransfer().GetAmount())
+}
+
+func TestHoldError(t *testing.T) {
+	require.Equal(t, codes.FailedPrecondition, status.Code(holdError(db.ErrHoldNotActive)))
+	require.Equal(t, codes.FailedPrecondition, status.Code(holdError(db.ErrHoldExpired)))
+	require.Equal(t, codes.FailedPrecondition, status.Code(holdError(db.ErrCaptureTooLarge)))
+	require.Equal(t, codes.FailedPrecondition, status.Code(holdError(db.ErrInsufficientFunds)))
+	require.Equal(t, codes.FailedPrecondition, status.Code(holdError(db.ErrAccountFrozen)))
+	require.Equal(t, codes.Internal, status.Code(holdError(sql.ErrTxDone)))
+}
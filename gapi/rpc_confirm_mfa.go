@@ -0,0 +1,43 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// ConfirmMFA turns on two-factor authentication once the user enters a code from their authenticator app.
+func (server *Server) ConfirmMFA(ctx context.Context, req *pb.ConfirmMFARequest) (*pb.ConfirmMFAResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateConfirmMFARequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	recoveryCodes, err := server.mfa.Confirm(ctx, authPayload.Username, req.GetCode())
+
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &pb.ConfirmMFAResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func validateConfirmMFARequest(req *pb.ConfirmMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateMFACode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return
+}
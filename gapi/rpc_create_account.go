@@ -0,0 +1,80 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateAccountRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	key, err := server.extractIdempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	// new accounts always belong to the logged in user and start with a zero balance
+	arg := db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Currency: req.GetCurrency(),
+			Balance:  0,
+		},
+		IdempotencyKey: key,
+	}
+
+	result, err := server.store.CreateAccountTx(ctx, arg)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			// one account per currency for a given user
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "account already exists for currency %s", req.GetCurrency())
+			case "foreign_key_violation":
+				return nil, status.Errorf(codes.PermissionDenied, "account owner does not exist")
+			}
+		}
+
+		if errors.Is(err, db.ErrIdempotencyKeyReused) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to create account: %s", err)
+	}
+
+	response := &pb.CreateAccountResponse{
+		Account: convertAccount(result.Account),
+	}
+
+	return response, nil
+}
+
+func validateCreateAccountRequest(req *pb.CreateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return
+}
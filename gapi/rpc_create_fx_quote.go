@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateFxQuote(ctx context.Context, req *pb.CreateFxQuoteRequest) (*pb.CreateFxQuoteResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateFxQuoteRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	rate, err := server.store.GetFxRate(ctx, db.GetFxRateParams{
+		FromCurrency: req.GetFromCurrency(),
+		ToCurrency:   req.GetToCurrency(),
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unavailable, "no exchange rate available for %s to %s", req.GetFromCurrency(), req.GetToCurrency())
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get exchange rate: %s", err)
+	}
+
+	// lock the rate with our spread applied until the quote expires
+	quote, err := server.store.CreateFxQuote(ctx, db.CreateFxQuoteParams{
+		ID:           uuid.New(),
+		Username:     authPayload.Username,
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Rate:         util.ApplySpread(rate.Rate, server.config.FXSpreadBps),
+		SpreadBps:    server.config.FXSpreadBps,
+		ExpiresAt:    time.Now().Add(server.config.FXQuoteDuration),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create quote: %s", err)
+	}
+
+	response := &pb.CreateFxQuoteResponse{
+		Quote: convertFxQuote(quote),
+	}
+
+	return response, nil
+}
+
+func validateCreateFxQuoteRequest(req *pb.CreateFxQuoteRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateCurrency(req.GetFromCurrency()); err != nil {
+		violations = append(violations, fieldViolation("from_currency", err))
+	}
+
+	if err := validator.ValidateCurrency(req.GetToCurrency()); err != nil {
+		violations = append(violations, fieldViolation("to_currency", err))
+	}
+
+	if req.GetFromCurrency() == req.GetToCurrency() {
+		violations = append(violations, fieldViolation("to_currency", fmt.Errorf("must be different from from_currency")))
+	}
+
+	return
+}
@@ -0,0 +1,146 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/schedule"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateScheduledTransfer(ctx context.Context, req *pb.CreateScheduledTransferRequest) (*pb.CreateScheduledTransferResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateScheduledTransferRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// only the owner of the source account can set up a standing order from it
+	if !policy.CanMoveMoney(authPayload, fromAccount) {
+		return nil, status.Errorf(codes.PermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	if err = server.requireVerifiedEmail(ctx, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	if _, err = server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency()); err != nil {
+		return nil, err
+	}
+
+	s := schedule.Schedule{
+		Frequency:      req.GetFrequency(),
+		CronExpression: req.GetCronExpression(),
+		StartAt:        time.Now(),
+	}
+
+	if req.StartAt != nil {
+		s.StartAt = req.GetStartAt().AsTime()
+	}
+
+	nextRunAt, err := s.First()
+
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid schedule: %s", err)
+	}
+
+	scheduledTransfer, err := server.store.CreateScheduledTransfer(ctx, db.CreateScheduledTransferParams{
+		Owner:          authPayload.Username,
+		FromAccountID:  req.GetFromAccountId(),
+		ToAccountID:    req.GetToAccountId(),
+		Amount:         req.GetAmount(),
+		Frequency:      s.Frequency,
+		CronExpression: s.CronExpression,
+		StartAt:        s.StartAt,
+		NextRunAt:      nextRunAt,
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create scheduled transfer: %s", err)
+	}
+
+	response := &pb.CreateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+
+	return response, nil
+}
+
+func validateCreateScheduledTransferRequest(req *pb.CreateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := validator.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from from_account_id")))
+	}
+
+	if err := validator.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	violations = append(violations, validateSchedule(req.GetFrequency(), req.GetCronExpression())...)
+
+	if req.StartAt != nil {
+		if err := validateStartAt(req.GetStartAt()); err != nil {
+			violations = append(violations, fieldViolation("start_at", err))
+		}
+	}
+
+	return
+}
+
+// validateSchedule checks the frequency and cron expression shared by the create and update requests.
+func validateSchedule(frequency string, cronExpression string) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateFrequency(frequency); err != nil {
+		violations = append(violations, fieldViolation("frequency", err))
+	}
+
+	if frequency == schedule.Cron {
+		if err := (schedule.Schedule{Frequency: frequency, CronExpression: cronExpression}).Validate(); err != nil {
+			violations = append(violations, fieldViolation("cron_expression", err))
+		}
+	}
+
+	return
+}
+
+func validateStartAt(startAt *timestamppb.Timestamp) error {
+	if startAt.AsTime().Before(time.Now()) {
+		return fmt.Errorf("must be in the future")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/worker"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateStatementExport(ctx context.Context, req *pb.CreateStatementExportRequest) (*pb.CreateStatementExportResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateStatementExportRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if !policy.CanReadAccount(authPayload, account) {
+		return nil, status.Errorf(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	arg := db.CreateStatementExportTxParams{
+		CreateStatementExportParams: db.CreateStatementExportParams{
+			ID:        uuid.New(),
+			Username:  authPayload.Username,
+			AccountID: account.ID,
+			Format:    req.GetFormat(),
+			FromTime:  req.GetFromTime().AsTime(),
+			ToTime:    req.GetToTime().AsTime(),
+		},
+		// render the file in the background, the task is queued through the outbox together with the export
+		OutboxMessages: func(export db.StatementExport) ([]db.CreateOutboxMessageParams, error) {
+
+			taskPayload := &worker.PayloadGenerateStatementExport{
+				ExportID: export.ID,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(3),
+				asynq.Queue(worker.QueueDefault),
+			}
+
+			message, err := worker.OutboxTaskGenerateStatementExport(taskPayload, opts...)
+			return []db.CreateOutboxMessageParams{message}, err
+		},
+	}
+
+	result, err := server.store.CreateStatementExportTx(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create statement export: %s", err)
+	}
+
+	response := &pb.CreateStatementExportResponse{
+		Export: convertStatementExport(result.StatementExport),
+	}
+
+	return response, nil
+}
+
+func validateCreateStatementExportRequest(req *pb.CreateStatementExportRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := validator.ValidateStatementFormat(req.GetFormat()); err != nil {
+		violations = append(violations, fieldViolation("format", err))
+	}
+
+	violations = append(violations, validateStatementRange(req.GetFromTime(), req.GetToTime(), maxStatementExportRange)...)
+
+	return
+}
@@ -0,0 +1,185 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateCreateTransferRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// only the owner of the source account can move money out of it
+	if !policy.CanMoveMoney(authPayload, fromAccount) {
+		return nil, status.Errorf(codes.PermissionDenied, "from account does not belong to the authenticated user")
+	}
+
+	if err = server.requireVerifiedEmail(ctx, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	// optional idempotency key lets clients safely retry a transfer after a timeout
+	key, err := server.extractIdempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	// a quote allows the receiver to hold a different currency than the sender
+	if req.QuoteId != nil {
+		return server.createConvertTransfer(ctx, req, authPayload.Username, key)
+	}
+
+	if _, err = server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency()); err != nil {
+		return nil, err
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID:  req.GetFromAccountId(),
+		ToAccountID:    req.GetToAccountId(),
+		Amount:         req.GetAmount(),
+		IdempotencyKey: key,
+		Audit:          server.auditContext(ctx, authPayload.Username),
+	}
+
+	// create the transfer record, both entries and update both balances atomically
+	result, err := server.store.TransferTx(ctx, arg)
+
+	if err != nil {
+		return nil, transferError(err)
+	}
+
+	return convertTransferTxResult(result), nil
+}
+
+// createConvertTransfer moves money between accounts holding different currencies at the rate locked by the request's quote.
+// The quote and both account currencies are checked inside the transaction.
+func (server *Server) createConvertTransfer(ctx context.Context, req *pb.CreateTransferRequest, username string, key *db.IdempotencyParams) (*pb.CreateTransferResponse, error) {
+	if _, err := server.store.GetAccount(ctx, req.GetToAccountId()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", req.GetToAccountId())
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	arg := db.ConvertTransferTxParams{
+		FromAccountID:  req.GetFromAccountId(),
+		ToAccountID:    req.GetToAccountId(),
+		Amount:         req.GetAmount(),
+		QuoteID:        uuid.MustParse(req.GetQuoteId()),
+		Username:       username,
+		IdempotencyKey: key,
+		Audit:          server.auditContext(ctx, username),
+	}
+
+	result, err := server.store.ConvertTransferTx(ctx, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "fx quote not found")
+		}
+
+		return nil, transferError(err)
+	}
+
+	response := convertTransferTxResult(result.TransferTxResult)
+	response.Conversion = convertFxConversion(result.Conversion)
+
+	return response, nil
+}
+
+// transferError maps the errors returned by the transfer transactions to gRPC status errors.
+func transferError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrInsufficientFunds),
+		errors.Is(err, db.ErrQuoteExpired),
+		errors.Is(err, db.ErrQuoteUsed),
+		errors.Is(err, db.ErrQuoteMismatch),
+		errors.Is(err, db.ErrConversionTooSmall),
+		errors.Is(err, db.ErrAccountFrozen):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	case errors.Is(err, db.ErrIdempotencyKeyReused):
+		return status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to create transfer: %s", err)
+}
+
+// validAccount checks that the account exists and holds the expected currency.
+// The returned error is already a gRPC status error.
+func (server *Server) validAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", accountID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := validator.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from from_account_id")))
+	}
+
+	if err := validator.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.QuoteId != nil {
+		if err := validator.ValidateUUID(req.GetQuoteId()); err != nil {
+			violations = append(violations, fieldViolation("quote_id", err))
+		}
+	}
+
+	return
+}
@@ -2,7 +2,6 @@ package gapi
 
 import (
 	"context"
-	"time"
 
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
 	"github.com/VihangaFTW/Go-Backend/pb"
@@ -39,9 +38,9 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 			FullName:       req.GetFullName(),
 			Email:          req.GetEmail(),
 		},
-		// Post-creation hook: schedule verify email task within the same transaction.
-		// If task scheduling fails, user creation is rolled back.
-		AfterCreateUser: func(user db.User) error {
+		// The verify email task is written to the outbox within the same transaction.
+		// It is only relayed to Redis once the user is committed, so it no longer has to be delayed.
+		OutboxMessages: func(user db.User) ([]db.CreateOutboxMessageParams, error) {
 
 			taskPayload := &worker.PayloadSendVerifyEmail{
 				Username: user.Username,
@@ -49,13 +48,14 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 
 			opts := []asynq.Option{
 				asynq.MaxRetry(10),
-				asynq.ProcessIn(10 * time.Second),
 				asynq.Queue(worker.QueueCritical),
 			}
 
-			return server.taskDistributor.DistributeTaskSendVerifyEmail(ctx, taskPayload, opts...)
-
+			message, err := worker.OutboxTaskSendVerifyEmail(taskPayload, opts...)
+			return []db.CreateOutboxMessageParams{message}, err
 		},
+		// signing up is the user's own action
+		Audit: server.auditContext(ctx, req.GetUsername()),
 	}
 
 	// Execute user creation and email verification task scheduling atomically within a single transaction.
@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteScheduledTransfer(ctx context.Context, req *pb.DeleteScheduledTransferRequest) (*pb.DeleteScheduledTransferResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDeleteScheduledTransferRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.ownedScheduledTransfer(ctx, req.GetId(), authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	//? standing orders are cancelled rather than removed so their run history stays attached to the transfers they made
+	result, err := server.store.UpdateScheduledTransferTx(ctx, db.UpdateScheduledTransferTxParams{
+		ID: scheduledTransfer.ID,
+		//! a standing order that completed or failed, possibly while this request was in flight, keeps its outcome
+		AllowedStatuses: []string{db.ScheduledTransferActive, db.ScheduledTransferPaused, db.ScheduledTransferCancelled},
+		Update: func(db.ScheduledTransfer) (db.UpdateScheduledTransferParams, error) {
+			return db.UpdateScheduledTransferParams{
+				Status: sql.NullString{
+					String: db.ScheduledTransferCancelled,
+					Valid:  true,
+				},
+			}, nil
+		},
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrScheduledTransferStatus) {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot delete scheduled transfer: %s", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to cancel scheduled transfer: %s", err)
+	}
+
+	response := &pb.DeleteScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(result.ScheduledTransfer),
+	}
+
+	return response, nil
+}
+
+func validateDeleteScheduledTransferRequest(req *pb.DeleteScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return
+}
@@ -0,0 +1,41 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// DisableMFA turns off two-factor authentication. It takes a code, so a stolen access token alone cannot turn it off.
+func (server *Server) DisableMFA(ctx context.Context, req *pb.DisableMFARequest) (*pb.DisableMFAResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDisableMFARequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if err = server.mfa.Disable(ctx, authPayload.Username, req.GetCode(), server.auditContext(ctx, authPayload.Username)); err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &pb.DisableMFAResponse{}, nil
+}
+
+func validateDisableMFARequest(req *pb.DisableMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateMFACode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return
+}
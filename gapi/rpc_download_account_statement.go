@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/statement"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DownloadAccountStatement(ctx context.Context, req *pb.DownloadAccountStatementRequest) (*httpbody.HttpBody, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDownloadAccountStatementRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	st, err := server.accountStatement(ctx, authPayload, req.GetAccountId(), req.GetFromTime().AsTime(), req.GetToTime().AsTime())
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err = statement.Write(&buf, st, req.GetFormat()); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to render statement: %s", err)
+	}
+
+	return server.statementFile(ctx, statement.FileName(st, req.GetFormat()), req.GetFormat(), buf.Bytes())
+}
+
+func validateDownloadAccountStatementRequest(req *pb.DownloadAccountStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := validator.ValidateStatementFormat(req.GetFormat()); err != nil {
+		violations = append(violations, fieldViolation("format", err))
+	}
+
+	violations = append(violations, validateStatementRange(req.GetFromTime(), req.GetToTime(), maxStatementRange)...)
+
+	return
+}
@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/statement"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DownloadStatementExport(ctx context.Context, req *pb.DownloadStatementExportRequest) (*httpbody.HttpBody, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateDownloadStatementExportRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	export, err := server.ownedStatementExport(ctx, req.GetId(), authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if export.Status != db.StatementExportReady {
+		return nil, status.Errorf(codes.FailedPrecondition, "statement export is %s", export.Status)
+	}
+
+	fileName := statement.FileName(statement.Statement{
+		Account: db.Account{ID: export.AccountID},
+		From:    export.FromTime,
+		To:      export.ToTime,
+	}, export.Format)
+
+	return server.statementFile(ctx, fileName, export.Format, export.Content)
+}
+
+// statementFile wraps a rendered statement in an HTTP body and asks the gateway to serve it as a file download.
+func (server *Server) statementFile(ctx context.Context, fileName string, format string, content []byte) (*httpbody.HttpBody, error) {
+	header := metadata.Pairs(contentDispositionHeader, fmt.Sprintf("attachment; filename=%q", fileName))
+
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set header: %s", err)
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: statement.ContentType(format),
+		Data:        content,
+	}, nil
+}
+
+func validateDownloadStatementExportRequest(req *pb.DownloadStatementExportRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateUUID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return
+}
@@ -0,0 +1,32 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+)
+
+// EnrollMFA generates a TOTP secret for the authenticated user.
+// Two-factor authentication stays off until ConfirmMFA is called with a code from it.
+func (server *Server) EnrollMFA(ctx context.Context, req *pb.EnrollMFARequest) (*pb.EnrollMFAResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := server.mfa.Enroll(ctx, authPayload.Username)
+
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	response := &pb.EnrollMFAResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+	}
+
+	return response, nil
+}
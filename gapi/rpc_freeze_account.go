@@ -0,0 +1,76 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) FreezeAccount(ctx context.Context, req *pb.FreezeAccountRequest) (*pb.FreezeAccountResponse, error) {
+	account, err := server.setAccountFrozen(ctx, req.GetId(), true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.FreezeAccountResponse{Account: convertAccount(account)}, nil
+}
+
+func (server *Server) UnfreezeAccount(ctx context.Context, req *pb.UnfreezeAccountRequest) (*pb.UnfreezeAccountResponse, error) {
+	account, err := server.setAccountFrozen(ctx, req.GetId(), false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UnfreezeAccountResponse{Account: convertAccount(account)}, nil
+}
+
+// setAccountFrozen freezes or unfreezes an account on behalf of a banker.
+// Only bankers get this far, as the auth interceptor requires the freeze permission for both RPCs.
+// The returned error is already a gRPC status error.
+func (server *Server) setAccountFrozen(ctx context.Context, accountID int64, frozen bool) (db.Account, error) {
+
+	violations := validateFreezeAccountRequest(accountID)
+
+	if violations != nil {
+		return db.Account{}, invalidArgumentError(violations)
+	}
+
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return db.Account{}, err
+	}
+
+	result, err := server.store.SetAccountFrozenTx(ctx, db.SetAccountFrozenTxParams{
+		AccountID: accountID,
+		IsFrozen:  frozen,
+		Audit:     server.auditContext(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.Account{}, status.Errorf(codes.NotFound, "account not found")
+		}
+
+		return db.Account{}, status.Errorf(codes.Internal, "failed to update account: %s", err)
+	}
+
+	return result.Account, nil
+}
+
+func validateFreezeAccountRequest(accountID int64) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(accountID); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return
+}
@@ -0,0 +1,126 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/statement"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxStatementRange is the longest date range served directly, larger ranges go through a statement export.
+	maxStatementRange = 92 * 24 * time.Hour
+	// maxStatementExportRange is the longest date range of a statement export.
+	maxStatementExportRange = 5 * 366 * 24 * time.Hour
+)
+
+func (server *Server) GetAccountStatement(ctx context.Context, req *pb.GetAccountStatementRequest) (*pb.GetAccountStatementResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateGetAccountStatementRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	st, err := server.accountStatement(ctx, authPayload, req.GetAccountId(), req.GetFromTime().AsTime(), req.GetToTime().AsTime())
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetAccountStatementResponse{
+		Account:        convertAccount(st.Account),
+		OpeningBalance: st.OpeningBalance,
+		ClosingBalance: st.ClosingBalance,
+		Lines:          make([]*pb.StatementLine, 0, len(st.Lines)),
+	}
+
+	for _, line := range st.Lines {
+		response.Lines = append(response.Lines, convertStatementLine(line))
+	}
+
+	return response, nil
+}
+
+// accountStatement builds the statement of an account the given user may read.
+// The returned error is already a gRPC status error.
+func (server *Server) accountStatement(ctx context.Context, authPayload *token.Payload, accountID int64, from time.Time, to time.Time) (statement.Statement, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return statement.Statement{}, status.Errorf(codes.NotFound, "account not found")
+		}
+
+		return statement.Statement{}, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if !policy.CanReadAccount(authPayload, account) {
+		return statement.Statement{}, status.Errorf(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	result, err := server.store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: accountID,
+		FromTime:  from,
+		ToTime:    to,
+	})
+
+	if err != nil {
+		return statement.Statement{}, status.Errorf(codes.Internal, "failed to get account statement: %s", err)
+	}
+
+	return statement.New(result, from, to), nil
+}
+
+func validateGetAccountStatementRequest(req *pb.GetAccountStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	violations = append(violations, validateStatementRange(req.GetFromTime(), req.GetToTime(), maxStatementRange)...)
+
+	return
+}
+
+// validateStatementRange checks that both ends of a statement range are set, in order and at most maxRange apart.
+func validateStatementRange(from *timestamppb.Timestamp, to *timestamppb.Timestamp, maxRange time.Duration) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if from == nil {
+		violations = append(violations, fieldViolation("from_time", fmt.Errorf("is required")))
+	}
+
+	if to == nil {
+		violations = append(violations, fieldViolation("to_time", fmt.Errorf("is required")))
+	}
+
+	if violations != nil {
+		return
+	}
+
+	if !to.AsTime().After(from.AsTime()) {
+		violations = append(violations, fieldViolation("to_time", fmt.Errorf("must be after from_time")))
+	} else if to.AsTime().Sub(from.AsTime()) > maxRange {
+		violations = append(violations, fieldViolation("to_time", fmt.Errorf("must be at most %d days after from_time", int(maxRange.Hours()/24))))
+	}
+
+	return
+}
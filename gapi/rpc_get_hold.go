@@ -0,0 +1,77 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetHold(ctx context.Context, req *pb.GetHoldRequest) (*pb.GetHoldResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateGetHoldRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hold, payee, err := server.holdWithPayee(ctx, req.GetHoldId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanReadHold(authPayload, hold, payee) {
+		return nil, status.Errorf(codes.PermissionDenied, "hold was neither placed by nor for the authenticated user")
+	}
+
+	response := &pb.GetHoldResponse{
+		Hold: convertHold(hold),
+	}
+
+	return response, nil
+}
+
+// holdWithPayee returns the hold and the account it is payable to.
+// The returned error is already a gRPC status error.
+func (server *Server) holdWithPayee(ctx context.Context, holdID int64) (db.Hold, db.Account, error) {
+	hold, err := server.store.GetHold(ctx, holdID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return hold, db.Account{}, status.Errorf(codes.NotFound, "hold [%d] not found", holdID)
+		}
+
+		return hold, db.Account{}, status.Errorf(codes.Internal, "failed to get hold: %s", err)
+	}
+
+	payee, err := server.store.GetAccount(ctx, hold.ToAccountID)
+
+	if err != nil {
+		return hold, payee, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	return hold, payee, nil
+}
+
+func validateGetHoldRequest(req *pb.GetHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetHoldId()); err != nil {
+		violations = append(violations, fieldViolation("hold_id", err))
+	}
+
+	return
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetStatementExport(ctx context.Context, req *pb.GetStatementExportRequest) (*pb.GetStatementExportResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateGetStatementExportRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	export, err := server.ownedStatementExport(ctx, req.GetId(), authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetStatementExportResponse{
+		Export: convertStatementExport(export),
+	}
+
+	return response, nil
+}
+
+// ownedStatementExport gets a statement export and checks that it belongs to the given user.
+// The returned error is already a gRPC status error.
+func (server *Server) ownedStatementExport(ctx context.Context, id string, username string) (db.StatementExport, error) {
+	export, err := server.store.GetStatementExport(ctx, uuid.MustParse(id))
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return export, status.Errorf(codes.NotFound, "statement export not found")
+		}
+
+		return export, status.Errorf(codes.Internal, "failed to get statement export: %s", err)
+	}
+
+	if export.Username != username {
+		return export, status.Errorf(codes.PermissionDenied, "statement export does not belong to the authenticated user")
+	}
+
+	return export, nil
+}
+
+func validateGetStatementExportRequest(req *pb.GetStatementExportRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateUUID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return
+}
@@ -0,0 +1,127 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListAuditEvents pages through the audit log, newest first.
+// Only admins get this far, as the auth interceptor requires the audit read permission.
+func (server *Server) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+
+	violations := validateListAuditEventsRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.ListAuditEventsParams{
+		Actor: sql.NullString{
+			String: req.GetActor(),
+			Valid:  req.Actor != nil,
+		},
+		Action: sql.NullString{
+			String: req.GetAction(),
+			Valid:  req.Action != nil,
+		},
+		ResourceType: sql.NullString{
+			String: req.GetResourceType(),
+			Valid:  req.ResourceType != nil,
+		},
+		ResourceID: sql.NullString{
+			String: req.GetResourceId(),
+			Valid:  req.ResourceId != nil,
+		},
+		CreatedAfter:  nullTime(req.GetCreatedAfter()),
+		CreatedBefore: nullTime(req.GetCreatedBefore()),
+		Limit:         req.GetPageSize(),
+		Offset:        (req.GetPageId() - 1) * req.GetPageSize(),
+	}
+
+	events, err := server.store.ListAuditEvents(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list audit events: %s", err)
+	}
+
+	response := &pb.ListAuditEventsResponse{
+		AuditEvents: make([]*pb.AuditEvent, 0, len(events)),
+	}
+
+	for _, event := range events {
+		response.AuditEvents = append(response.AuditEvents, convertAuditEvent(event))
+	}
+
+	return response, nil
+}
+
+// nullTime converts an optional timestamp, a missing one is NULL rather than the Unix epoch.
+func nullTime(ts *timestamppb.Timestamp) sql.NullTime {
+	if ts == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: ts.AsTime(), Valid: true}
+}
+
+func validateListAuditEventsRequest(req *pb.ListAuditEventsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidatePageID(req.GetPageId()); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+
+	if err := validator.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	if req.Actor != nil {
+		if err := validator.ValidateString(req.GetActor(), 1, 100); err != nil {
+			violations = append(violations, fieldViolation("actor", err))
+		}
+	}
+
+	if req.Action != nil {
+		if err := validator.ValidateAuditAction(req.GetAction()); err != nil {
+			violations = append(violations, fieldViolation("action", err))
+		}
+	}
+
+	if req.ResourceType != nil {
+		if err := validator.ValidateAuditResourceType(req.GetResourceType()); err != nil {
+			violations = append(violations, fieldViolation("resource_type", err))
+		}
+	}
+
+	if req.ResourceId != nil {
+		if err := validator.ValidateString(req.GetResourceId(), 1, 100); err != nil {
+			violations = append(violations, fieldViolation("resource_id", err))
+		}
+	}
+
+	if req.CreatedAfter != nil {
+		if err := req.GetCreatedAfter().CheckValid(); err != nil {
+			violations = append(violations, fieldViolation("created_after", err))
+		}
+	}
+
+	if req.CreatedBefore != nil {
+		if err := req.GetCreatedBefore().CheckValid(); err != nil {
+			violations = append(violations, fieldViolation("created_before", err))
+		}
+	}
+
+	if req.CreatedAfter != nil && req.CreatedBefore != nil && !req.GetCreatedAfter().AsTime().Before(req.GetCreatedBefore().AsTime()) {
+		violations = append(violations, fieldViolation("created_before", errors.New("must be after created_after")))
+	}
+
+	return
+}
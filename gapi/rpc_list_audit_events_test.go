@@ -0,0 +1,142 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	client := NewGatewayClient(server, server.AuthUnaryInterceptor)
+
+	username := util.RandomOwner()
+	createdAfter := time.Now().Add(-time.Hour).UTC()
+
+	event := db.AuditEvent{
+		ID:           util.RandomInt(1, 1000),
+		Actor:        username,
+		Action:       db.AuditActionUserRoleChange,
+		ResourceType: db.AuditResourceUser,
+		ResourceID:   username,
+		Before:       json.RawMessage(`{"role":"depositor"}`),
+		After:        json.RawMessage(`{"role":"banker"}`),
+		RequestID:    "request-id",
+		CreatedAt:    time.Now(),
+	}
+
+	req := &pb.ListAuditEventsRequest{
+		PageId:       2,
+		PageSize:     5,
+		Actor:        proto.String(username),
+		Action:       proto.String(db.AuditActionUserRoleChange),
+		CreatedAfter: timestamppb.New(createdAfter),
+	}
+
+	//? only the filters that were sent narrow the list
+	arg := db.ListAuditEventsParams{
+		Actor:        sql.NullString{String: username, Valid: true},
+		Action:       sql.NullString{String: db.AuditActionUserRoleChange, Valid: true},
+		CreatedAfter: sql.NullTime{Time: createdAfter, Valid: true},
+		Limit:        5,
+		Offset:       5,
+	}
+	store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.AuditEvent{event}, nil)
+
+	//! bankers see accounts of other users, but not the audit log
+	ctx := newContextWithBearerToken(t, server.tokenMaker, username, util.BankerRole, token.TokenTypeAccessToken)
+	_, err := client.ListAuditEvents(ctx, req)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx = newContextWithBearerToken(t, server.tokenMaker, username, util.AdminRole, token.TokenTypeAccessToken)
+	res, err := client.ListAuditEvents(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, res.GetAuditEvents(), 1)
+
+	got := res.GetAuditEvents()[0]
+	require.Equal(t, event.ID, got.GetId())
+	require.Equal(t, event.Action, got.GetAction())
+	require.JSONEq(t, string(event.Before), got.GetBefore())
+	require.JSONEq(t, string(event.After), got.GetAfter())
+	require.Equal(t, event.RequestID, got.GetRequestId())
+}
+
+func TestListAuditEventsInvalidFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	client := NewGatewayClient(server, server.AuthUnaryInterceptor)
+
+	store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, util.RandomOwner(), util.AdminRole, token.TokenTypeAccessToken)
+
+	now := time.Now()
+
+	testcases := map[string]*pb.ListAuditEventsRequest{
+		"UnknownAction":       {PageId: 1, PageSize: 5, Action: proto.String("user.delete")},
+		"UnknownResourceType": {PageId: 1, PageSize: 5, ResourceType: proto.String("ledger")},
+		"InvertedRange": {
+			PageId:        1,
+			PageSize:      5,
+			CreatedAfter:  timestamppb.New(now),
+			CreatedBefore: timestamppb.New(now.Add(-time.Hour)),
+		},
+		"InvalidPage": {PageId: 0, PageSize: 5},
+	}
+
+	for name, req := range testcases {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.ListAuditEvents(ctx, req)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestWithRequestID(t *testing.T) {
+	//? a request without an ID gets one that every later lookup sees
+	ctx, requestID := withRequestID(context.Background())
+	require.NotEmpty(t, requestID)
+
+	server := newTestServer(t, nil)
+	audit := server.auditContext(ctx, db.AuditActorSystem)
+	require.Equal(t, requestID, audit.RequestID)
+	require.Equal(t, db.AuditActorSystem, audit.Actor)
+
+	//? an ID sent by the client is kept
+	ctx, sentID := withRequestID(ctx)
+	require.Equal(t, requestID, sentID)
+}
+
+func TestAuditContextClientIP(t *testing.T) {
+	server := &Server{}
+
+	//! the addresses sent by the client come first in X-Forwarded-For and must not end up in the audit log
+	md := metadata.Pairs(xForwardedForHeader, "198.51.100.1, 203.0.113.7")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	audit := server.auditContext(ctx, "alice")
+	require.Equal(t, "alice", audit.Actor)
+	require.Equal(t, "203.0.113.7", audit.ClientIP)
+}
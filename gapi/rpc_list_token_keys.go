@@ -0,0 +1,34 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTokenKeys publishes the public keys that tokens are signed with.
+// The keys are public by nature, so no authentication is required.
+func (server *Server) ListTokenKeys(ctx context.Context, req *pb.ListTokenKeysRequest) (*pb.ListTokenKeysResponse, error) {
+
+	//? symmetric tokens can only be verified with the secret key, which must never be published
+	publisher, ok := token.AsPublicKeyPublisher(server.tokenMaker)
+
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "tokens are not signed with public keys")
+	}
+
+	keys := publisher.PublicKeys()
+
+	response := &pb.ListTokenKeysResponse{
+		Keys: make([]*pb.TokenKey, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		response.Keys = append(response.Keys, convertTokenKey(key))
+	}
+
+	return response, nil
+}
@@ -7,7 +7,9 @@ import (
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
 	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/token"
 	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/google/uuid"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -22,12 +24,21 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, invalidArgumentError(violations)
 	}
 	
+	attempt := server.loginAttempt(ctx, req.GetUsername())
+
+	//? throttled attempts are rejected before the password is checked, so they cannot be used to guess it
+	if err := server.checkLoginAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+
 	// get the user
 	user, err := server.store.GetUser(ctx, req.GetUsername())
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user does not exist")
+			//! a missing user takes as long and fails the same way as a wrong password, so usernames cannot be probed
+			_ = util.CheckMissingUserPassword(req.GetPassword())
+			return nil, server.loginFailed(ctx, attempt, false, errIncorrectCredentials)
 		}
 
 		return nil, status.Errorf(codes.Internal, "failed to find user")
@@ -36,48 +47,90 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	// check password
 	err = util.CheckPassword(req.GetPassword(), user.HashedPassword)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "incorrect password")
+		return nil, server.loginFailed(ctx, attempt, true, errIncorrectCredentials)
 	}
 
-	// generate access token
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(req.Username, server.config.AccessTokenDuration)
+	//? the password is right, but users with two-factor authentication still have to enter a code.
+	//? their failed attempts are only forgotten once the code is right as well, so the codes are throttled too
+	mfaEnabled, err := server.mfa.Enabled(ctx, user.Username)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create access token")
+		return nil, status.Errorf(codes.Internal, "failed to check two-factor authentication: %s", err)
+	}
+
+	if mfaEnabled {
+		mfaToken, mfaPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeMFAChallenge, uuid.Nil, server.config.MFAChallengeDuration)
+
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create mfa token")
+		}
+
+		response := &pb.LoginUserResponse{
+			MfaRequired:       true,
+			MfaToken:          mfaToken,
+			MfaTokenExpiresAt: timestamppb.New(mfaPayload.ExpiresAt),
+		}
+
+		return response, nil
+	}
+
+	if err = server.loginSucceeded(ctx, attempt); err != nil {
+		return nil, err
 	}
 
-	// generate a refresh token
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(req.Username, server.config.RefreshTokenDuration)
+	return server.createLoginSession(ctx, user)
+}
+
+// createLoginSession starts a session for a user who passed every login check and returns its tokens.
+func (server *Server) createLoginSession(ctx context.Context, user db.User) (*pb.LoginUserResponse, error) {
+	// generate a refresh token, which starts a new token family
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeRefreshToken, uuid.Nil, server.config.RefreshTokenDuration)
 
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create refresh token")
 	}
 
+	// generate access token in the same family, so revoking the session revokes it as well
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeAccessToken, refreshPayload.FamilyID, server.config.AccessTokenDuration)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token")
+	}
+
 	// extract metadata
 	metadata := server.extractMetadata(ctx)
 
 	// create a session
-	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    metadata.UserAgent,
-		ClientIp:     metadata.ClientIp,
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiresAt,
+	txResult, err := server.store.CreateSessionTx(ctx, db.CreateSessionTxParams{
+		CreateSessionParams: db.CreateSessionParams{
+			ID:           refreshPayload.ID,
+			Username:     user.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    metadata.UserAgent,
+			ClientIp:     metadata.ClientIp,
+			IsBlocked:    false,
+			ExpiresAt:    refreshPayload.ExpiresAt,
+			// the login starts a new family that every rotated refresh token joins
+			FamilyID: refreshPayload.FamilyID,
+		},
+		Audit: server.auditContext(ctx, user.Username),
 	})
 
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create session")
+	}
+
 	// send back response
 	response := &pb.LoginUserResponse{
 		User:                  convertUser(user),
-		SessionId:             session.ID.String(),
+		SessionId:             txResult.Session.ID.String(),
 		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
 		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiresAt),
 		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiresAt),
 	}
 
 	return response, nil
-
 }
 
 func validateLoginUserRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
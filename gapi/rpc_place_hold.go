@@ -0,0 +1,128 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) PlaceHold(ctx context.Context, req *pb.PlaceHoldRequest) (*pb.PlaceHoldResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validatePlaceHoldRequest(req, server.config.HoldMaxDuration)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validAccount(ctx, req.GetAccountId(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// only the owner of the account can promise its funds to someone else
+	if !policy.CanMoveMoney(authPayload, account) {
+		return nil, status.Errorf(codes.PermissionDenied, "account does not belong to the authenticated user")
+	}
+
+	if err = server.requireVerifiedEmail(ctx, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	//? the hold is captured as a plain transfer, so the payee must hold the same currency
+	if _, err = server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency()); err != nil {
+		return nil, err
+	}
+
+	key, err := server.extractIdempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(server.config.HoldDuration)
+	if req.ExpiresAt != nil {
+		expiresAt = req.GetExpiresAt().AsTime()
+	}
+
+	result, err := server.store.PlaceHoldTx(ctx, db.PlaceHoldTxParams{
+		Owner:          authPayload.Username,
+		AccountID:      req.GetAccountId(),
+		ToAccountID:    req.GetToAccountId(),
+		Amount:         req.GetAmount(),
+		ExpiresAt:      expiresAt,
+		IdempotencyKey: key,
+		Audit:          server.auditContext(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, holdError(err)
+	}
+
+	response := &pb.PlaceHoldResponse{
+		Hold:    convertHold(result.Hold),
+		Account: convertAccount(result.Account),
+	}
+
+	return response, nil
+}
+
+func validatePlaceHoldRequest(req *pb.PlaceHoldRequest, maxDuration time.Duration) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := validator.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from account_id")))
+	}
+
+	if err := validator.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.ExpiresAt != nil {
+		if err := validateHoldExpiry(req.GetExpiresAt().AsTime(), maxDuration); err != nil {
+			violations = append(violations, fieldViolation("expires_at", err))
+		}
+	}
+
+	return
+}
+
+// validateHoldExpiry checks that a requested expiry is in the future and within the longest a hold can last.
+func validateHoldExpiry(expiresAt time.Time, maxDuration time.Duration) error {
+	now := time.Now()
+
+	if !expiresAt.After(now) {
+		return fmt.Errorf("must be in the future")
+	}
+
+	if expiresAt.After(now.Add(maxDuration)) {
+		return fmt.Errorf("must be within %s", maxDuration)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package gapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateHoldExpiry(t *testing.T) {
+	maxDuration := 24 * time.Hour
+
+	require.NoError(t, validateHoldExpiry(time.Now().Add(time.Hour), maxDuration))
+	require.Error(t, validateHoldExpiry(time.Now().Add(-time.Minute), maxDuration))
+	require.Error(t, validateHoldExpiry(time.Now().Add(maxDuration+time.Minute), maxDuration))
+}
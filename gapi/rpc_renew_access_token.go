@@ -0,0 +1,110 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RenewAccessToken exchanges a refresh token for a new access token and a new refresh token.
+// Each refresh token can be used once, presenting it again revokes every session of the same login.
+func (server *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+
+	violations := validateRenewAccessTokenRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken(), token.TokenTypeRefreshToken)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	// the refresh token is rotated on every renewal
+	refreshToken, newRefreshPayload, err := server.tokenMaker.CreateToken(refreshPayload.Username, refreshPayload.Role, token.TokenTypeRefreshToken, refreshPayload.FamilyID, server.config.RefreshTokenDuration)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create refresh token")
+	}
+
+	metadata := server.extractMetadata(ctx)
+
+	result, err := server.store.RenewSessionTx(ctx, db.RenewSessionTxParams{
+		SessionID:    refreshPayload.ID,
+		Username:     refreshPayload.Username,
+		RefreshToken: req.GetRefreshToken(),
+		NewSession: db.CreateSessionParams{
+			ID:           newRefreshPayload.ID,
+			RefreshToken: refreshToken,
+			UserAgent:    metadata.UserAgent,
+			ClientIp:     metadata.ClientIp,
+			ExpiresAt:    newRefreshPayload.ExpiresAt,
+		},
+		Audit: server.auditContext(ctx, refreshPayload.Username),
+	})
+
+	if err != nil {
+		//? the whole family was blocked, so its access tokens must stop working as well
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			revokeErr := server.tokenRevoker.RevokeFamily(ctx, refreshPayload.FamilyID, time.Now().Add(server.config.RefreshTokenDuration))
+			if revokeErr != nil {
+				return nil, status.Errorf(codes.Internal, "failed to revoke tokens: %s", revokeErr)
+			}
+		}
+
+		return nil, renewSessionError(err)
+	}
+
+	// the access token carries the user's current role in case it changed since the login
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(result.User.Username, result.User.Role, token.TokenTypeAccessToken, newRefreshPayload.FamilyID, server.config.AccessTokenDuration)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token")
+	}
+
+	response := &pb.RenewAccessTokenResponse{
+		SessionId:             result.Session.ID.String(),
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiresAt),
+		RefreshTokenExpiresAt: timestamppb.New(result.Session.ExpiresAt),
+	}
+
+	return response, nil
+}
+
+// renewSessionError maps the errors returned by RenewSessionTx to gRPC status errors.
+func renewSessionError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "session not found")
+	case errors.Is(err, db.ErrSessionBlocked),
+		errors.Is(err, db.ErrSessionExpired),
+		errors.Is(err, db.ErrSessionMismatch),
+		errors.Is(err, db.ErrRefreshTokenReused):
+		return unauthenticatedError(err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to renew session: %s", err)
+}
+
+func validateRenewAccessTokenRequest(req *pb.RenewAccessTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetRefreshToken() == "" {
+		violations = append(violations, fieldViolation("refresh_token", fmt.Errorf("must not be empty")))
+	}
+
+	return
+}
@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/worker"
+	"github.com/hibiken/asynq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestPasswordReset emails a password reset link to the address.
+// The user is looked up by the worker, so the response and its timing are the same whether or not the address is known.
+func (server *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+
+	violations := validateRequestPasswordResetRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	taskPayload := &worker.PayloadSendPasswordResetEmail{
+		Email: req.GetEmail(),
+	}
+
+	message, err := worker.OutboxTaskSendPasswordResetEmail(taskPayload, asynq.MaxRetry(10), asynq.Queue(worker.QueueCritical))
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create password reset email task: %s", err)
+	}
+
+	//? written to the outbox, so a request is not lost while Redis is unavailable
+	if _, err = server.store.CreateOutboxMessage(ctx, message); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to queue password reset email task: %s", err)
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+func validateRequestPasswordResetRequest(req *pb.RequestPasswordResetRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateEmail(req.GetEmail()); err != nil {
+		violations = append(violations, fieldViolation("email", err))
+	}
+
+	return
+}
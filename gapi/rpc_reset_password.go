@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResetPassword sets a new password with the token from a password reset email and signs out every session of the user.
+func (server *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+
+	violations := validateResetPasswordRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hashedPassword, err := util.HashPassword(req.GetPassword())
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+	}
+
+	//? the token itself authenticates the request, no access token is needed
+	result, err := server.store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		TokenHash:      util.HashSecret(req.GetToken()),
+		HashedPassword: hashedPassword,
+		Audit:          server.auditContext(ctx, ""),
+		//! whoever knew the old password may still hold tokens, they must stop working even if Redis is down right now
+		OutboxMessages: func(user db.User) ([]db.CreateOutboxMessageParams, error) {
+			message, err := revokeUserTokensMessage(user.Username)
+			return []db.CreateOutboxMessageParams{message}, err
+		},
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidPasswordReset) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to reset password: %s", err)
+	}
+
+	server.revokeTokensIssuedBefore(ctx, result.User)
+
+	//? the cutoff already covers the blocked sessions, their families only catch tokens issued within the same second as the reset
+	for _, session := range result.BlockedSessions {
+		if err = server.tokenRevoker.RevokeFamily(ctx, session.FamilyID, session.ExpiresAt); err != nil {
+			log.Error().Err(err).Str("session_id", session.ID.String()).Msg("failed to revoke session tokens")
+		}
+	}
+
+	return &pb.ResetPasswordResponse{}, nil
+}
+
+func validateResetPasswordRequest(req *pb.ResetPasswordRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateSecretCode(req.GetToken()); err != nil {
+		violations = append(violations, fieldViolation("token", err))
+	}
+
+	if err := validator.ValidatePassword(req.GetPassword()); err != nil {
+		violations = append(violations, fieldViolation("password", err))
+	}
+
+	return
+}
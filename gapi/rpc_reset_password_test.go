@@ -0,0 +1,122 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/VihangaFTW/Go-Backend/worker"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	username := util.RandomOwner()
+	resetToken, err := util.RandomSecret(32)
+	require.NoError(t, err)
+
+	_, refreshPayload, err := server.tokenMaker.CreateToken(username, util.DepositorRole, token.TokenTypeRefreshToken, uuid.Nil, time.Hour)
+	require.NoError(t, err)
+
+	accessToken, _, err := server.tokenMaker.CreateToken(username, util.DepositorRole, token.TokenTypeAccessToken, refreshPayload.FamilyID, time.Minute)
+	require.NoError(t, err)
+
+	store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ResetPasswordTxParams) (db.ResetPasswordTxResult, error) {
+			//! only the hash of the token is looked up
+			require.Equal(t, util.HashSecret(resetToken), arg.TokenHash)
+			require.NoError(t, util.CheckPassword("new secret", arg.HashedPassword))
+			requireRevokeUserTokensMessage(t, arg, username)
+
+			return db.ResetPasswordTxResult{
+				User: db.User{Username: username, PasswordChangedAt: time.Now()},
+				BlockedSessions: []db.Session{
+					{ID: refreshPayload.ID, Username: username, FamilyID: refreshPayload.FamilyID, ExpiresAt: refreshPayload.ExpiresAt},
+				},
+			}, nil
+		})
+
+	_, err = server.ResetPassword(context.Background(), &pb.ResetPasswordRequest{Token: resetToken, Password: "new secret"})
+	require.NoError(t, err)
+
+	//? tokens issued with the old password stop working before they expire
+	_, err = server.tokenMaker.VerifyToken(accessToken, token.TokenTypeAccessToken)
+	require.ErrorIs(t, err, token.ErrRevokedToken)
+}
+
+// requireRevokeUserTokensMessage checks that the reset writes the task revoking the tokens of the user to the outbox.
+func requireRevokeUserTokensMessage(t *testing.T, arg db.ResetPasswordTxParams, username string) {
+	require.NotNil(t, arg.OutboxMessages)
+
+	messages, err := arg.OutboxMessages(db.User{Username: username})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, worker.TaskRevokeUserTokens, messages[0].TaskType)
+
+	var payload worker.PayloadRevokeUserTokens
+	require.NoError(t, json.Unmarshal(messages[0].Payload, &payload))
+	require.Equal(t, username, payload.Username)
+}
+
+// failingRevoker stands in for a deny list that cannot be reached.
+type failingRevoker struct{}
+
+func (failingRevoker) RevokeFamily(ctx context.Context, familyID uuid.UUID, expiresAt time.Time) error {
+	return errors.New("redis is down")
+}
+
+func (failingRevoker) RevokeIssuedBefore(ctx context.Context, username string, issuedBefore time.Time) error {
+	return errors.New("redis is down")
+}
+
+func TestResetPasswordRevokerDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	server.tokenRevoker = failingRevoker{}
+
+	username := util.RandomOwner()
+
+	store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ResetPasswordTxParams) (db.ResetPasswordTxResult, error) {
+			requireRevokeUserTokensMessage(t, arg, username)
+
+			return db.ResetPasswordTxResult{
+				User:            db.User{Username: username, PasswordChangedAt: time.Now()},
+				BlockedSessions: []db.Session{{ID: uuid.New(), Username: username, FamilyID: uuid.New()}},
+			}, nil
+		})
+
+	//* the password is committed and the outbox task revokes the tokens, so the caller is not told the reset failed
+	_, err := server.ResetPassword(context.Background(), &pb.ResetPasswordRequest{Token: util.RandomString(43), Password: "new secret"})
+	require.NoError(t, err)
+}
+
+func TestResetPasswordInvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ResetPasswordTxResult{}, db.ErrInvalidPasswordReset)
+
+	_, err := server.ResetPassword(context.Background(), &pb.ResetPasswordRequest{Token: util.RandomString(43), Password: "new secret"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	//? a token too short to be ours is rejected before the lookup
+	_, err = server.ResetPassword(context.Background(), &pb.ResetPasswordRequest{Token: "short", Password: "new secret"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,110 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ReverseTransfer(ctx context.Context, req *pb.ReverseTransferRequest) (*pb.ReverseTransferResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateReverseTransferRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	transfer, err := server.store.GetTranfer(ctx, req.GetTransferId())
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "transfer [%d] not found", req.GetTransferId())
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get transfer: %s", err)
+	}
+
+	receiver, err := server.store.GetAccount(ctx, transfer.ToAccountID)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	// only the receiver can give the money back, unless an admin steps in
+	if !policy.CanReverseTransfer(authPayload, receiver) {
+		return nil, status.Errorf(codes.PermissionDenied, "transfer was not received by the authenticated user")
+	}
+
+	if err = server.requireVerifiedEmail(ctx, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	key, err := server.extractIdempotencyKey(ctx, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID:     transfer.ID,
+		Amount:         req.GetAmount(),
+		IdempotencyKey: key,
+		Audit:          server.auditContext(ctx, authPayload.Username),
+	})
+
+	if err != nil {
+		return nil, reverseTransferError(err)
+	}
+
+	response := &pb.ReverseTransferResponse{
+		Reversal:    convertTransfer(result.Transfer),
+		Original:    convertTransfer(result.Original),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}
+
+	return response, nil
+}
+
+// reverseTransferError maps the errors returned by ReverseTransferTx to gRPC status errors.
+func reverseTransferError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrTransferAlreadyReversed),
+		errors.Is(err, db.ErrReverseReversal),
+		errors.Is(err, db.ErrRefundTooLarge):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+
+	return transferError(err)
+}
+
+func validateReverseTransferRequest(req *pb.ReverseTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetTransferId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+
+	if req.Amount != nil {
+		if err := validator.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	return
+}
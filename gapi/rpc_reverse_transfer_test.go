@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReverseTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	client := NewGatewayClient(server, server.AuthUnaryInterceptor)
+
+	sender := util.RandomOwner()
+	receiver := db.User{Username: util.RandomOwner(), IsEmailVerified: true}
+
+	original := db.Transfer{ID: util.RandomInt(1, 1000), FromAccountID: 1, ToAccountID: 2, Amount: 100}
+	receiverAccount := db.Account{ID: original.ToAccountID, Owner: receiver.Username, Currency: util.USD}
+
+	reversal := db.Transfer{
+		ID:            original.ID + 1,
+		FromAccountID: original.ToAccountID,
+		ToAccountID:   original.FromAccountID,
+		Amount:        40,
+	}
+	reversal.ReversalOf.Int64, reversal.ReversalOf.Valid = original.ID, true
+
+	req := &pb.ReverseTransferRequest{TransferId: original.ID, Amount: proto.Int64(40)}
+
+	store.EXPECT().GetTranfer(gomock.Any(), gomock.Eq(original.ID)).Times(2).Return(original, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(original.ToAccountID)).Times(2).Return(receiverAccount, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(receiver.Username)).Times(1).Return(receiver, nil)
+
+	store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(1).
+		Return(db.ReverseTransferTxResult{TransferTxResult: db.TransferTxResult{Transfer: reversal}, Original: original}, nil)
+
+	//! the sender cannot take their money back themselves
+	ctx := newContextWithBearerToken(t, server.tokenMaker, sender, util.DepositorRole, token.TokenTypeAccessToken)
+	_, err := client.ReverseTransfer(ctx, req)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx = newContextWithBearerToken(t, server.tokenMaker, receiver.Username, util.DepositorRole, token.TokenTypeAccessToken)
+	res, err := client.ReverseTransfer(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, original.ID, res.GetReversal().GetReversalOf())
+	require.Equal(t, int64(40), res.GetReversal().GetAmount())
+	require.Nil(t, res.GetOriginal().ReversalOf)
+}
+
+func TestReverseTransferError(t *testing.T) {
+	require.Equal(t, codes.FailedPrecondition, status.Code(reverseTransferError(db.ErrTransferAlreadyReversed)))
+	require.Equal(t, codes.FailedPrecondition, status.Code(reverseTransferError(db.ErrReverseReversal)))
+	require.Equal(t, codes.FailedPrecondition, status.Code(reverseTransferError(db.ErrRefundTooLarge)))
+	require.Equal(t, codes.FailedPrecondition, status.Code(reverseTransferError(db.ErrInsufficientFunds)))
+	require.Equal(t, codes.Internal, status.Code(reverseTransferError(sql.ErrTxDone)))
+}
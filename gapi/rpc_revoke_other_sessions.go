@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeOtherSessions(ctx context.Context, req *pb.RevokeOtherSessionsRequest) (*pb.RevokeOtherSessionsResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateRevokeOtherSessionsRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	currentID := uuid.MustParse(req.GetSessionId())
+
+	// the kept session must belong to the caller, otherwise any id would sign out every device
+	session, err := server.store.GetSession(ctx, currentID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get session: %s", err)
+	}
+
+	if session.Username != authPayload.Username {
+		return nil, status.Errorf(codes.NotFound, "session not found")
+	}
+
+	revoked, err := server.store.BlockOtherSessions(ctx, db.BlockOtherSessionsParams{
+		Username:  authPayload.Username,
+		CurrentID: currentID,
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions: %s", err)
+	}
+
+	for _, session := range revoked {
+		err = server.tokenRevoker.RevokeFamily(ctx, session.FamilyID, session.ExpiresAt)
+
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to revoke tokens: %s", err)
+		}
+	}
+
+	return &pb.RevokeOtherSessionsResponse{RevokedSessions: int64(len(revoked))}, nil
+}
+
+func validateRevokeOtherSessionsRequest(req *pb.RevokeOtherSessionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateUUID(req.GetSessionId()); err != nil {
+		violations = append(violations, fieldViolation("session_id", err))
+	}
+
+	return
+}
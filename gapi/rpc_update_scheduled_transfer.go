@@ -0,0 +1,163 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/schedule"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateScheduledTransfer(ctx context.Context, req *pb.UpdateScheduledTransferRequest) (*pb.UpdateScheduledTransferResponse, error) {
+
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateUpdateScheduledTransferRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.ownedScheduledTransfer(ctx, req.GetId(), authPayload.Username)
+
+	if err != nil {
+		return nil, err
+	}
+
+	//? the change is built from the locked standing order, a run executed since it was read above may have finished it
+	result, err := server.store.UpdateScheduledTransferTx(ctx, db.UpdateScheduledTransferTxParams{
+		ID: scheduledTransfer.ID,
+		// finished standing orders are kept for their history but can no longer change
+		AllowedStatuses: []string{db.ScheduledTransferActive, db.ScheduledTransferPaused},
+		Update: func(scheduledTransfer db.ScheduledTransfer) (db.UpdateScheduledTransferParams, error) {
+			return scheduledTransferUpdate(scheduledTransfer, req)
+		},
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrScheduledTransferStatus) {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot update scheduled transfer: %s", err)
+		}
+
+		//? the schedule was rejected while building the change
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to update scheduled transfer: %s", err)
+	}
+
+	response := &pb.UpdateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(result.ScheduledTransfer),
+	}
+
+	return response, nil
+}
+
+// scheduledTransferUpdate returns the change the request makes to the standing order.
+// The returned error is already a gRPC status error.
+func scheduledTransferUpdate(scheduledTransfer db.ScheduledTransfer, req *pb.UpdateScheduledTransferRequest) (db.UpdateScheduledTransferParams, error) {
+	arg := db.UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+		Amount: sql.NullInt64{
+			Int64: req.GetAmount(),
+			Valid: req.Amount != nil,
+		},
+		Frequency: sql.NullString{
+			String: req.GetFrequency(),
+			Valid:  req.Frequency != nil,
+		},
+		CronExpression: sql.NullString{
+			String: req.GetCronExpression(),
+			Valid:  req.CronExpression != nil,
+		},
+		StartAt: sql.NullTime{
+			Time:  req.GetStartAt().AsTime(),
+			Valid: req.StartAt != nil,
+		},
+	}
+
+	if req.Paused != nil {
+		newStatus := db.ScheduledTransferActive
+		if req.GetPaused() {
+			newStatus = db.ScheduledTransferPaused
+		}
+
+		arg.Status = sql.NullString{String: newStatus, Valid: true}
+	}
+
+	rescheduled := arg.Frequency.Valid || arg.CronExpression.Valid || arg.StartAt.Valid
+	resumed := scheduledTransfer.Status == db.ScheduledTransferPaused && req.Paused != nil && !req.GetPaused()
+
+	//? a new schedule, or one resumed after its runs went by, restarts from the next upcoming run
+	if rescheduled || resumed {
+		s := schedule.Schedule{
+			Frequency:      scheduledTransfer.Frequency,
+			CronExpression: scheduledTransfer.CronExpression,
+			StartAt:        scheduledTransfer.StartAt,
+		}
+
+		if arg.Frequency.Valid {
+			s.Frequency = arg.Frequency.String
+		}
+
+		if arg.CronExpression.Valid {
+			s.CronExpression = arg.CronExpression.String
+		}
+
+		if arg.StartAt.Valid {
+			s.StartAt = arg.StartAt.Time
+		}
+
+		nextRunAt, err := s.Upcoming(time.Now())
+
+		if err != nil {
+			return arg, status.Errorf(codes.InvalidArgument, "invalid schedule: %s", err)
+		}
+
+		if nextRunAt.IsZero() {
+			return arg, status.Errorf(codes.InvalidArgument, "schedule has no upcoming runs")
+		}
+
+		arg.NextRunAt = sql.NullTime{Time: nextRunAt, Valid: true}
+	}
+
+	return arg, nil
+}
+
+func validateUpdateScheduledTransferRequest(req *pb.UpdateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	if req.Amount != nil {
+		if err := validator.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	if req.Frequency != nil {
+		violations = append(violations, validateSchedule(req.GetFrequency(), req.GetCronExpression())...)
+	}
+
+	if req.StartAt != nil {
+		if err := validateStartAt(req.GetStartAt()); err != nil {
+			violations = append(violations, fieldViolation("start_at", err))
+		}
+	}
+
+	return
+}
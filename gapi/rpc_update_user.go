@@ -8,7 +8,10 @@ import (
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
 	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/policy"
 	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/worker"
+	"github.com/hibiken/asynq"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -16,11 +19,11 @@ import (
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
 
-	//? authorize user's access token
-	authPayload, err := server.authorizeUser(ctx)
+	//? the access token was verified by the auth interceptor
+	authPayload, err := authPayloadFromContext(ctx)
 
 	if err != nil {
-		return nil, unauthenticatedError(err)
+		return nil, err
 	}
 
 	violations := validateUpdateUserRequest(req)
@@ -30,10 +33,15 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 
-	if authPayload.Username != req.GetUsername() {
+	// admins manage every user, everyone else can only update themselves
+	if !policy.CanUpdateUser(authPayload, req.GetUsername()) {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's info")
 	}
 
+	if req.Role != nil && !policy.CanChangeRole(authPayload) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot change user roles")
+	}
+
 	arg := db.UpdateUserParams{
 		Username: req.Username,
 		FullName: sql.NullString{
@@ -44,6 +52,15 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 			String: req.GetEmail(),
 			Valid:  req.Email != nil,
 		},
+		// a new email address has to be verified again
+		IsEmailVerified: sql.NullBool{
+			Bool:  false,
+			Valid: req.Email != nil,
+		},
+		Role: sql.NullString{
+			String: req.GetRole(),
+			Valid:  req.Role != nil,
+		},
 	}
 
 	if req.Password != nil {
@@ -66,7 +83,44 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		}
 	}
 
-	user, err := server.store.UpdateUser(ctx, arg)
+	txArg := db.UpdateUserTxParams{
+		UpdateUserParams: arg,
+		Audit:            server.auditContext(ctx, authPayload.Username),
+	}
+
+	if req.Email != nil || req.Password != nil {
+		// the tasks go through the outbox, so they only run if the update is committed and are not lost if Redis is down
+		txArg.OutboxMessages = func(user db.User) ([]db.CreateOutboxMessageParams, error) {
+			var messages []db.CreateOutboxMessageParams
+
+			if req.Email != nil {
+				taskPayload := &worker.PayloadSendVerifyEmail{
+					Username: user.Username,
+				}
+
+				message, err := worker.OutboxTaskSendVerifyEmail(taskPayload, asynq.MaxRetry(10), asynq.Queue(worker.QueueCritical))
+				if err != nil {
+					return nil, err
+				}
+
+				messages = append(messages, message)
+			}
+
+			if req.Password != nil {
+				//! tokens issued with the old password, possibly to whoever learned it, must stop working
+				message, err := revokeUserTokensMessage(user.Username)
+				if err != nil {
+					return nil, err
+				}
+
+				messages = append(messages, message)
+			}
+
+			return messages, nil
+		}
+	}
+
+	txResult, err := server.store.UpdateUserTx(ctx, txArg)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -75,6 +129,12 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, status.Errorf(codes.Internal, "failed to update user: %s", err)
 	}
 
+	user := txResult.User
+
+	if req.Password != nil {
+		server.revokeTokensIssuedBefore(ctx, user)
+	}
+
 	response := &pb.UpdateUserResponse{
 		User: convertUser(user),
 	}
@@ -92,6 +152,12 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		if err := validator.ValidateEmail(req.GetEmail()); err != nil {
 			violations = append(violations, fieldViolation("email", err))
 		}
+	}
+
+	if req.Role != nil {
+		if err := validator.ValidateRole(req.GetRole()); err != nil {
+			violations = append(violations, fieldViolation("role", err))
+		}
 
 	}
 
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+
+	violations := validateVerifyEmailRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	//? the link itself authenticates the request, no access token is needed
+	result, err := server.store.VerifyEmailTx(ctx, db.VerifyEmailTxParams{
+		EmailID:    req.GetEmailId(),
+		SecretCode: req.GetSecretCode(),
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidVerifyEmail) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to verify email: %s", err)
+	}
+
+	response := &pb.VerifyEmailResponse{
+		IsVerified: result.User.IsEmailVerified,
+	}
+
+	return response, nil
+}
+
+// requireVerifiedEmail blocks sensitive operations such as transfers until the user has verified their email address.
+// The returned error is already a gRPC status error.
+func (server *Server) requireVerifiedEmail(ctx context.Context, username string) error {
+	user, err := server.store.GetUser(ctx, username)
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	if !user.IsEmailVerified {
+		return status.Errorf(codes.PermissionDenied, "email address must be verified before this operation")
+	}
+
+	return nil
+}
+
+func validateVerifyEmailRequest(req *pb.VerifyEmailRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := validator.ValidateEmailID(req.GetEmailId()); err != nil {
+		violations = append(violations, fieldViolation("email_id", err))
+	}
+
+	if err := validator.ValidateSecretCode(req.GetSecretCode()); err != nil {
+		violations = append(violations, fieldViolation("secret_code", err))
+	}
+
+	return
+}
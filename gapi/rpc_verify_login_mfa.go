@@ -0,0 +1,73 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VihangaFTW/Go-Backend/mfa"
+	"github.com/VihangaFTW/Go-Backend/pb"
+	validator "github.com/VihangaFTW/Go-Backend/rpc_validator"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyLoginMFA finishes the login of a user with two-factor authentication.
+// The mfa token proves the password was checked, the code proves the user holds the second factor.
+func (server *Server) VerifyLoginMFA(ctx context.Context, req *pb.VerifyLoginMFARequest) (*pb.LoginUserResponse, error) {
+
+	violations := validateVerifyLoginMFARequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	mfaPayload, err := server.tokenMaker.VerifyToken(req.GetMfaToken(), token.TokenTypeMFAChallenge)
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	//? a code has only a million values, so guessing it is throttled like guessing the password
+	attempt := server.loginAttempt(ctx, mfaPayload.Username)
+
+	if err = server.checkLoginAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	if err = server.mfa.Verify(ctx, mfaPayload.Username, req.GetCode()); err != nil {
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			return nil, server.loginFailed(ctx, attempt, true, mfaError(err))
+		}
+
+		return nil, mfaError(err)
+	}
+
+	if err = server.loginSucceeded(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	//? read the user again, the role may have changed since the password check
+	user, err := server.store.GetUser(ctx, mfaPayload.Username)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find user")
+	}
+
+	return server.createLoginSession(ctx, user)
+}
+
+func validateVerifyLoginMFARequest(req *pb.VerifyLoginMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetMfaToken() == "" {
+		violations = append(violations, fieldViolation("mfa_token", fmt.Errorf("must not be empty")))
+	}
+
+	if err := validator.ValidateMFACode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/mfa"
 	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/ratelimit"
 	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/VihangaFTW/Go-Backend/util"
-	"github.com/VihangaFTW/Go-Backend/worker"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,23 +18,40 @@ type Server struct {
 	config     util.Config
 	store      db.Store
 	tokenMaker token.Maker
-	router     *gin.Engine
-
-	taskDistributor worker.TaskDistributor
+	// tokenRevoker revokes tokens issued by tokenMaker before they expire
+	tokenRevoker token.Revoker
+	router       *gin.Engine
+	// mfa checks the second factor of users with two-factor authentication
+	mfa *mfa.Authenticator
+	// loginGuard throttles failed logins per username and client IP
+	loginGuard *lockout.Guard
+	// rateLimiter limits the requests of every user and client
+	rateLimiter *ratelimit.Limiter
 }
 
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.PasetoHexKey)
+func NewServer(config util.Config, store db.Store, denyList token.DenyList, loginGuard *lockout.Guard, rateLimiter *ratelimit.Limiter) (*Server, error) {
+	baseMaker, err := token.NewMakerFromConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	//? refresh tokens live the longest, so no revoked token outlives them
+	tokenMaker := token.NewRevocationMaker(baseMaker, denyList, config.RefreshTokenDuration)
+
+	mfaCipher, err := mfa.NewCipher(config.MFAEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create mfa cipher: %w", err)
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
-		taskDistributor: taskDistributor,
-	}	
+		config:       config,
+		store:        store,
+		tokenMaker:   tokenMaker,
+		tokenRevoker: tokenMaker,
+		mfa:          mfa.NewAuthenticator(store, mfaCipher, config.MFAIssuer),
+		loginGuard:   loginGuard,
+		rateLimiter:  rateLimiter,
+	}
 
 	return server, nil
 }
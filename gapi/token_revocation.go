@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/worker"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+// revokeUserTokensMessage returns the outbox message that revokes the tokens of the user issued before their password change.
+// Every transaction that changes a password writes it, so the revocation happens once the change is committed even if Redis is down.
+func revokeUserTokensMessage(username string) (db.CreateOutboxMessageParams, error) {
+	taskPayload := &worker.PayloadRevokeUserTokens{
+		Username: username,
+	}
+
+	return worker.OutboxTaskRevokeUserTokens(taskPayload, asynq.MaxRetry(25), asynq.Queue(worker.QueueCritical))
+}
+
+// revokeTokensIssuedBefore rejects the tokens of the user issued before their password change without waiting for the outbox.
+// A failure only delays the revocation until the outbox task runs, so it is logged rather than returned.
+func (server *Server) revokeTokensIssuedBefore(ctx context.Context, user db.User) {
+	err := server.tokenRevoker.RevokeIssuedBefore(ctx, user.Username, user.PasswordChangedAt)
+
+	if err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("failed to revoke tokens, left to the outbox task")
+	}
+}
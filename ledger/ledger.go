@@ -0,0 +1,126 @@
+package ledger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+)
+
+// maxAlertLines caps the problems listed in an alert email, the full report is in the worker log.
+const maxAlertLines = 20
+
+// Report is the outcome of a ledger verification.
+type Report struct {
+	CheckedAt           time.Time                       `json:"checked_at"`
+	AccountsChecked     int64                           `json:"accounts_checked"`
+	BalanceDrifts       []db.ListBalanceDriftsRow       `json:"balance_drifts"`
+	HeldBalanceDrifts   []db.ListHeldBalanceDriftsRow   `json:"held_balance_drifts"`
+	UnbalancedTransfers []db.ListUnbalancedTransfersRow `json:"unbalanced_transfers"`
+	OrphanEntries       []db.Entry                      `json:"orphan_entries"`
+	// LegacyEntries counts the entries recorded before transfers were tracked. They are not a problem on their own.
+	LegacyEntries int64 `json:"legacy_entries"`
+}
+
+// Verify checks the ledger held by the store.
+func Verify(ctx context.Context, store db.Store) (Report, error) {
+	result, err := store.VerifyLedgerTx(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to verify ledger: %w", err)
+	}
+
+	//? empty lists rather than null, so consumers of the json output can iterate without checks
+	report := Report{
+		CheckedAt:           time.Now().UTC(),
+		AccountsChecked:     result.AccountsChecked,
+		BalanceDrifts:       append([]db.ListBalanceDriftsRow{}, result.BalanceDrifts...),
+		HeldBalanceDrifts:   append([]db.ListHeldBalanceDriftsRow{}, result.HeldBalanceDrifts...),
+		UnbalancedTransfers: append([]db.ListUnbalancedTransfersRow{}, result.UnbalancedTransfers...),
+		OrphanEntries:       append([]db.Entry{}, result.OrphanEntries...),
+		LegacyEntries:       result.LegacyEntries,
+	}
+
+	return report, nil
+}
+
+// Problems returns the number of inconsistencies found.
+func (report Report) Problems() int {
+	return len(report.BalanceDrifts) + len(report.HeldBalanceDrifts) + len(report.UnbalancedTransfers) + len(report.OrphanEntries)
+}
+
+// OK reports whether the balances and transfers agree with the entries and the held balances with the active holds.
+func (report Report) OK() bool {
+	return report.Problems() == 0
+}
+
+// WriteJSON writes the report as indented JSON.
+func WriteJSON(w io.Writer, report Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+// Lines describes every problem of the report in one line each.
+func Lines(report Report) []string {
+	lines := make([]string, 0, report.Problems())
+
+	for _, drift := range report.BalanceDrifts {
+		lines = append(lines, fmt.Sprintf("account %d (%s, %s) has balance %d but its entries sum to %d, a drift of %d",
+			drift.AccountID, drift.Owner, drift.Currency, drift.Balance, drift.EntriesTotal, drift.Balance-drift.EntriesTotal))
+	}
+
+	for _, drift := range report.HeldBalanceDrifts {
+		lines = append(lines, fmt.Sprintf("account %d (%s, %s) has held balance %d but its active holds sum to %d, a drift of %d",
+			drift.AccountID, drift.Owner, drift.Currency, drift.HeldBalance, drift.HoldsTotal, drift.HeldBalance-drift.HoldsTotal))
+	}
+
+	for _, transfer := range report.UnbalancedTransfers {
+		lines = append(lines, fmt.Sprintf("transfer %d of %d from account %d to %d has %d entries debiting %d and crediting %d, expected a credit of %d",
+			transfer.TransferID, transfer.Amount, transfer.FromAccountID, transfer.ToAccountID,
+			transfer.EntryCount, transfer.DebitTotal, transfer.CreditTotal, transfer.ExpectedCredit))
+	}
+
+	for _, entry := range report.OrphanEntries {
+		lines = append(lines, fmt.Sprintf("entry %d of %d on account %d is not booked to a party of transfer %d",
+			entry.ID, entry.Amount, entry.AccountID, entry.TransferID.Int64))
+	}
+
+	return lines
+}
+
+// AlertEmail returns the subject and HTML content of the email sent when the report found problems.
+func AlertEmail(report Report) (string, string) {
+	subject := "Simple Bank ledger check failed"
+
+	var content strings.Builder
+
+	fmt.Fprintf(&content, "The ledger check of %s went through %d accounts and found %d problems:<br/>\n",
+		report.CheckedAt.Format("2006-01-02 15:04 MST"), report.AccountsChecked, report.Problems())
+	fmt.Fprintf(&content, "%d accounts whose balance does not match their entries<br/>\n", len(report.BalanceDrifts))
+	fmt.Fprintf(&content, "%d accounts whose held balance does not match their active holds<br/>\n", len(report.HeldBalanceDrifts))
+	fmt.Fprintf(&content, "%d transfers without exactly one matching debit and credit<br/>\n", len(report.UnbalancedTransfers))
+	fmt.Fprintf(&content, "%d entries that their transfer does not explain<br/>\n", len(report.OrphanEntries))
+	fmt.Fprintf(&content, "%d entries recorded before transfers were tracked were not checked against a transfer<br/>\n", report.LegacyEntries)
+
+	content.WriteString("<ul>\n")
+
+	lines := Lines(report)
+	for i, line := range lines {
+		if i == maxAlertLines {
+			fmt.Fprintf(&content, "<li>and %d more, see the worker log for the full report</li>\n", len(lines)-maxAlertLines)
+			break
+		}
+
+		fmt.Fprintf(&content, "<li>%s</li>\n", html.EscapeString(line))
+	}
+
+	content.WriteString("</ul>\n")
+
+	return subject, content.String()
+}
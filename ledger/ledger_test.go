@@ -0,0 +1,129 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func brokenLedger() db.VerifyLedgerTxResult {
+	return db.VerifyLedgerTxResult{
+		AccountsChecked: 3,
+		BalanceDrifts: []db.ListBalanceDriftsRow{
+			{AccountID: 1, Owner: "alice", Currency: util.USD, Balance: 1_500, EntriesTotal: 1_000},
+		},
+		HeldBalanceDrifts: []db.ListHeldBalanceDriftsRow{
+			{AccountID: 2, Owner: "bob", Currency: util.USD, HeldBalance: 300, HoldsTotal: 200},
+		},
+		UnbalancedTransfers: []db.ListUnbalancedTransfersRow{
+			{TransferID: 7, FromAccountID: 1, ToAccountID: 2, Amount: 100, ExpectedCredit: 100, EntryCount: 1, DebitTotal: -100},
+		},
+		OrphanEntries: []db.Entry{
+			{ID: 12, AccountID: 3, Amount: -20, TransferID: sql.NullInt64{Int64: 7, Valid: true}},
+		},
+		LegacyEntries: 2,
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().VerifyLedgerTx(gomock.Any()).Times(1).Return(brokenLedger(), nil)
+
+	report, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	require.False(t, report.OK())
+	require.Equal(t, 4, report.Problems())
+	require.Equal(t, int64(3), report.AccountsChecked)
+	require.Equal(t, int64(2), report.LegacyEntries)
+	require.NotZero(t, report.CheckedAt)
+}
+
+func TestVerifyCleanLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().VerifyLedgerTx(gomock.Any()).Times(1).Return(db.VerifyLedgerTxResult{AccountsChecked: 3}, nil)
+
+	report, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	require.True(t, report.OK())
+
+	//? a clean report lists empty problems rather than null
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, report))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, []any{}, decoded["balance_drifts"])
+	require.Equal(t, []any{}, decoded["held_balance_drifts"])
+	require.Equal(t, []any{}, decoded["unbalanced_transfers"])
+	require.Equal(t, []any{}, decoded["orphan_entries"])
+}
+
+func TestVerifyLegacyEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	//? entries recorded before migration 000007 have no transfer and do not fail the check
+	store.EXPECT().VerifyLedgerTx(gomock.Any()).Times(1).Return(db.VerifyLedgerTxResult{AccountsChecked: 3, LegacyEntries: 10}, nil)
+
+	report, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	require.True(t, report.OK())
+	require.Equal(t, int64(10), report.LegacyEntries)
+	require.Empty(t, Lines(report))
+}
+
+func TestVerifyStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().VerifyLedgerTx(gomock.Any()).Times(1).Return(db.VerifyLedgerTxResult{}, sql.ErrConnDone)
+
+	_, err := Verify(context.Background(), store)
+	require.True(t, errors.Is(err, sql.ErrConnDone))
+}
+
+func TestLines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().VerifyLedgerTx(gomock.Any()).Times(1).Return(brokenLedger(), nil)
+
+	report, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+
+	lines := Lines(report)
+	require.Len(t, lines, 4)
+	require.Contains(t, lines[0], "a drift of 500")
+	require.Contains(t, lines[1], "held balance 300 but its active holds sum to 200")
+	require.Contains(t, lines[2], "transfer 7")
+	require.Contains(t, lines[3], "not booked to a party of transfer 7")
+}
+
+func TestAlertEmail(t *testing.T) {
+	report := Report{}
+
+	for i := range maxAlertLines + 5 {
+		report.OrphanEntries = append(report.OrphanEntries, db.Entry{ID: int64(i + 1), AccountID: 1, Amount: 10, TransferID: sql.NullInt64{Int64: 1, Valid: true}})
+	}
+
+	subject, content := AlertEmail(report)
+	require.NotEmpty(t, subject)
+	require.Contains(t, content, fmt.Sprintf("found %d problems", maxAlertLines+5))
+	require.Contains(t, content, fmt.Sprintf("entry %d of 10", maxAlertLines))
+	require.NotContains(t, content, fmt.Sprintf("entry %d of 10", maxAlertLines+1))
+	require.Contains(t, content, "and 5 more")
+}
@@ -0,0 +1,123 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCounter is a Counter shared by every server instance through Redis.
+// Every key expires on its own, so nothing has to be cleaned up.
+type RedisCounter struct {
+	client redis.UniversalClient
+}
+
+func NewRedisCounter(client redis.UniversalClient) *RedisCounter {
+	return &RedisCounter{client: client}
+}
+
+func (counter *RedisCounter) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+
+	//? both commands run in a MULTI block, so a counter never lives on without an expiry
+	_, err := counter.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, window)
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (counter *RedisCounter) Block(ctx context.Context, key string, duration time.Duration) error {
+	return counter.client.Set(ctx, key, 1, duration).Err()
+}
+
+func (counter *RedisCounter) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := counter.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	//? negative values mean the key does not exist or has no expiry
+	return max(ttl, 0), nil
+}
+
+func (counter *RedisCounter) Reset(ctx context.Context, keys ...string) error {
+	return counter.client.Del(ctx, keys...).Err()
+}
+
+// MemoryCounter is a Counter kept in the memory of a single process.
+// It suits tests and single instance setups; anything else should share a RedisCounter.
+type MemoryCounter struct {
+	mutex   sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value     int64
+	expiresAt time.Time
+}
+
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{entries: make(map[string]memoryEntry)}
+}
+
+func (counter *MemoryCounter) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	entry := counter.get(key)
+	entry.value++
+	entry.expiresAt = time.Now().Add(window)
+	counter.entries[key] = entry
+
+	return entry.value, nil
+}
+
+func (counter *MemoryCounter) Block(ctx context.Context, key string, duration time.Duration) error {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	counter.entries[key] = memoryEntry{value: 1, expiresAt: time.Now().Add(duration)}
+	return nil
+}
+
+func (counter *MemoryCounter) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	entry := counter.get(key)
+	if entry.value == 0 {
+		return 0, nil
+	}
+
+	return time.Until(entry.expiresAt), nil
+}
+
+func (counter *MemoryCounter) Reset(ctx context.Context, keys ...string) error {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(counter.entries, key)
+	}
+
+	return nil
+}
+
+// get returns the entry of the key, or a zero entry once it has expired. The mutex must be held.
+func (counter *MemoryCounter) get(key string) memoryEntry {
+	entry, ok := counter.entries[key]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		return memoryEntry{}
+	}
+
+	return entry
+}
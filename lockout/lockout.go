@@ -0,0 +1,175 @@
+// Package lockout slows down password guessing by tracking failed logins per username and per client IP.
+// After a few free attempts every failure doubles the wait before the next one, until the username or IP is locked out.
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+// Policy describes how quickly failures of one username or one client IP are throttled.
+type Policy struct {
+	// FreeAttempts failures are allowed before any delay is imposed
+	FreeAttempts int64
+	// BaseDelay is the wait after the first failure past the free attempts, doubled by every further failure
+	BaseDelay time.Duration
+	// LockoutAttempts failures lock the username or IP out for LockoutDuration
+	LockoutAttempts int64
+	LockoutDuration time.Duration
+	// Window is how long failures are remembered after the last one
+	Window time.Duration
+}
+
+// Default policies. Many users can share an IP behind a NAT, so IPs get more room than usernames.
+var (
+	UsernamePolicy = Policy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		LockoutAttempts: 10,
+		LockoutDuration: 15 * time.Minute,
+		Window:          time.Hour,
+	}
+	ClientIPPolicy = Policy{
+		FreeAttempts:    20,
+		BaseDelay:       time.Second,
+		LockoutAttempts: 100,
+		LockoutDuration: 15 * time.Minute,
+		Window:          time.Hour,
+	}
+)
+
+// delay returns how long to wait after the given number of failures.
+func (policy Policy) delay(failures int64) time.Duration {
+	if failures >= policy.LockoutAttempts {
+		return policy.LockoutDuration
+	}
+
+	if failures <= policy.FreeAttempts {
+		return 0
+	}
+
+	delay := policy.BaseDelay
+	for i := policy.FreeAttempts + 1; i < failures && delay < policy.LockoutDuration; i++ {
+		delay *= 2
+	}
+
+	return min(delay, policy.LockoutDuration)
+}
+
+// Counter keeps the failure counts and the waits, usually shared by every server instance through Redis.
+type Counter interface {
+	// Increment adds a failure and returns the number of failures, which are forgotten after window
+	Increment(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Block makes BlockedFor report the key as blocked for the duration
+	Block(ctx context.Context, key string, duration time.Duration) error
+	// BlockedFor returns how long the key remains blocked, or zero
+	BlockedFor(ctx context.Context, key string) (time.Duration, error)
+	// Reset forgets the failures and the blocks of the keys
+	Reset(ctx context.Context, keys ...string) error
+}
+
+// Attempt identifies who is trying to log in.
+type Attempt struct {
+	Username string
+	ClientIP string
+}
+
+// Result describes the state after a failed attempt.
+type Result struct {
+	// RetryAfter is how long to wait before the next attempt is accepted
+	RetryAfter time.Duration
+	// LockedOut is set by the failure that locks the username out, so the user is notified only once
+	LockedOut bool
+}
+
+// Guard applies the policies to login attempts.
+type Guard struct {
+	counter        Counter
+	usernamePolicy Policy
+	clientIPPolicy Policy
+}
+
+// NewGuard creates a Guard with the default policies.
+func NewGuard(counter Counter) *Guard {
+	return NewGuardWithPolicies(counter, UsernamePolicy, ClientIPPolicy)
+}
+
+// NewGuardWithPolicies creates a Guard that throttles usernames and client IPs with the given policies.
+func NewGuardWithPolicies(counter Counter, usernamePolicy Policy, clientIPPolicy Policy) *Guard {
+	return &Guard{
+		counter:        counter,
+		usernamePolicy: usernamePolicy,
+		clientIPPolicy: clientIPPolicy,
+	}
+}
+
+// Check returns how long to wait before the attempt is accepted, or zero if it may go ahead.
+func (guard *Guard) Check(ctx context.Context, attempt Attempt) (time.Duration, error) {
+	usernameWait, err := guard.counter.BlockedFor(ctx, blockedKey("username", attempt.Username))
+	if err != nil {
+		return 0, err
+	}
+
+	//? an unknown client IP is not tracked, otherwise every such client would share a single counter
+	if attempt.ClientIP == "" {
+		return usernameWait, nil
+	}
+
+	clientIPWait, err := guard.counter.BlockedFor(ctx, blockedKey("client_ip", attempt.ClientIP))
+	if err != nil {
+		return 0, err
+	}
+
+	return max(usernameWait, clientIPWait), nil
+}
+
+// Failure records a failed attempt against both the username and the client IP.
+func (guard *Guard) Failure(ctx context.Context, attempt Attempt) (Result, error) {
+	usernameFailures, usernameWait, err := guard.fail(ctx, "username", attempt.Username, guard.usernamePolicy)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var clientIPWait time.Duration
+	if attempt.ClientIP != "" {
+		_, clientIPWait, err = guard.fail(ctx, "client_ip", attempt.ClientIP, guard.clientIPPolicy)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	return Result{
+		RetryAfter: max(usernameWait, clientIPWait),
+		LockedOut:  usernameFailures == guard.usernamePolicy.LockoutAttempts,
+	}, nil
+}
+
+// Success forgets the failures of the username. Failures of the client IP are kept,
+// otherwise an attacker could clear them by logging in to their own account in between guesses.
+func (guard *Guard) Success(ctx context.Context, attempt Attempt) error {
+	return guard.counter.Reset(ctx, failuresKey("username", attempt.Username), blockedKey("username", attempt.Username))
+}
+
+func (guard *Guard) fail(ctx context.Context, kind string, id string, policy Policy) (int64, time.Duration, error) {
+	failures, err := guard.counter.Increment(ctx, failuresKey(kind, id), policy.Window)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	wait := policy.delay(failures)
+	if wait > 0 {
+		if err = guard.counter.Block(ctx, blockedKey(kind, id), wait); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return failures, wait, nil
+}
+
+func failuresKey(kind string, id string) string {
+	return "login:failures:" + kind + ":" + id
+}
+
+func blockedKey(kind string, id string) string {
+	return "login:blocked:" + kind + ":" + id
+}
@@ -0,0 +1,124 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyDelay(t *testing.T) {
+	policy := Policy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		LockoutAttempts: 10,
+		LockoutDuration: time.Minute,
+	}
+
+	testcases := map[int64]time.Duration{
+		1:  0,
+		3:  0,
+		4:  time.Second,
+		5:  2 * time.Second,
+		6:  4 * time.Second,
+		9:  32 * time.Second,
+		10: time.Minute,
+		50: time.Minute,
+	}
+
+	for failures, expected := range testcases {
+		require.Equal(t, expected, policy.delay(failures), "failures: %d", failures)
+	}
+
+	//? the doubling never exceeds the lockout
+	policy.LockoutAttempts = 100
+	require.Equal(t, time.Minute, policy.delay(99))
+}
+
+func TestGuard(t *testing.T) {
+	guard := NewGuard(NewMemoryCounter())
+	ctx := context.Background()
+
+	attempt := Attempt{Username: util.RandomOwner(), ClientIP: "10.0.0.1"}
+
+	for i := int64(1); i <= UsernamePolicy.FreeAttempts; i++ {
+		result, err := guard.Failure(ctx, attempt)
+		require.NoError(t, err)
+		require.Zero(t, result.RetryAfter)
+	}
+
+	wait, err := guard.Check(ctx, attempt)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	//? the next failure imposes a wait on the username from any IP
+	result, err := guard.Failure(ctx, attempt)
+	require.NoError(t, err)
+	require.Equal(t, UsernamePolicy.BaseDelay, result.RetryAfter)
+	require.False(t, result.LockedOut)
+
+	wait, err = guard.Check(ctx, Attempt{Username: attempt.Username, ClientIP: "10.0.0.2"})
+	require.NoError(t, err)
+	require.Greater(t, wait, time.Duration(0))
+
+	//? other users behind the same IP are not affected yet
+	wait, err = guard.Check(ctx, Attempt{Username: util.RandomOwner(), ClientIP: attempt.ClientIP})
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	//? a success clears the username
+	require.NoError(t, guard.Success(ctx, attempt))
+
+	wait, err = guard.Check(ctx, attempt)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+}
+
+func TestGuardLockout(t *testing.T) {
+	guard := NewGuard(NewMemoryCounter())
+	ctx := context.Background()
+
+	attempt := Attempt{Username: util.RandomOwner(), ClientIP: "10.0.0.1"}
+
+	var lockouts int
+	var result Result
+	var err error
+
+	for i := int64(1); i <= UsernamePolicy.LockoutAttempts+2; i++ {
+		result, err = guard.Failure(ctx, attempt)
+		require.NoError(t, err)
+
+		if result.LockedOut {
+			lockouts++
+		}
+	}
+
+	//! the user is notified of the lockout once, not on every further failure
+	require.Equal(t, 1, lockouts)
+	require.Equal(t, UsernamePolicy.LockoutDuration, result.RetryAfter)
+
+	wait, err := guard.Check(ctx, attempt)
+	require.NoError(t, err)
+	require.InDelta(t, UsernamePolicy.LockoutDuration, wait, float64(time.Second))
+}
+
+func TestGuardClientIP(t *testing.T) {
+	guard := NewGuard(NewMemoryCounter())
+	ctx := context.Background()
+
+	//? guessing one password against many usernames is caught by the IP
+	for i := int64(1); i <= ClientIPPolicy.FreeAttempts+1; i++ {
+		_, err := guard.Failure(ctx, Attempt{Username: util.RandomOwner(), ClientIP: "10.0.0.1"})
+		require.NoError(t, err)
+	}
+
+	wait, err := guard.Check(ctx, Attempt{Username: util.RandomOwner(), ClientIP: "10.0.0.1"})
+	require.NoError(t, err)
+	require.Greater(t, wait, time.Duration(0))
+
+	wait, err = guard.Check(ctx, Attempt{Username: util.RandomOwner(), ClientIP: "10.0.0.2"})
+	require.NoError(t, err)
+	require.Zero(t, wait)
+}
@@ -7,6 +7,8 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog"
@@ -14,14 +16,21 @@ import (
 
 	"github.com/VihangaFTW/Go-Backend/api"
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/fx"
 	"github.com/VihangaFTW/Go-Backend/gapi"
+	"github.com/VihangaFTW/Go-Backend/ledger"
+	"github.com/VihangaFTW/Go-Backend/lockout"
+	"github.com/VihangaFTW/Go-Backend/mail"
 	"github.com/VihangaFTW/Go-Backend/pb"
+	"github.com/VihangaFTW/Go-Backend/ratelimit"
+	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/VihangaFTW/Go-Backend/worker"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -51,6 +60,12 @@ func main() {
 		log.Fatal().Err(err).Msg("cannot connect to db")
 	}
 
+	//* one-off ledger check, e.g. `main verify-ledger`, instead of starting the servers
+	if len(os.Args) > 1 && os.Args[1] == "verify-ledger" {
+		runLedgerVerification(db.NewStore(conn))
+		return
+	}
+
 	runDbMigrations(config.MigrationURL, config.DBSource)
 
 	store := db.NewStore(conn)
@@ -62,17 +77,44 @@ func main() {
 
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
 
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: config.RedisAddress,
+	})
+
+	//* revoked tokens are shared by every server instance
+	denyList := token.NewRedisDenyList(redisClient)
+
+	//* failed logins are counted across every server instance, so spreading guesses over them does not help
+	loginGuard := lockout.NewGuard(lockout.NewRedisCounter(redisClient))
+
+	//* request limits of every user and client, shared by the servers below
+	rateLimiter, err := ratelimit.NewLimiterFromConfig(config, redisClient)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create rate limiter")
+	}
+
 	//* run task processor (blocking server)
-	go runRedisTaskProcessor(redisOpt, store)
+	go runRedisTaskProcessor(config, redisOpt, store, taskDistributor, denyList)
 
-	go runGatewayServer(config, store, taskDistributor)
-	runGrpcServer(config, store, taskDistributor)
+	//* periodic jobs such as standing orders
+	go runRedisTaskScheduler(config, redisOpt)
+
+	//* hand the tasks written to the outbox over to the task processor
+	go runOutboxRelay(config, redisOpt, store)
+
+	//* keep exchange rates fresh for cross-currency transfers
+	if config.FXRatesFile != "" {
+		go runFxRateSync(config, store)
+	}
+
+	go runGatewayServer(config, store, denyList, loginGuard, rateLimiter)
+	runGrpcServer(config, store, denyList, loginGuard, rateLimiter)
 }
 
 // runGinServer starts the HTTP REST API server using the Gin framework.
 // This function is currently not called but can be used as an alternative to gRPC.
-func runGinServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
+func runGinServer(config util.Config, store db.Store, denyList token.DenyList, loginGuard *lockout.Guard, rateLimiter *ratelimit.Limiter) {
+	server, err := api.NewServer(config, store, denyList, loginGuard, rateLimiter)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create server")
 	}
@@ -83,15 +125,18 @@ func runGinServer(config util.Config, store db.Store) {
 	}
 }
 
-func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGrpcServer(config util.Config, store db.Store, denyList token.DenyList, loginGuard *lockout.Guard, rateLimiter *ratelimit.Limiter) {
+	server, err := gapi.NewServer(config, store, denyList, loginGuard, rateLimiter)
 
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create gprc server")
 	}
 
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
-	grpcServer := grpc.NewServer(grpcLogger)
+	//? the logger runs first so that requests rejected by the auth or rate limit interceptors are logged too.
+	//? the rate limit runs after the auth, which tells it the user to count the request against
+	unaryInterceptors := grpc.ChainUnaryInterceptor(gapi.GrpcLogger, server.AuthUnaryInterceptor, server.RateLimitUnaryInterceptor)
+	streamInterceptors := grpc.ChainStreamInterceptor(server.AuthStreamInterceptor, server.RateLimitStreamInterceptor)
+	grpcServer := grpc.NewServer(unaryInterceptors, streamInterceptors)
 
 	pb.RegisterSimpleBankServer(grpcServer, server)
 	reflection.Register(grpcServer)
@@ -110,8 +155,8 @@ func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.Ta
 }
 
 // runGatewayServer starts the HTTP gateway server that translates RESTful HTTP/JSON requests into gRPC requests.
-func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGatewayServer(config util.Config, store db.Store, denyList token.DenyList, loginGuard *lockout.Guard, rateLimiter *ratelimit.Limiter) {
+	server, err := gapi.NewServer(config, store, denyList, loginGuard, rateLimiter)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create gRPC server")
 	}
@@ -125,14 +170,21 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	grpcMux := runtime.NewServeMux(
+		jsonOption,
+		runtime.WithIncomingHeaderMatcher(gapi.IncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(gapi.OutgoingHeaderMatcher),
+	)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
+	//? the gateway calls the server in process but still goes through the auth interceptor
+	client := gapi.NewGatewayClient(server, server.AuthUnaryInterceptor)
+
+	err = pb.RegisterSimpleBankHandlerClient(ctx, grpcMux, client)
 	if err != nil {
-		log.Fatal().Err(err).Msg("cannot register handler server")
+		log.Fatal().Err(err).Msg("cannot register handler client")
 	}
 
 	mux := http.NewServeMux()
@@ -155,8 +207,8 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 
 	log.Info().Msgf("start http gateway at %s", listener.Addr().String())
 
-	//? http logger middleware: wraps the multiplexer with the logger
-	handler := gapi.HttpLogger(mux)
+	//? http logger middleware: wraps the multiplexer with the logger, outside of the rate limiter so rejected requests are logged too
+	handler := gapi.HttpLogger(server.HttpRateLimiter(mux))
 
 	err = http.Serve(listener, handler)
 
@@ -180,8 +232,13 @@ func runDbMigrations(migrationUrl string, dbSource string) {
 	log.Info().Msgf("db migration success!")
 }
 
-func runRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) {
-	redisProcessor := worker.NewRedisTaskProcessor(redisOpt, store)
+func runRedisTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store, taskDistributor worker.TaskDistributor, denyList token.DenyList) {
+	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
+
+	//? the processor revokes tokens but never issues or verifies any
+	tokenRevoker := token.NewDenyListRevoker(denyList, config.RefreshTokenDuration)
+
+	redisProcessor := worker.NewRedisTaskProcessor(redisOpt, store, taskDistributor, mailer, config.VerifyEmailURL, config.PasswordResetURL, splitList(config.LedgerAlertEmails), tokenRevoker)
 
 	log.Info().Msg("start redis task processor")
 
@@ -191,3 +248,78 @@ func runRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) {
 		log.Fatal().Err(err).Msg("failed to start redis task processor")
 	}
 }
+
+func runRedisTaskScheduler(config util.Config, redisOpt asynq.RedisClientOpt) {
+	scheduler := worker.NewRedisTaskScheduler(redisOpt, config.ScheduledTransferPollInterval, config.HoldExpiryInterval, config.LedgerCheckSchedule)
+
+	log.Info().Msg("start redis task scheduler")
+
+	err := scheduler.Start()
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start redis task scheduler")
+	}
+}
+
+// runOutboxRelay relays the background tasks that the servers write to the outbox along with their changes.
+func runOutboxRelay(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store) {
+	relay := worker.NewOutboxRelay(redisOpt, store, config.OutboxPollInterval, config.OutboxRetention)
+
+	log.Info().Msg("start outbox relay")
+
+	err := relay.Start(context.Background())
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to run outbox relay")
+	}
+}
+
+// runLedgerVerification checks the ledger once and prints the report as JSON to stdout.
+// It exits with status 1 when the balances or transfers disagree with the entries, so it can gate scripts and cron jobs.
+func runLedgerVerification(store db.Store) {
+	report, err := ledger.Verify(context.Background(), store)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot verify ledger")
+	}
+
+	if err = ledger.WriteJSON(os.Stdout, report); err != nil {
+		log.Fatal().Err(err).Msg("cannot write ledger report")
+	}
+
+	if !report.OK() {
+		os.Exit(1)
+	}
+}
+
+// splitList splits a comma separated config value, skipping empty items.
+func splitList(value string) []string {
+	var items []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// runFxRateSync loads exchange rates from the configured rate provider into the database and refreshes them periodically.
+func runFxRateSync(config util.Config, store db.Store) {
+	provider := fx.NewFileRateProvider(config.FXRatesFile)
+
+	for {
+		if err := fx.SyncRates(context.Background(), store, provider); err != nil {
+			log.Error().Err(err).Msg("failed to sync fx rates")
+		} else {
+			log.Info().Msg("fx rates synced")
+		}
+
+		//? a zero interval means the rates are only loaded once at startup
+		if config.FXRateRefreshInterval <= 0 {
+			return
+		}
+
+		time.Sleep(config.FXRateRefreshInterval)
+	}
+}
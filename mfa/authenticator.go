@@ -0,0 +1,211 @@
+package mfa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+)
+
+// Errors returned by the Authenticator
+var (
+	ErrNotEnrolled    = errors.New("two-factor authentication has not been set up")
+	ErrAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+)
+
+// Enrollment is what an authenticator app needs to start generating codes.
+type Enrollment struct {
+	Secret string
+	URI    string
+}
+
+// Authenticator manages the second factor of users: enrolling, confirming and disabling it, and checking codes at login.
+// Both the HTTP and the gRPC servers go through it, so the two logins behave the same.
+type Authenticator struct {
+	store  db.Store
+	cipher *Cipher
+	issuer string
+}
+
+// NewAuthenticator creates an Authenticator. The issuer is the name authenticator apps show next to the codes.
+func NewAuthenticator(store db.Store, cipher *Cipher, issuer string) *Authenticator {
+	return &Authenticator{
+		store:  store,
+		cipher: cipher,
+		issuer: issuer,
+	}
+}
+
+// Enroll generates a new secret for the user. Two-factor authentication stays off until Confirm is called with a code from it.
+func (a *Authenticator) Enroll(ctx context.Context, username string) (Enrollment, error) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	encryptedSecret, err := a.cipher.Encrypt(secret, username)
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	_, err = a.store.UpsertUserMFA(ctx, db.UpsertUserMFAParams{
+		Username:        username,
+		EncryptedSecret: encryptedSecret,
+	})
+
+	if err != nil {
+		//? the upsert skips confirmed secrets, which must be disabled before enrolling again
+		if errors.Is(err, sql.ErrNoRows) {
+			return Enrollment{}, ErrAlreadyEnabled
+		}
+		return Enrollment{}, fmt.Errorf("failed to store secret: %w", err)
+	}
+
+	return Enrollment{
+		Secret: secret,
+		URI:    URI(a.issuer, username, secret),
+	}, nil
+}
+
+// Confirm turns on two-factor authentication once the user proves their app generates the right codes.
+// It returns the recovery codes, which are only ever shown this once.
+func (a *Authenticator) Confirm(ctx context.Context, username string, code string) ([]string, error) {
+	userMFA, err := a.getUserMFA(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if userMFA.ConfirmedAt.Valid {
+		return nil, ErrAlreadyEnabled
+	}
+
+	step, err := a.validateCode(userMFA, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, recoveryCode := range recoveryCodes {
+		hashes = append(hashes, HashRecoveryCode(recoveryCode))
+	}
+
+	_, err = a.store.ConfirmMFATx(ctx, db.ConfirmMFATxParams{
+		Username:           username,
+		Step:               step,
+		RecoveryCodeHashes: hashes,
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrMFAAlreadyConfirmed) {
+			return nil, ErrAlreadyEnabled
+		}
+		return nil, fmt.Errorf("failed to confirm two-factor authentication: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// Enabled reports whether the user has to enter a code when logging in.
+func (a *Authenticator) Enabled(ctx context.Context, username string) (bool, error) {
+	userMFA, err := a.getUserMFA(ctx, username)
+	if err != nil {
+		if errors.Is(err, ErrNotEnrolled) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return userMFA.ConfirmedAt.Valid, nil
+}
+
+// Verify checks a one-time password or a recovery code of a user with two-factor authentication enabled.
+// Each code is accepted once.
+func (a *Authenticator) Verify(ctx context.Context, username string, code string) error {
+	userMFA, err := a.getUserMFA(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if !userMFA.ConfirmedAt.Valid {
+		return ErrNotEnrolled
+	}
+
+	if IsRecoveryCode(code) {
+		_, err = a.store.UseMFARecoveryCode(ctx, db.UseMFARecoveryCodeParams{
+			Username: username,
+			CodeHash: HashRecoveryCode(code),
+		})
+
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidCode
+			}
+			return fmt.Errorf("failed to use recovery code: %w", err)
+		}
+
+		return nil
+	}
+
+	step, err := a.validateCode(userMFA, code)
+	if err != nil {
+		return err
+	}
+
+	//! a code seen before, e.g. by someone looking over the user's shoulder, must not work again
+	_, err = a.store.UseMFAStep(ctx, db.UseMFAStepParams{
+		Username: username,
+		Step:     step,
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidCode
+		}
+		return fmt.Errorf("failed to use one-time password: %w", err)
+	}
+
+	return nil
+}
+
+// Disable turns off two-factor authentication. It asks for a code, so a stolen access token alone cannot turn it off.
+// The change is recorded in the audit log with the given context.
+func (a *Authenticator) Disable(ctx context.Context, username string, code string, audit db.AuditContext) error {
+	if err := a.Verify(ctx, username, code); err != nil {
+		return err
+	}
+
+	if err := a.store.DisableMFATx(ctx, db.DisableMFATxParams{Username: username, Audit: audit}); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Authenticator) getUserMFA(ctx context.Context, username string) (db.UserMfa, error) {
+	userMFA, err := a.store.GetUserMFA(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.UserMfa{}, ErrNotEnrolled
+		}
+		return db.UserMfa{}, fmt.Errorf("failed to get two-factor settings: %w", err)
+	}
+
+	return userMFA, nil
+}
+
+func (a *Authenticator) validateCode(userMFA db.UserMfa, code string) (int64, error) {
+	secret, err := a.cipher.Decrypt(userMFA.EncryptedSecret, userMFA.Username)
+	if err != nil {
+		return 0, err
+	}
+
+	return ValidateCode(secret, code, time.Now())
+}
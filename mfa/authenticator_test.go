@@ -0,0 +1,114 @@
+package mfa
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestAuthenticator(t *testing.T, store db.Store) *Authenticator {
+	cipher, err := NewCipher(testingHexKey)
+	require.NoError(t, err)
+
+	return NewAuthenticator(store, cipher, "Simple Bank")
+}
+
+func TestEnrollAndConfirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	authenticator := newTestAuthenticator(t, store)
+	ctx := context.Background()
+
+	var pending db.UserMfa
+
+	store.EXPECT().UpsertUserMFA(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpsertUserMFAParams) (db.UserMfa, error) {
+			pending = db.UserMfa{Username: arg.Username, EncryptedSecret: arg.EncryptedSecret}
+			return pending, nil
+		})
+
+	enrollment, err := authenticator.Enroll(ctx, "alice")
+	require.NoError(t, err)
+	require.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+
+	//! the secret is never stored in the clear
+	require.NotContains(t, string(pending.EncryptedSecret), enrollment.Secret)
+
+	store.EXPECT().GetUserMFA(gomock.Any(), "alice").AnyTimes().DoAndReturn(func(context.Context, string) (db.UserMfa, error) {
+		return pending, nil
+	})
+
+	//? not enabled until confirmed
+	enabled, err := authenticator.Enabled(ctx, "alice")
+	require.NoError(t, err)
+	require.False(t, enabled)
+
+	_, err = authenticator.Confirm(ctx, "alice", "000000")
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	code, err := GenerateCode(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+
+	store.EXPECT().ConfirmMFATx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ConfirmMFATxParams) (db.ConfirmMFATxResult, error) {
+			require.Equal(t, Step(time.Now()), arg.Step)
+			require.Len(t, arg.RecoveryCodeHashes, RecoveryCodeCount)
+
+			pending.ConfirmedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			return db.ConfirmMFATxResult{UserMFA: pending}, nil
+		})
+
+	recoveryCodes, err := authenticator.Confirm(ctx, "alice", code)
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes, RecoveryCodeCount)
+
+	enabled, err = authenticator.Enabled(ctx, "alice")
+	require.NoError(t, err)
+	require.True(t, enabled)
+
+	_, err = authenticator.Confirm(ctx, "alice", code)
+	require.ErrorIs(t, err, ErrAlreadyEnabled)
+
+	//? a recovery code is looked up by its hash
+	store.EXPECT().UseMFARecoveryCode(gomock.Any(), db.UseMFARecoveryCodeParams{
+		Username: "alice",
+		CodeHash: HashRecoveryCode(recoveryCodes[0]),
+	}).Times(1).Return(db.MfaRecoveryCode{}, nil)
+	audit := db.AuditContext{Actor: "alice", ClientIP: "10.0.0.1"}
+	store.EXPECT().DisableMFATx(gomock.Any(), db.DisableMFATxParams{Username: "alice", Audit: audit}).Times(1).Return(nil)
+
+	require.NoError(t, authenticator.Disable(ctx, "alice", recoveryCodes[0], audit))
+}
+
+func TestEnrollAlreadyEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	authenticator := newTestAuthenticator(t, store)
+
+	//? the upsert skips a confirmed secret
+	store.EXPECT().UpsertUserMFA(gomock.Any(), gomock.Any()).Times(1).Return(db.UserMfa{}, sql.ErrNoRows)
+
+	_, err := authenticator.Enroll(context.Background(), "alice")
+	require.ErrorIs(t, err, ErrAlreadyEnabled)
+}
+
+func TestVerifyNotEnrolled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	authenticator := newTestAuthenticator(t, store)
+
+	store.EXPECT().GetUserMFA(gomock.Any(), "alice").Times(2).Return(db.UserMfa{}, sql.ErrNoRows)
+
+	enabled, err := authenticator.Enabled(context.Background(), "alice")
+	require.NoError(t, err)
+	require.False(t, enabled)
+
+	err = authenticator.Verify(context.Background(), "alice", "123456")
+	require.ErrorIs(t, err, ErrNotEnrolled)
+}
@@ -0,0 +1,70 @@
+package mfa
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the Cipher
+var (
+	ErrInvalidEncryptionKey = errors.New("invalid mfa encryption key: must be 64 hex characters (32 bytes)")
+	ErrMalformedCiphertext  = errors.New("malformed mfa ciphertext")
+)
+
+// Cipher encrypts TOTP secrets before they are stored, so a database dump alone cannot generate codes.
+// It uses AES-256-GCM and prepends the random nonce to the ciphertext.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher creates a Cipher from a hex encoded 32 byte key.
+func NewCipher(hexKey string) (*Cipher, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil || len(key) != 32 {
+		return nil, ErrInvalidEncryptionKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create block cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create gcm: %w", err)
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt seals the secret. The username is bound as additional data,
+// so a secret copied onto another user's row does not decrypt.
+func (c *Cipher) Encrypt(secret string, username string) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return c.aead.Seal(nonce, nonce, []byte(secret), []byte(username)), nil
+}
+
+// Decrypt opens a secret sealed by Encrypt for the same user.
+func (c *Cipher) Decrypt(ciphertext []byte, username string) (string, error) {
+	nonceSize := c.aead.NonceSize()
+
+	if len(ciphertext) < nonceSize {
+		return "", ErrMalformedCiphertext
+	}
+
+	secret, err := c.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], []byte(username))
+	if err != nil {
+		return "", ErrMalformedCiphertext
+	}
+
+	return string(secret), nil
+}
@@ -0,0 +1,32 @@
+package mfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testingHexKey = "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+
+func TestCipher(t *testing.T) {
+	c, err := NewCipher(testingHexKey)
+	require.NoError(t, err)
+
+	ciphertext, err := c.Encrypt(rfcSecret, "alice")
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext), rfcSecret)
+
+	secret, err := c.Decrypt(ciphertext, "alice")
+	require.NoError(t, err)
+	require.Equal(t, rfcSecret, secret)
+
+	//! a secret moved onto another user's row must not decrypt
+	_, err = c.Decrypt(ciphertext, "mallory")
+	require.ErrorIs(t, err, ErrMalformedCiphertext)
+
+	_, err = c.Decrypt(ciphertext[:4], "alice")
+	require.ErrorIs(t, err, ErrMalformedCiphertext)
+
+	_, err = NewCipher("abcd")
+	require.ErrorIs(t, err, ErrInvalidEncryptionKey)
+}
@@ -0,0 +1,53 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// RecoveryCodeCount is the number of recovery codes issued when two-factor authentication is enabled
+const RecoveryCodeCount = 10
+
+// recoveryCodeSize is the number of random bytes of a code, 80 bits written as 16 base32 characters
+const recoveryCodeSize = 10
+
+// GenerateRecoveryCodes returns n random single use codes, formatted as xxxx-xxxx-xxxx-xxxx for readability.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+
+	for range n {
+		buf := make([]byte, recoveryCodeSize)
+
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		encoded := strings.ToLower(base32NoPadding.EncodeToString(buf))
+		codes = append(codes, encoded[0:4]+"-"+encoded[4:8]+"-"+encoded[8:12]+"-"+encoded[12:16])
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash a recovery code is stored and looked up by.
+// The codes are random enough that a fast hash is safe, unlike passwords.
+// Case, spaces and dashes are ignored, so a code can be typed the way it reads.
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsRecoveryCode tells recovery codes apart from one-time passwords, which are much shorter.
+func IsRecoveryCode(code string) bool {
+	return len(strings.TrimSpace(code)) > Digits
+}
@@ -0,0 +1,28 @@
+package mfa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	for _, code := range codes {
+		require.Len(t, code, 19)
+		require.True(t, IsRecoveryCode(code))
+	}
+
+	require.NotEqual(t, codes[0], codes[1])
+
+	//? codes can be typed without dashes and in any case
+	code := codes[0]
+	require.Equal(t, HashRecoveryCode(code), HashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(code, "-", ""))+" "))
+	require.NotEqual(t, HashRecoveryCode(codes[0]), HashRecoveryCode(codes[1]))
+
+	require.False(t, IsRecoveryCode("123456"))
+}
@@ -0,0 +1,76 @@
+package mfa
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 secret of the RFC 6238 test vectors, "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode(t *testing.T) {
+	//? the RFC lists 8 digit codes, these are their last 6 digits
+	testcases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range testcases {
+		code, err := GenerateCode(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, expected, code)
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	now := time.Now()
+
+	code, err := GenerateCode(secret, now)
+	require.NoError(t, err)
+
+	step, err := ValidateCode(secret, code, now)
+	require.NoError(t, err)
+	require.Equal(t, Step(now), step)
+
+	//? a code from the previous period is still accepted for clock drift
+	previous, err := GenerateCode(secret, now.Add(-Period))
+	require.NoError(t, err)
+
+	step, err = ValidateCode(secret, previous, now)
+	require.NoError(t, err)
+	require.Equal(t, Step(now)-1, step)
+
+	old, err := GenerateCode(secret, now.Add(-3*Period))
+	require.NoError(t, err)
+
+	_, err = ValidateCode(secret, old, now)
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	_, err = ValidateCode(secret, "12345", now)
+	require.ErrorIs(t, err, ErrInvalidCode)
+
+	_, err = ValidateCode("not base32!", code, now)
+	require.Error(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("Simple Bank", "alice", rfcSecret))
+	require.NoError(t, err)
+
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/Simple Bank:alice", uri.Path)
+	require.Equal(t, rfcSecret, uri.Query().Get("secret"))
+	require.Equal(t, "Simple Bank", uri.Query().Get("issuer"))
+	require.Equal(t, "6", uri.Query().Get("digits"))
+	require.Equal(t, "30", uri.Query().Get("period"))
+}
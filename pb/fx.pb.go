@@ -0,0 +1,278 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.6
+// source: fx.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Rates are fixed point numbers scaled by 10^8, so 1.25 is sent as 125000000.
+type FxQuote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromCurrency  string                 `protobuf:"bytes,2,opt,name=from_currency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,3,opt,name=to_currency,proto3" json:"to_currency,omitempty"`
+	Rate          int64                  `protobuf:"varint,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	SpreadBps     int64                  `protobuf:"varint,5,opt,name=spread_bps,proto3" json:"spread_bps,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FxQuote) Reset() {
+	*x = FxQuote{}
+	mi := &file_fx_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FxQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FxQuote) ProtoMessage() {}
+
+func (x *FxQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FxQuote.ProtoReflect.Descriptor instead.
+func (*FxQuote) Descriptor() ([]byte, []int) {
+	return file_fx_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FxQuote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FxQuote) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *FxQuote) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *FxQuote) GetRate() int64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *FxQuote) GetSpreadBps() int64 {
+	if x != nil {
+		return x.SpreadBps
+	}
+	return 0
+}
+
+func (x *FxQuote) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type FxConversion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,proto3" json:"quote_id,omitempty"`
+	FromCurrency  string                 `protobuf:"bytes,2,opt,name=from_currency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,3,opt,name=to_currency,proto3" json:"to_currency,omitempty"`
+	FromAmount    int64                  `protobuf:"varint,4,opt,name=from_amount,proto3" json:"from_amount,omitempty"`
+	ToAmount      int64                  `protobuf:"varint,5,opt,name=to_amount,proto3" json:"to_amount,omitempty"`
+	Rate          int64                  `protobuf:"varint,6,opt,name=rate,proto3" json:"rate,omitempty"`
+	SpreadBps     int64                  `protobuf:"varint,7,opt,name=spread_bps,proto3" json:"spread_bps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FxConversion) Reset() {
+	*x = FxConversion{}
+	mi := &file_fx_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FxConversion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FxConversion) ProtoMessage() {}
+
+func (x *FxConversion) ProtoReflect() protoreflect.Message {
+	mi := &file_fx_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FxConversion.ProtoReflect.Descriptor instead.
+func (*FxConversion) Descriptor() ([]byte, []int) {
+	return file_fx_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FxConversion) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *FxConversion) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *FxConversion) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *FxConversion) GetFromAmount() int64 {
+	if x != nil {
+		return x.FromAmount
+	}
+	return 0
+}
+
+func (x *FxConversion) GetToAmount() int64 {
+	if x != nil {
+		return x.ToAmount
+	}
+	return 0
+}
+
+func (x *FxConversion) GetRate() int64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *FxConversion) GetSpreadBps() int64 {
+	if x != nil {
+		return x.SpreadBps
+	}
+	return 0
+}
+
+var File_fx_proto protoreflect.FileDescriptor
+
+const file_fx_proto_rawDesc = "" +
+	"\n" +
+	"\bfx.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd1\x01\n" +
+	"\aFxQuote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\rfrom_currency\x12 \n" +
+	"\vto_currency\x18\x03 \x01(\tR\vto_currency\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\x03R\x04rate\x12\x1e\n" +
+	"\n" +
+	"spread_bps\x18\x05 \x01(\x03R\n" +
+	"spread_bps\x12:\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expires_at\"\xe6\x01\n" +
+	"\fFxConversion\x12\x1a\n" +
+	"\bquote_id\x18\x01 \x01(\tR\bquote_id\x12$\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\rfrom_currency\x12 \n" +
+	"\vto_currency\x18\x03 \x01(\tR\vto_currency\x12 \n" +
+	"\vfrom_amount\x18\x04 \x01(\x03R\vfrom_amount\x12\x1c\n" +
+	"\tto_amount\x18\x05 \x01(\x03R\tto_amount\x12\x12\n" +
+	"\x04rate\x18\x06 \x01(\x03R\x04rate\x12\x1e\n" +
+	"\n" +
+	"spread_bps\x18\a \x01(\x03R\n" +
+	"spread_bpsB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
+
+var (
+	file_fx_proto_rawDescOnce sync.Once
+	file_fx_proto_rawDescData []byte
+)
+
+func file_fx_proto_rawDescGZIP() []byte {
+	file_fx_proto_rawDescOnce.Do(func() {
+		file_fx_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fx_proto_rawDesc), len(file_fx_proto_rawDesc)))
+	})
+	return file_fx_proto_rawDescData
+}
+
+var file_fx_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_fx_proto_goTypes = []any{
+	(*FxQuote)(nil),               // 0: pb.FxQuote
+	(*FxConversion)(nil),          // 1: pb.FxConversion
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_fx_proto_depIdxs = []int32{
+	2, // 0: pb.FxQuote.expires_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_fx_proto_init() }
+func file_fx_proto_init() {
+	if File_fx_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fx_proto_rawDesc), len(file_fx_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_fx_proto_goTypes,
+		DependencyIndexes: file_fx_proto_depIdxs,
+		MessageInfos:      file_fx_proto_msgTypes,
+	}.Build()
+	File_fx_proto = out.File
+	file_fx_proto_goTypes = nil
+	file_fx_proto_depIdxs = nil
+}
@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.6
+// source: rpc_create_fx_quote.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateFxQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,proto3" json:"to_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFxQuoteRequest) Reset() {
+	*x = CreateFxQuoteRequest{}
+	mi := &file_rpc_create_fx_quote_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFxQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFxQuoteRequest) ProtoMessage() {}
+
+func (x *CreateFxQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_fx_quote_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFxQuoteRequest.ProtoReflect.Descriptor instead.
+func (*CreateFxQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_fx_quote_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateFxQuoteRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *CreateFxQuoteRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+type CreateFxQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *FxQuote               `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFxQuoteResponse) Reset() {
+	*x = CreateFxQuoteResponse{}
+	mi := &file_rpc_create_fx_quote_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFxQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFxQuoteResponse) ProtoMessage() {}
+
+func (x *CreateFxQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_fx_quote_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFxQuoteResponse.ProtoReflect.Descriptor instead.
+func (*CreateFxQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_create_fx_quote_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateFxQuoteResponse) GetQuote() *FxQuote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+var File_rpc_create_fx_quote_proto protoreflect.FileDescriptor
+
+const file_rpc_create_fx_quote_proto_rawDesc = "" +
+	"\n" +
+	"\x19rpc_create_fx_quote.proto\x12\x02pb\x1a\bfx.proto\"^\n" +
+	"\x14CreateFxQuoteRequest\x12$\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\rfrom_currency\x12 \n" +
+	"\vto_currency\x18\x02 \x01(\tR\vto_currency\":\n" +
+	"\x15CreateFxQuoteResponse\x12!\n" +
+	"\x05quote\x18\x01 \x01(\v2\v.pb.FxQuoteR\x05quoteB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
+
+var (
+	file_rpc_create_fx_quote_proto_rawDescOnce sync.Once
+	file_rpc_create_fx_quote_proto_rawDescData []byte
+)
+
+func file_rpc_create_fx_quote_proto_rawDescGZIP() []byte {
+	file_rpc_create_fx_quote_proto_rawDescOnce.Do(func() {
+		file_rpc_create_fx_quote_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_create_fx_quote_proto_rawDesc), len(file_rpc_create_fx_quote_proto_rawDesc)))
+	})
+	return file_rpc_create_fx_quote_proto_rawDescData
+}
+
+var file_rpc_create_fx_quote_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_create_fx_quote_proto_goTypes = []any{
+	(*CreateFxQuoteRequest)(nil),  // 0: pb.CreateFxQuoteRequest
+	(*CreateFxQuoteResponse)(nil), // 1: pb.CreateFxQuoteResponse
+	(*FxQuote)(nil),               // 2: pb.FxQuote
+}
+var file_rpc_create_fx_quote_proto_depIdxs = []int32{
+	2, // 0: pb.CreateFxQuoteResponse.quote:type_name -> pb.FxQuote
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_create_fx_quote_proto_init() }
+func file_rpc_create_fx_quote_proto_init() {
+	if File_rpc_create_fx_quote_proto != nil {
+		return
+	}
+	file_fx_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_create_fx_quote_proto_rawDesc), len(file_rpc_create_fx_quote_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_create_fx_quote_proto_goTypes,
+		DependencyIndexes: file_rpc_create_fx_quote_proto_depIdxs,
+		MessageInfos:      file_rpc_create_fx_quote_proto_msgTypes,
+	}.Build()
+	File_rpc_create_fx_quote_proto = out.File
+	file_rpc_create_fx_quote_proto_goTypes = nil
+	file_rpc_create_fx_quote_proto_depIdxs = nil
+}
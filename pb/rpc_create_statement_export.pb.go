@@ -0,0 +1,206 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.6
+// source: rpc_create_statement_export.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateStatementExportRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,proto3" json:"account_id,omitempty"`
+	FromTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from_time,proto3" json:"from_time,omitempty"`
+	ToTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to_time,proto3" json:"to_time,omitempty"`
+	// csv or ofx
+	Format        string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStatementExportRequest) Reset() {
+	*x = CreateStatementExportRequest{}
+	mi := &file_rpc_create_statement_export_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStatementExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStatementExportRequest) ProtoMessage() {}
+
+func (x *CreateStatementExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_statement_export_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStatementExportRequest.ProtoReflect.Descriptor instead.
+func (*CreateStatementExportRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_statement_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateStatementExportRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CreateStatementExportRequest) GetFromTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromTime
+	}
+	return nil
+}
+
+func (x *CreateStatementExportRequest) GetToTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToTime
+	}
+	return nil
+}
+
+func (x *CreateStatementExportRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type CreateStatementExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *StatementExport       `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStatementExportResponse) Reset() {
+	*x = CreateStatementExportResponse{}
+	mi := &file_rpc_create_statement_export_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStatementExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStatementExportResponse) ProtoMessage() {}
+
+func (x *CreateStatementExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_statement_export_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStatementExportResponse.ProtoReflect.Descriptor instead.
+func (*CreateStatementExportResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_create_statement_export_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateStatementExportResponse) GetExport() *StatementExport {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+var File_rpc_create_statement_export_proto protoreflect.FileDescriptor
+
+const file_rpc_create_statement_export_proto_rawDesc = "" +
+	"\n" +
+	"!rpc_create_statement_export.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0fstatement.proto\"\xc6\x01\n" +
+	"\x1cCreateStatementExportRequest\x12\x1e\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\n" +
+	"account_id\x128\n" +
+	"\tfrom_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tfrom_time\x124\n" +
+	"\ato_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ato_time\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"L\n" +
+	"\x1dCreateStatementExportResponse\x12+\n" +
+	"\x06export\x18\x01 \x01(\v2\x13.pb.StatementExportR\x06exportB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
+
+var (
+	file_rpc_create_statement_export_proto_rawDescOnce sync.Once
+	file_rpc_create_statement_export_proto_rawDescData []byte
+)
+
+func file_rpc_create_statement_export_proto_rawDescGZIP() []byte {
+	file_rpc_create_statement_export_proto_rawDescOnce.Do(func() {
+		file_rpc_create_statement_export_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_create_statement_export_proto_rawDesc), len(file_rpc_create_statement_export_proto_rawDesc)))
+	})
+	return file_rpc_create_statement_export_proto_rawDescData
+}
+
+var file_rpc_create_statement_export_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_create_statement_export_proto_goTypes = []any{
+	(*CreateStatementExportRequest)(nil),  // 0: pb.CreateStatementExportRequest
+	(*CreateStatementExportResponse)(nil), // 1: pb.CreateStatementExportResponse
+	(*timestamppb.Timestamp)(nil),         // 2: google.protobuf.Timestamp
+	(*StatementExport)(nil),               // 3: pb.StatementExport
+}
+var file_rpc_create_statement_export_proto_depIdxs = []int32{
+	2, // 0: pb.CreateStatementExportRequest.from_time:type_name -> google.protobuf.Timestamp
+	2, // 1: pb.CreateStatementExportRequest.to_time:type_name -> google.protobuf.Timestamp
+	3, // 2: pb.CreateStatementExportResponse.export:type_name -> pb.StatementExport
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rpc_create_statement_export_proto_init() }
+func file_rpc_create_statement_export_proto_init() {
+	if File_rpc_create_statement_export_proto != nil {
+		return
+	}
+	file_statement_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_create_statement_export_proto_rawDesc), len(file_rpc_create_statement_export_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_create_statement_export_proto_goTypes,
+		DependencyIndexes: file_rpc_create_statement_export_proto_depIdxs,
+		MessageInfos:      file_rpc_create_statement_export_proto_msgTypes,
+	}.Build()
+	File_rpc_create_statement_export_proto = out.File
+	file_rpc_create_statement_export_proto_goTypes = nil
+	file_rpc_create_statement_export_proto_depIdxs = nil
+}
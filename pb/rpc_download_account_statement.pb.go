@@ -0,0 +1,156 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.6
+// source: rpc_download_account_statement.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DownloadAccountStatementRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId int64                  `protobuf:"varint,1,opt,name=account_id,proto3" json:"account_id,omitempty"`
+	FromTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from_time,proto3" json:"from_time,omitempty"`
+	ToTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to_time,proto3" json:"to_time,omitempty"`
+	// csv or ofx
+	Format        string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAccountStatementRequest) Reset() {
+	*x = DownloadAccountStatementRequest{}
+	mi := &file_rpc_download_account_statement_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAccountStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAccountStatementRequest) ProtoMessage() {}
+
+func (x *DownloadAccountStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_download_account_statement_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAccountStatementRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAccountStatementRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_download_account_statement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DownloadAccountStatementRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *DownloadAccountStatementRequest) GetFromTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromTime
+	}
+	return nil
+}
+
+func (x *DownloadAccountStatementRequest) GetToTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToTime
+	}
+	return nil
+}
+
+func (x *DownloadAccountStatementRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+var File_rpc_download_account_statement_proto protoreflect.FileDescriptor
+
+const file_rpc_download_account_statement_proto_rawDesc = "" +
+	"\n" +
+	"$rpc_download_account_statement.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc9\x01\n" +
+	"\x1fDownloadAccountStatementRequest\x12\x1e\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\n" +
+	"account_id\x128\n" +
+	"\tfrom_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tfrom_time\x124\n" +
+	"\ato_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ato_time\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06formatB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
+
+var (
+	file_rpc_download_account_statement_proto_rawDescOnce sync.Once
+	file_rpc_download_account_statement_proto_rawDescData []byte
+)
+
+func file_rpc_download_account_statement_proto_rawDescGZIP() []byte {
+	file_rpc_download_account_statement_proto_rawDescOnce.Do(func() {
+		file_rpc_download_account_statement_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_download_account_statement_proto_rawDesc), len(file_rpc_download_account_statement_proto_rawDesc)))
+	})
+	return file_rpc_download_account_statement_proto_rawDescData
+}
+
+var file_rpc_download_account_statement_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rpc_download_account_statement_proto_goTypes = []any{
+	(*DownloadAccountStatementRequest)(nil), // 0: pb.DownloadAccountStatementRequest
+	(*timestamppb.Timestamp)(nil),           // 1: google.protobuf.Timestamp
+}
+var file_rpc_download_account_statement_proto_depIdxs = []int32{
+	1, // 0: pb.DownloadAccountStatementRequest.from_time:type_name -> google.protobuf.Timestamp
+	1, // 1: pb.DownloadAccountStatementRequest.to_time:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_download_account_statement_proto_init() }
+func file_rpc_download_account_statement_proto_init() {
+	if File_rpc_download_account_statement_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_download_account_statement_proto_rawDesc), len(file_rpc_download_account_statement_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_download_account_statement_proto_goTypes,
+		DependencyIndexes: file_rpc_download_account_statement_proto_depIdxs,
+		MessageInfos:      file_rpc_download_account_statement_proto_msgTypes,
+	}.Build()
+	File_rpc_download_account_statement_proto = out.File
+	file_rpc_download_account_statement_proto_goTypes = nil
+	file_rpc_download_account_statement_proto_depIdxs = nil
+}
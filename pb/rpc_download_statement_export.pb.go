@@ -0,0 +1,122 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.6
+// source: rpc_download_statement_export.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DownloadStatementExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadStatementExportRequest) Reset() {
+	*x = DownloadStatementExportRequest{}
+	mi := &file_rpc_download_statement_export_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadStatementExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadStatementExportRequest) ProtoMessage() {}
+
+func (x *DownloadStatementExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_download_statement_export_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadStatementExportRequest.ProtoReflect.Descriptor instead.
+func (*DownloadStatementExportRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_download_statement_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DownloadStatementExportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_rpc_download_statement_export_proto protoreflect.FileDescriptor
+
+const file_rpc_download_statement_export_proto_rawDesc = "" +
+	"\n" +
+	"#rpc_download_statement_export.proto\x12\x02pb\"0\n" +
+	"\x1eDownloadStatementExportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02idB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
+
+var (
+	file_rpc_download_statement_export_proto_rawDescOnce sync.Once
+	file_rpc_download_statement_export_proto_rawDescData []byte
+)
+
+func file_rpc_download_statement_export_proto_rawDescGZIP() []byte {
+	file_rpc_download_statement_export_proto_rawDescOnce.Do(func() {
+		file_rpc_download_statement_export_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_download_statement_export_proto_rawDesc), len(file_rpc_download_statement_export_proto_rawDesc)))
+	})
+	return file_rpc_download_statement_export_proto_rawDescData
+}
+
+var file_rpc_download_statement_export_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rpc_download_statement_export_proto_goTypes = []any{
+	(*DownloadStatementExportRequest)(nil), // 0: pb.DownloadStatementExportRequest
+}
+var file_rpc_download_statement_export_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_download_statement_export_proto_init() }
+func file_rpc_download_statement_export_proto_init() {
+	if File_rpc_download_statement_export_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_download_statement_export_proto_rawDesc), len(file_rpc_download_statement_export_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_download_statement_export_proto_goTypes,
+		DependencyIndexes: file_rpc_download_statement_export_proto_depIdxs,
+		MessageInfos:      file_rpc_download_statement_export_proto_msgTypes,
+	}.Build()
+	File_rpc_download_statement_export_proto = out.File
+	file_rpc_download_statement_export_proto_goTypes = nil
+	file_rpc_download_statement_export_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.6
+// source: rpc_list_token_keys.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListTokenKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokenKeysRequest) Reset() {
+	*x = ListTokenKeysRequest{}
+	mi := &file_rpc_list_token_keys_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokenKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokenKeysRequest) ProtoMessage() {}
+
+func (x *ListTokenKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_token_keys_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokenKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListTokenKeysRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_token_keys_proto_rawDescGZIP(), []int{0}
+}
+
+type ListTokenKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*TokenKey            `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokenKeysResponse) Reset() {
+	*x = ListTokenKeysResponse{}
+	mi := &file_rpc_list_token_keys_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokenKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokenKeysResponse) ProtoMessage() {}
+
+func (x *ListTokenKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_token_keys_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokenKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListTokenKeysResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_token_keys_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListTokenKeysResponse) GetKeys() []*TokenKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+var File_rpc_list_token_keys_proto protoreflect.FileDescriptor
+
+const file_rpc_list_token_keys_proto_rawDesc = "" +
+	"\n" +
+	"\x19rpc_list_token_keys.proto\x12\x02pb\x1a\x0ftoken_key.proto\"\x16\n" +
+	"\x14ListTokenKeysRequest\"9\n" +
+	"\x15ListTokenKeysResponse\x12 \n" +
+	"\x04keys\x18\x01 \x03(\v2\f.pb.TokenKeyR\x04keysB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
+
+var (
+	file_rpc_list_token_keys_proto_rawDescOnce sync.Once
+	file_rpc_list_token_keys_proto_rawDescData []byte
+)
+
+func file_rpc_list_token_keys_proto_rawDescGZIP() []byte {
+	file_rpc_list_token_keys_proto_rawDescOnce.Do(func() {
+		file_rpc_list_token_keys_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_list_token_keys_proto_rawDesc), len(file_rpc_list_token_keys_proto_rawDesc)))
+	})
+	return file_rpc_list_token_keys_proto_rawDescData
+}
+
+var file_rpc_list_token_keys_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_token_keys_proto_goTypes = []any{
+	(*ListTokenKeysRequest)(nil),  // 0: pb.ListTokenKeysRequest
+	(*ListTokenKeysResponse)(nil), // 1: pb.ListTokenKeysResponse
+	(*TokenKey)(nil),              // 2: pb.TokenKey
+}
+var file_rpc_list_token_keys_proto_depIdxs = []int32{
+	2, // 0: pb.ListTokenKeysResponse.keys:type_name -> pb.TokenKey
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_token_keys_proto_init() }
+func file_rpc_list_token_keys_proto_init() {
+	if File_rpc_list_token_keys_proto != nil {
+		return
+	}
+	file_token_key_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_list_token_keys_proto_rawDesc), len(file_rpc_list_token_keys_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_token_keys_proto_goTypes,
+		DependencyIndexes: file_rpc_list_token_keys_proto_depIdxs,
+		MessageInfos:      file_rpc_list_token_keys_proto_msgTypes,
+	}.Build()
+	File_rpc_list_token_keys_proto = out.File
+	file_rpc_list_token_keys_proto_goTypes = nil
+	file_rpc_list_token_keys_proto_depIdxs = nil
+}
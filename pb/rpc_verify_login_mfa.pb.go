@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.6
+// source: rpc_verify_login_mfa.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VerifyLoginMFARequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MfaToken string                 `protobuf:"bytes,1,opt,name=mfa_token,proto3" json:"mfa_token,omitempty"`
+	// code is a one-time password from the authenticator app or a recovery code
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLoginMFARequest) Reset() {
+	*x = VerifyLoginMFARequest{}
+	mi := &file_rpc_verify_login_mfa_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLoginMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLoginMFARequest) ProtoMessage() {}
+
+func (x *VerifyLoginMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_verify_login_mfa_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLoginMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyLoginMFARequest) Descriptor() ([]byte, []int) {
+	return file_rpc_verify_login_mfa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VerifyLoginMFARequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *VerifyLoginMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+var File_rpc_verify_login_mfa_proto protoreflect.FileDescriptor
+
+const file_rpc_verify_login_mfa_proto_rawDesc = "" +
+	"\n" +
+	"\x1arpc_verify_login_mfa.proto\x12\x02pb\"I\n" +
+	"\x15VerifyLoginMFARequest\x12\x1c\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\tmfa_token\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04codeB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
+
+var (
+	file_rpc_verify_login_mfa_proto_rawDescOnce sync.Once
+	file_rpc_verify_login_mfa_proto_rawDescData []byte
+)
+
+func file_rpc_verify_login_mfa_proto_rawDescGZIP() []byte {
+	file_rpc_verify_login_mfa_proto_rawDescOnce.Do(func() {
+		file_rpc_verify_login_mfa_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_verify_login_mfa_proto_rawDesc), len(file_rpc_verify_login_mfa_proto_rawDesc)))
+	})
+	return file_rpc_verify_login_mfa_proto_rawDescData
+}
+
+var file_rpc_verify_login_mfa_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rpc_verify_login_mfa_proto_goTypes = []any{
+	(*VerifyLoginMFARequest)(nil), // 0: pb.VerifyLoginMFARequest
+}
+var file_rpc_verify_login_mfa_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_verify_login_mfa_proto_init() }
+func file_rpc_verify_login_mfa_proto_init() {
+	if File_rpc_verify_login_mfa_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_verify_login_mfa_proto_rawDesc), len(file_rpc_verify_login_mfa_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_verify_login_mfa_proto_goTypes,
+		DependencyIndexes: file_rpc_verify_login_mfa_proto_depIdxs,
+		MessageInfos:      file_rpc_verify_login_mfa_proto_msgTypes,
+	}.Build()
+	File_rpc_verify_login_mfa_proto = out.File
+	file_rpc_verify_login_mfa_proto_goTypes = nil
+	file_rpc_verify_login_mfa_proto_depIdxs = nil
+}
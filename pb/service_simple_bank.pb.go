@@ -9,6 +9,7 @@ package pb
 import (
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -26,7 +27,7 @@ var File_service_simple_bank_proto protoreflect.FileDescriptor
 
 const file_service_simple_bank_proto_rawDesc = "" +
 	"\n" +
-	"\x19service_simple_bank.proto\x12\x02pb\x1a\x15rpc_create_user.proto\x1a\x14rpc_login_user.proto\x1a\x15rpc_update_user.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x83\x04\n" +
+	"\x19service_simple_bank.proto\x12\x02pb\x1a\x15rpc_create_user.proto\x1a\x14rpc_login_user.proto\x1a\x15rpc_update_user.proto\x1a\x18rpc_create_account.proto\x1a\x15rpc_get_account.proto\x1a\x17rpc_list_accounts.proto\x1a\x19rpc_create_transfer.proto\x1a\x1arpc_reverse_transfer.proto\x1a\x14rpc_place_hold.proto\x1a\x16rpc_capture_hold.proto\x1a\x16rpc_release_hold.proto\x1a\x12rpc_get_hold.proto\x1a\x19rpc_create_fx_quote.proto\x1a#rpc_create_scheduled_transfer.proto\x1a rpc_get_scheduled_transfer.proto\x1a\"rpc_list_scheduled_transfers.proto\x1a#rpc_update_scheduled_transfer.proto\x1a#rpc_delete_scheduled_transfer.proto\x1a\x1frpc_get_account_statement.proto\x1a$rpc_download_account_statement.proto\x1a!rpc_create_statement_export.proto\x1a\x1erpc_get_statement_export.proto\x1a#rpc_download_statement_export.proto\x1a\x16rpc_verify_email.proto\x1a\x17rpc_list_sessions.proto\x1a\x18rpc_revoke_session.proto\x1a\x1frpc_revoke_other_sessions.proto\x1a\x15rpc_logout_user.proto\x1a\x1crpc_renew_access_token.proto\x1a\x18rpc_freeze_account.proto\x1a\x19rpc_list_token_keys.proto\x1a\x1arpc_verify_login_mfa.proto\x1a\x14rpc_enroll_mfa.proto\x1a\x15rpc_confirm_mfa.proto\x1a\x15rpc_disable_mfa.proto\x1a rpc_request_password_reset.proto\x1a\x18rpc_reset_password.proto\x1a\x1brpc_list_audit_events.proto\x1a\x19google/api/httpbody.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xb0F\n" +
 	"\n" +
 	"SimpleBank\x12\x98\x01\n" +
 	"\n" +
@@ -37,30 +38,249 @@ const file_service_simple_bank_proto_rawDesc = "" +
 	"\becho rpc\x12\x14Update existing user\x1a1Use this API to update an existing user's details\x82\xd3\xe4\x93\x02\x14:\x01*2\x0f/v1/update_user\x12\xa9\x01\n" +
 	"\tLoginUser\x12\x14.pb.LoginUserRequest\x1a\x15.pb.LoginUserResponse\"o\x92AS\n" +
 	"\becho rpc\x12\n" +
-	"Login user\x1a;Use this API to login user and get access and refresh token\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_userB\x97\x01\x92Ao\x12m\n" +
+	"Login user\x1a;Use this API to login user and get access and refresh token\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_user\x12\xb9\x01\n" +
+	"\rCreateAccount\x12\x18.pb.CreateAccountRequest\x1a\x19.pb.CreateAccountResponse\"s\x92AY\n" +
+	"\becho rpc\x12\x12Create new account\x1a9Use this API to open a new account for the logged in user\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/accounts\x12\xac\x01\n" +
+	"\n" +
+	"GetAccount\x12\x15.pb.GetAccountRequest\x1a\x16.pb.GetAccountResponse\"o\x92AS\n" +
+	"\becho rpc\x12\vGet account\x1a:Use this API to get an account owned by the logged in user\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/accounts/{id}\x12\xac\x01\n" +
+	"\fListAccounts\x12\x17.pb.ListAccountsRequest\x1a\x18.pb.ListAccountsResponse\"i\x92AR\n" +
+	"\becho rpc\x12\rList accounts\x1a7Use this API to list the accounts of the logged in user\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/accounts\x12\xeb\x01\n" +
+	"\x0eCreateTransfer\x12\x19.pb.CreateTransferRequest\x1a\x1a.pb.CreateTransferResponse\"\xa1\x01\x92A\x85\x01\n" +
+	"\becho rpc\x12\x0fCreate transfer\x1ahUse this API to transfer money between two accounts. Accounts with different currencies need an FX quote\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/transfers\x12\x97\x02\n" +
+	"\x0fReverseTransfer\x12\x1a.pb.ReverseTransferRequest\x1a\x1b.pb.ReverseTransferResponse\"\xca\x01\x92A\x98\x01\n" +
+	"\becho rpc\x12\x10Reverse transfer\x1azUse this API to refund a transfer, fully or partially. Only the receiver or an admin can reverse a transfer, and only once\x82\xd3\xe4\x93\x02(:\x01*\"#/v1/transfers/{transfer_id}/reverse\x12\xef\x01\n" +
+	"\tPlaceHold\x12\x14.pb.PlaceHoldRequest\x1a\x15.pb.PlaceHoldResponse\"\xb4\x01\x92A\x9c\x01\n" +
+	"\becho rpc\x12\n" +
+	"Place hold\x1a\x83\x01Use this API to reserve funds on an account for a payee. Held funds cannot be spent until the hold is captured, released or expires\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/holds\x12\xf6\x01\n" +
+	"\vCaptureHold\x12\x16.pb.CaptureHoldRequest\x1a\x17.pb.CaptureHoldResponse\"\xb5\x01\x92A\x8b\x01\n" +
+	"\becho rpc\x12\fCapture hold\x1aqUse this API to collect a hold, fully or partially, as a transfer to the payee. Only the payee can capture a hold\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/holds/{hold_id}/capture\x12\xe7\x01\n" +
+	"\vReleaseHold\x12\x16.pb.ReleaseHoldRequest\x1a\x17.pb.ReleaseHoldResponse\"\xa6\x01\x92A}\n" +
+	"\becho rpc\x12\fRelease hold\x1acUse this API to lift a hold without moving any money. Only the payee or an admin can release a hold\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/holds/{hold_id}/release\x12\xaa\x01\n" +
+	"\aGetHold\x12\x12.pb.GetHoldRequest\x1a\x13.pb.GetHoldResponse\"v\x92AX\n" +
+	"\becho rpc\x12\bGet hold\x1aBUse this API to get a hold placed by or for the authenticated user\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/holds/{hold_id}\x12\xd6\x01\n" +
+	"\rCreateFxQuote\x12\x18.pb.CreateFxQuoteRequest\x1a\x19.pb.CreateFxQuoteResponse\"\x8f\x01\x92At\n" +
+	"\becho rpc\x12\x0fCreate FX quote\x1aWUse this API to lock an exchange rate for a short time before a cross-currency transfer\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/fx_quotes\x12\x80\x02\n" +
+	"\x17CreateScheduledTransfer\x12\".pb.CreateScheduledTransferRequest\x1a#.pb.CreateScheduledTransferResponse\"\x9b\x01\x92Av\n" +
+	"\becho rpc\x12\x19Create scheduled transfer\x1aOUse this API to schedule a future transfer or set up a recurring standing order\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/scheduled_transfers\x12\xfc\x01\n" +
+	"\x14GetScheduledTransfer\x12\x1f.pb.GetScheduledTransferRequest\x1a .pb.GetScheduledTransferResponse\"\xa0\x01\x92Ay\n" +
+	"\becho rpc\x12\x16Get scheduled transfer\x1aUUse this API to get a standing order of the logged in user along with its recent runs\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/scheduled_transfers/{id}\x12\xe8\x01\n" +
+	"\x16ListScheduledTransfers\x12!.pb.ListScheduledTransfersRequest\x1a\".pb.ListScheduledTransfersResponse\"\x86\x01\x92Ad\n" +
+	"\becho rpc\x12\x18List scheduled transfers\x1a>Use this API to list the standing orders of the logged in user\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/scheduled_transfers\x12\xee\x01\n" +
+	"\x17UpdateScheduledTransfer\x12\".pb.UpdateScheduledTransferRequest\x1a#.pb.UpdateScheduledTransferResponse\"\x89\x01\x92A_\n" +
+	"\becho rpc\x12\x19Update scheduled transfer\x1a8Use this API to change, pause or resume a standing order\x82\xd3\xe4\x93\x02!:\x01*2\x1c/v1/scheduled_transfers/{id}\x12\xf3\x01\n" +
+	"\x17DeleteScheduledTransfer\x12\".pb.DeleteScheduledTransferRequest\x1a#.pb.DeleteScheduledTransferResponse\"\x8e\x01\x92Ag\n" +
+	"\becho rpc\x12\x19Delete scheduled transfer\x1a@Use this API to cancel a standing order. Its run history is kept\x82\xd3\xe4\x93\x02\x1e*\x1c/v1/scheduled_transfers/{id}\x12\x93\x02\n" +
+	"\x13GetAccountStatement\x12\x1e.pb.GetAccountStatementRequest\x1a\x1f.pb.GetAccountStatementResponse\"\xba\x01\x92A\x8b\x01\n" +
+	"\becho rpc\x12\x15Get account statement\x1ahUse this API to get the entries of an account in a date range with running, opening and closing balances\x82\xd3\xe4\x93\x02%\x12#/v1/accounts/{account_id}/statement\x12\x9e\x02\n" +
+	"\x18DownloadAccountStatement\x12#.pb.DownloadAccountStatementRequest\x1a\x14.google.api.HttpBody\"\xc6\x01\x92A\x8e\x01\n" +
+	"\becho rpc\x12\x1aDownload account statement\x1afUse this API to download an account statement as CSV or OFX. Large date ranges need a statement export\x82\xd3\xe4\x93\x02.\x12,/v1/accounts/{account_id}/statement/download\x12\xf4\x01\n" +
+	"\x15CreateStatementExport\x12 .pb.CreateStatementExportRequest\x1a!.pb.CreateStatementExportResponse\"\x95\x01\x92Ar\n" +
+	"\becho rpc\x12\x17Create statement export\x1aMUse this API to generate a statement for a large date range in the background\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/statement_exports\x12\xd6\x01\n" +
+	"\x12GetStatementExport\x12\x1d.pb.GetStatementExportRequest\x1a\x1e.pb.GetStatementExportResponse\"\x80\x01\x92A[\n" +
+	"\becho rpc\x12\x14Get statement export\x1a9Use this API to check whether a statement export is ready\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/statement_exports/{id}\x12\xe7\x01\n" +
+	"\x17DownloadStatementExport\x12\".pb.DownloadStatementExportRequest\x1a\x14.google.api.HttpBody\"\x91\x01\x92Ac\n" +
+	"\becho rpc\x12\x19Download statement export\x1a<Use this API to download a statement export once it is ready\x82\xd3\xe4\x93\x02%\x12#/v1/statement_exports/{id}/download\x12\xc4\x01\n" +
+	"\vVerifyEmail\x12\x16.pb.VerifyEmailRequest\x1a\x17.pb.VerifyEmailResponse\"\x83\x01\x92Ah\n" +
+	"\becho rpc\x12\fVerify email\x1aNUse this API to verify a user's email address with the link sent after sign up\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/verify_email\x12\xb9\x01\n" +
+	"\fListSessions\x12\x17.pb.ListSessionsRequest\x1a\x18.pb.ListSessionsResponse\"v\x92A_\n" +
+	"\becho rpc\x12\rList sessions\x1aDUse this API to list the devices signed in as the authenticated user\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/sessions\x12\xbc\x01\n" +
+	"\rRevokeSession\x12\x18.pb.RevokeSessionRequest\x1a\x19.pb.RevokeSessionResponse\"v\x92AZ\n" +
+	"\becho rpc\x12\x0eRevoke session\x1a>Use this API to sign out a single device, such as a lost phone\x82\xd3\xe4\x93\x02\x13*\x11/v1/sessions/{id}\x12\xe0\x01\n" +
+	"\x13RevokeOtherSessions\x12\x1e.pb.RevokeOtherSessionsRequest\x1a\x1f.pb.RevokeOtherSessionsResponse\"\x87\x01\x92A_\n" +
+	"\becho rpc\x12\x15Revoke other sessions\x1a<Use this API to sign out every device except the current one\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/sessions/revoke_others\x12\xaa\x01\n" +
+	"\n" +
+	"LogoutUser\x12\x15.pb.LogoutUserRequest\x1a\x16.pb.LogoutUserResponse\"m\x92AP\n" +
+	"\becho rpc\x12\vLogout user\x1a7Use this API to sign out the session of a refresh token\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/logout_user\x12\xa9\x02\n" +
+	"\x10RenewAccessToken\x12\x1b.pb.RenewAccessTokenRequest\x1a\x1c.pb.RenewAccessTokenResponse\"\xd9\x01\x92A\xb4\x01\n" +
+	"\becho rpc\x12\x12Renew access token\x1a\x93\x01Use this API to exchange a refresh token for a new access token and a new refresh token. Reusing a refresh token revokes every session of the login\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/renew_access_token\x12\xe2\x01\n" +
+	"\rFreezeAccount\x12\x18.pb.FreezeAccountRequest\x1a\x19.pb.FreezeAccountResponse\"\x9b\x01\x92Au\n" +
+	"\becho rpc\x12\x0eFreeze account\x1aYUse this API to stop an account from sending or receiving money. Requires the banker role\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/accounts/{id}/freeze\x12\xda\x01\n" +
+	"\x0fUnfreezeAccount\x12\x1a.pb.UnfreezeAccountRequest\x1a\x1b.pb.UnfreezeAccountResponse\"\x8d\x01\x92Ae\n" +
+	"\becho rpc\x12\x10Unfreeze account\x1aGUse this API to lift the freeze on an account. Requires the banker role\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/accounts/{id}/unfreeze\x12\xdf\x01\n" +
+	"\rListTokenKeys\x12\x18.pb.ListTokenKeysRequest\x1a\x19.pb.ListTokenKeysResponse\"\x98\x01\x92A\x7f\n" +
+	"\becho rpc\x12\x0fList token keys\x1abUse this API to get the public keys that tokens are signed with, so other services can verify them\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/token_keys\x12\xa1\x02\n" +
+	"\x0eVerifyLoginMFA\x12\x19.pb.VerifyLoginMFARequest\x1a\x15.pb.LoginUserResponse\"\xdc\x01\x92A\xbb\x01\n" +
+	"\becho rpc\x12\x11Verify login code\x1a\x9b\x01Use this API to finish logging in a user with two-factor authentication, using the mfa token returned by the login and a one-time password or recovery code\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/login_user/mfa\x12\x8f\x02\n" +
+	"\tEnrollMFA\x12\x14.pb.EnrollMFARequest\x1a\x15.pb.EnrollMFAResponse\"\xd4\x01\x92A\xb7\x01\n" +
+	"\becho rpc\x12#Enroll in two-factor authentication\x1a\x85\x01Use this API to get a TOTP secret and otpauth URI for an authenticator app. Two-factor authentication stays off until it is confirmed\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/mfa/enroll\x12\x9b\x02\n" +
+	"\n" +
+	"ConfirmMFA\x12\x15.pb.ConfirmMFARequest\x1a\x16.pb.ConfirmMFAResponse\"\xdd\x01\x92A\xbf\x01\n" +
+	"\becho rpc\x12!Confirm two-factor authentication\x1a\x8f\x01Use this API to turn on two-factor authentication with a code from the authenticator app. Returns the recovery codes, which are only shown once\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/mfa/confirm\x12\xe7\x01\n" +
+	"\n" +
+	"DisableMFA\x12\x15.pb.DisableMFARequest\x1a\x16.pb.DisableMFAResponse\"\xa9\x01\x92A\x8b\x01\n" +
+	"\becho rpc\x12!Disable two-factor authentication\x1a\\Use this API to turn off two-factor authentication with a one-time password or recovery code\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/mfa/disable\x12\x9b\x02\n" +
+	"\x14RequestPasswordReset\x12\x1f.pb.RequestPasswordResetRequest\x1a .pb.RequestPasswordResetResponse\"\xbf\x01\x92A\x96\x01\n" +
+	"\becho rpc\x12\x16Request password reset\x1arUse this API to email a password reset link. It responds the same way whether or not the address belongs to a user\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/request_password_reset\x12\xfa\x01\n" +
+	"\rResetPassword\x12\x18.pb.ResetPasswordRequest\x1a\x19.pb.ResetPasswordResponse\"\xb3\x01\x92A\x92\x01\n" +
+	"\becho rpc\x12\x0eReset password\x1avUse this API to set a new password with the token from a password reset email. Every session of the user is signed out\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/reset_password\x12\xed\x01\n" +
+	"\x0fListAuditEvents\x12\x1a.pb.ListAuditEventsRequest\x1a\x1b.pb.ListAuditEventsResponse\"\xa0\x01\x92A\x84\x01\n" +
+	"\becho rpc\x12\x11List audit events\x1aeUse this API to search the audit log of changes to users, sessions and money. Only admins may read it\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/audit_eventsB\x97\x01\x92Ao\x12m\n" +
 	"\x0eGO Backend API\"V\n" +
 	"\x16Vihanga Malaviarachchi\x12\x1dhttps://github.com/VihangaFTW\x1a\x1dvihaaanga.mihiranga@gmail.com2\x031.2Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
 
 var file_service_simple_bank_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),  // 0: pb.CreateUserRequest
-	(*UpdateUserRequest)(nil),  // 1: pb.UpdateUserRequest
-	(*LoginUserRequest)(nil),   // 2: pb.LoginUserRequest
-	(*CreateUserResponse)(nil), // 3: pb.CreateUserResponse
-	(*UpdateUserResponse)(nil), // 4: pb.UpdateUserResponse
-	(*LoginUserResponse)(nil),  // 5: pb.LoginUserResponse
+	(*CreateUserRequest)(nil),               // 0: pb.CreateUserRequest
+	(*UpdateUserRequest)(nil),               // 1: pb.UpdateUserRequest
+	(*LoginUserRequest)(nil),                // 2: pb.LoginUserRequest
+	(*CreateAccountRequest)(nil),            // 3: pb.CreateAccountRequest
+	(*GetAccountRequest)(nil),               // 4: pb.GetAccountRequest
+	(*ListAccountsRequest)(nil),             // 5: pb.ListAccountsRequest
+	(*CreateTransferRequest)(nil),           // 6: pb.CreateTransferRequest
+	(*ReverseTransferRequest)(nil),          // 7: pb.ReverseTransferRequest
+	(*PlaceHoldRequest)(nil),                // 8: pb.PlaceHoldRequest
+	(*CaptureHoldRequest)(nil),              // 9: pb.CaptureHoldRequest
+	(*ReleaseHoldRequest)(nil),              // 10: pb.ReleaseHoldRequest
+	(*GetHoldRequest)(nil),                  // 11: pb.GetHoldRequest
+	(*CreateFxQuoteRequest)(nil),            // 12: pb.CreateFxQuoteRequest
+	(*CreateScheduledTransferRequest)(nil),  // 13: pb.CreateScheduledTransferRequest
+	(*GetScheduledTransferRequest)(nil),     // 14: pb.GetScheduledTransferRequest
+	(*ListScheduledTransfersRequest)(nil),   // 15: pb.ListScheduledTransfersRequest
+	(*UpdateScheduledTransferRequest)(nil),  // 16: pb.UpdateScheduledTransferRequest
+	(*DeleteScheduledTransferRequest)(nil),  // 17: pb.DeleteScheduledTransferRequest
+	(*GetAccountStatementRequest)(nil),      // 18: pb.GetAccountStatementRequest
+	(*DownloadAccountStatementRequest)(nil), // 19: pb.DownloadAccountStatementRequest
+	(*CreateStatementExportRequest)(nil),    // 20: pb.CreateStatementExportRequest
+	(*GetStatementExportRequest)(nil),       // 21: pb.GetStatementExportRequest
+	(*DownloadStatementExportRequest)(nil),  // 22: pb.DownloadStatementExportRequest
+	(*VerifyEmailRequest)(nil),              // 23: pb.VerifyEmailRequest
+	(*ListSessionsRequest)(nil),             // 24: pb.ListSessionsRequest
+	(*RevokeSessionRequest)(nil),            // 25: pb.RevokeSessionRequest
+	(*RevokeOtherSessionsRequest)(nil),      // 26: pb.RevokeOtherSessionsRequest
+	(*LogoutUserRequest)(nil),               // 27: pb.LogoutUserRequest
+	(*RenewAccessTokenRequest)(nil),         // 28: pb.RenewAccessTokenRequest
+	(*FreezeAccountRequest)(nil),            // 29: pb.FreezeAccountRequest
+	(*UnfreezeAccountRequest)(nil),          // 30: pb.UnfreezeAccountRequest
+	(*ListTokenKeysRequest)(nil),            // 31: pb.ListTokenKeysRequest
+	(*VerifyLoginMFARequest)(nil),           // 32: pb.VerifyLoginMFARequest
+	(*EnrollMFARequest)(nil),                // 33: pb.EnrollMFARequest
+	(*ConfirmMFARequest)(nil),               // 34: pb.ConfirmMFARequest
+	(*DisableMFARequest)(nil),               // 35: pb.DisableMFARequest
+	(*RequestPasswordResetRequest)(nil),     // 36: pb.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),            // 37: pb.ResetPasswordRequest
+	(*ListAuditEventsRequest)(nil),          // 38: pb.ListAuditEventsRequest
+	(*CreateUserResponse)(nil),              // 39: pb.CreateUserResponse
+	(*UpdateUserResponse)(nil),              // 40: pb.UpdateUserResponse
+	(*LoginUserResponse)(nil),               // 41: pb.LoginUserResponse
+	(*CreateAccountResponse)(nil),           // 42: pb.CreateAccountResponse
+	(*GetAccountResponse)(nil),              // 43: pb.GetAccountResponse
+	(*ListAccountsResponse)(nil),            // 44: pb.ListAccountsResponse
+	(*CreateTransferResponse)(nil),          // 45: pb.CreateTransferResponse
+	(*ReverseTransferResponse)(nil),         // 46: pb.ReverseTransferResponse
+	(*PlaceHoldResponse)(nil),               // 47: pb.PlaceHoldResponse
+	(*CaptureHoldResponse)(nil),             // 48: pb.CaptureHoldResponse
+	(*ReleaseHoldResponse)(nil),             // 49: pb.ReleaseHoldResponse
+	(*GetHoldResponse)(nil),                 // 50: pb.GetHoldResponse
+	(*CreateFxQuoteResponse)(nil),           // 51: pb.CreateFxQuoteResponse
+	(*CreateScheduledTransferResponse)(nil), // 52: pb.CreateScheduledTransferResponse
+	(*GetScheduledTransferResponse)(nil),    // 53: pb.GetScheduledTransferResponse
+	(*ListScheduledTransfersResponse)(nil),  // 54: pb.ListScheduledTransfersResponse
+	(*UpdateScheduledTransferResponse)(nil), // 55: pb.UpdateScheduledTransferResponse
+	(*DeleteScheduledTransferResponse)(nil), // 56: pb.DeleteScheduledTransferResponse
+	(*GetAccountStatementResponse)(nil),     // 57: pb.GetAccountStatementResponse
+	(*httpbody.HttpBody)(nil),               // 58: google.api.HttpBody
+	(*CreateStatementExportResponse)(nil),   // 59: pb.CreateStatementExportResponse
+	(*GetStatementExportResponse)(nil),      // 60: pb.GetStatementExportResponse
+	(*VerifyEmailResponse)(nil),             // 61: pb.VerifyEmailResponse
+	(*ListSessionsResponse)(nil),            // 62: pb.ListSessionsResponse
+	(*RevokeSessionResponse)(nil),           // 63: pb.RevokeSessionResponse
+	(*RevokeOtherSessionsResponse)(nil),     // 64: pb.RevokeOtherSessionsResponse
+	(*LogoutUserResponse)(nil),              // 65: pb.LogoutUserResponse
+	(*RenewAccessTokenResponse)(nil),        // 66: pb.RenewAccessTokenResponse
+	(*FreezeAccountResponse)(nil),           // 67: pb.FreezeAccountResponse
+	(*UnfreezeAccountResponse)(nil),         // 68: pb.UnfreezeAccountResponse
+	(*ListTokenKeysResponse)(nil),           // 69: pb.ListTokenKeysResponse
+	(*EnrollMFAResponse)(nil),               // 70: pb.EnrollMFAResponse
+	(*ConfirmMFAResponse)(nil),              // 71: pb.ConfirmMFAResponse
+	(*DisableMFAResponse)(nil),              // 72: pb.DisableMFAResponse
+	(*RequestPasswordResetResponse)(nil),    // 73: pb.RequestPasswordResetResponse
+	(*ResetPasswordResponse)(nil),           // 74: pb.ResetPasswordResponse
+	(*ListAuditEventsResponse)(nil),         // 75: pb.ListAuditEventsResponse
 }
 var file_service_simple_bank_proto_depIdxs = []int32{
-	0, // 0: pb.SimpleBank.CreateUser:input_type -> pb.CreateUserRequest
-	1, // 1: pb.SimpleBank.UpdateUser:input_type -> pb.UpdateUserRequest
-	2, // 2: pb.SimpleBank.LoginUser:input_type -> pb.LoginUserRequest
-	3, // 3: pb.SimpleBank.CreateUser:output_type -> pb.CreateUserResponse
-	4, // 4: pb.SimpleBank.UpdateUser:output_type -> pb.UpdateUserResponse
-	5, // 5: pb.SimpleBank.LoginUser:output_type -> pb.LoginUserResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: pb.SimpleBank.CreateUser:input_type -> pb.CreateUserRequest
+	1,  // 1: pb.SimpleBank.UpdateUser:input_type -> pb.UpdateUserRequest
+	2,  // 2: pb.SimpleBank.LoginUser:input_type -> pb.LoginUserRequest
+	3,  // 3: pb.SimpleBank.CreateAccount:input_type -> pb.CreateAccountRequest
+	4,  // 4: pb.SimpleBank.GetAccount:input_type -> pb.GetAccountRequest
+	5,  // 5: pb.SimpleBank.ListAccounts:input_type -> pb.ListAccountsRequest
+	6,  // 6: pb.SimpleBank.CreateTransfer:input_type -> pb.CreateTransferRequest
+	7,  // 7: pb.SimpleBank.ReverseTransfer:input_type -> pb.ReverseTransferRequest
+	8,  // 8: pb.SimpleBank.PlaceHold:input_type -> pb.PlaceHoldRequest
+	9,  // 9: pb.SimpleBank.CaptureHold:input_type -> pb.CaptureHoldRequest
+	10, // 10: pb.SimpleBank.ReleaseHold:input_type -> pb.ReleaseHoldRequest
+	11, // 11: pb.SimpleBank.GetHold:input_type -> pb.GetHoldRequest
+	12, // 12: pb.SimpleBank.CreateFxQuote:input_type -> pb.CreateFxQuoteRequest
+	13, // 13: pb.SimpleBank.CreateScheduledTransfer:input_type -> pb.CreateScheduledTransferRequest
+	14, // 14: pb.SimpleBank.GetScheduledTransfer:input_type -> pb.GetScheduledTransferRequest
+	15, // 15: pb.SimpleBank.ListScheduledTransfers:input_type -> pb.ListScheduledTransfersRequest
+	16, // 16: pb.SimpleBank.UpdateScheduledTransfer:input_type -> pb.UpdateScheduledTransferRequest
+	17, // 17: pb.SimpleBank.DeleteScheduledTransfer:input_type -> pb.DeleteScheduledTransferRequest
+	18, // 18: pb.SimpleBank.GetAccountStatement:input_type -> pb.GetAccountStatementRequest
+	19, // 19: pb.SimpleBank.DownloadAccountStatement:input_type -> pb.DownloadAccountStatementRequest
+	20, // 20: pb.SimpleBank.CreateStatementExport:input_type -> pb.CreateStatementExportRequest
+	21, // 21: pb.SimpleBank.GetStatementExport:input_type -> pb.GetStatementExportRequest
+	22, // 22: pb.SimpleBank.DownloadStatementExport:input_type -> pb.DownloadStatementExportRequest
+	23, // 23: pb.SimpleBank.VerifyEmail:input_type -> pb.VerifyEmailRequest
+	24, // 24: pb.SimpleBank.ListSessions:input_type -> pb.ListSessionsRequest
+	25, // 25: pb.SimpleBank.RevokeSession:input_type -> pb.RevokeSessionRequest
+	26, // 26: pb.SimpleBank.RevokeOtherSessions:input_type -> pb.RevokeOtherSessionsRequest
+	27, // 27: pb.SimpleBank.LogoutUser:input_type -> pb.LogoutUserRequest
+	28, // 28: pb.SimpleBank.RenewAccessToken:input_type -> pb.RenewAccessTokenRequest
+	29, // 29: pb.SimpleBank.FreezeAccount:input_type -> pb.FreezeAccountRequest
+	30, // 30: pb.SimpleBank.UnfreezeAccount:input_type -> pb.UnfreezeAccountRequest
+	31, // 31: pb.SimpleBank.ListTokenKeys:input_type -> pb.ListTokenKeysRequest
+	32, // 32: pb.SimpleBank.VerifyLoginMFA:input_type -> pb.VerifyLoginMFARequest
+	33, // 33: pb.SimpleBank.EnrollMFA:input_type -> pb.EnrollMFARequest
+	34, // 34: pb.SimpleBank.ConfirmMFA:input_type -> pb.ConfirmMFARequest
+	35, // 35: pb.SimpleBank.DisableMFA:input_type -> pb.DisableMFARequest
+	36, // 36: pb.SimpleBank.RequestPasswordReset:input_type -> pb.RequestPasswordResetRequest
+	37, // 37: pb.SimpleBank.ResetPassword:input_type -> pb.ResetPasswordRequest
+	38, // 38: pb.SimpleBank.ListAuditEvents:input_type -> pb.ListAuditEventsRequest
+	39, // 39: pb.SimpleBank.CreateUser:output_type -> pb.CreateUserResponse
+	40, // 40: pb.SimpleBank.UpdateUser:output_type -> pb.UpdateUserResponse
+	41, // 41: pb.SimpleBank.LoginUser:output_type -> pb.LoginUserResponse
+	42, // 42: pb.SimpleBank.CreateAccount:output_type -> pb.CreateAccountResponse
+	43, // 43: pb.SimpleBank.GetAccount:output_type -> pb.GetAccountResponse
+	44, // 44: pb.SimpleBank.ListAccounts:output_type -> pb.ListAccountsResponse
+	45, // 45: pb.SimpleBank.CreateTransfer:output_type -> pb.CreateTransferResponse
+	46, // 46: pb.SimpleBank.ReverseTransfer:output_type -> pb.ReverseTransferResponse
+	47, // 47: pb.SimpleBank.PlaceHold:output_type -> pb.PlaceHoldResponse
+	48, // 48: pb.SimpleBank.CaptureHold:output_type -> pb.CaptureHoldResponse
+	49, // 49: pb.SimpleBank.ReleaseHold:output_type -> pb.ReleaseHoldResponse
+	50, // 50: pb.SimpleBank.GetHold:output_type -> pb.GetHoldResponse
+	51, // 51: pb.SimpleBank.CreateFxQuote:output_type -> pb.CreateFxQuoteResponse
+	52, // 52: pb.SimpleBank.CreateScheduledTransfer:output_type -> pb.CreateScheduledTransferResponse
+	53, // 53: pb.SimpleBank.GetScheduledTransfer:output_type -> pb.GetScheduledTransferResponse
+	54, // 54: pb.SimpleBank.ListScheduledTransfers:output_type -> pb.ListScheduledTransfersResponse
+	55, // 55: pb.SimpleBank.UpdateScheduledTransfer:output_type -> pb.UpdateScheduledTransferResponse
+	56, // 56: pb.SimpleBank.DeleteScheduledTransfer:output_type -> pb.DeleteScheduledTransferResponse
+	57, // 57: pb.SimpleBank.GetAccountStatement:output_type -> pb.GetAccountStatementResponse
+	58, // 58: pb.SimpleBank.DownloadAccountStatement:output_type -> google.api.HttpBody
+	59, // 59: pb.SimpleBank.CreateStatementExport:output_type -> pb.CreateStatementExportResponse
+	60, // 60: pb.SimpleBank.GetStatementExport:output_type -> pb.GetStatementExportResponse
+	58, // 61: pb.SimpleBank.DownloadStatementExport:output_type -> google.api.HttpBody
+	61, // 62: pb.SimpleBank.VerifyEmail:output_type -> pb.VerifyEmailResponse
+	62, // 63: pb.SimpleBank.ListSessions:output_type -> pb.ListSessionsResponse
+	63, // 64: pb.SimpleBank.RevokeSession:output_type -> pb.RevokeSessionResponse
+	64, // 65: pb.SimpleBank.RevokeOtherSessions:output_type -> pb.RevokeOtherSessionsResponse
+	65, // 66: pb.SimpleBank.LogoutUser:output_type -> pb.LogoutUserResponse
+	66, // 67: pb.SimpleBank.RenewAccessToken:output_type -> pb.RenewAccessTokenResponse
+	67, // 68: pb.SimpleBank.FreezeAccount:output_type -> pb.FreezeAccountResponse
+	68, // 69: pb.SimpleBank.UnfreezeAccount:output_type -> pb.UnfreezeAccountResponse
+	69, // 70: pb.SimpleBank.ListTokenKeys:output_type -> pb.ListTokenKeysResponse
+	41, // 71: pb.SimpleBank.VerifyLoginMFA:output_type -> pb.LoginUserResponse
+	70, // 72: pb.SimpleBank.EnrollMFA:output_type -> pb.EnrollMFAResponse
+	71, // 73: pb.SimpleBank.ConfirmMFA:output_type -> pb.ConfirmMFAResponse
+	72, // 74: pb.SimpleBank.DisableMFA:output_type -> pb.DisableMFAResponse
+	73, // 75: pb.SimpleBank.RequestPasswordReset:output_type -> pb.RequestPasswordResetResponse
+	74, // 76: pb.SimpleBank.ResetPassword:output_type -> pb.ResetPasswordResponse
+	75, // 77: pb.SimpleBank.ListAuditEvents:output_type -> pb.ListAuditEventsResponse
+	39, // [39:78] is the sub-list for method output_type
+	0,  // [0:39] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_service_simple_bank_proto_init() }
@@ -71,6 +291,41 @@ func file_service_simple_bank_proto_init() {
 	file_rpc_create_user_proto_init()
 	file_rpc_login_user_proto_init()
 	file_rpc_update_user_proto_init()
+	file_rpc_create_account_proto_init()
+	file_rpc_get_account_proto_init()
+	file_rpc_list_accounts_proto_init()
+	file_rpc_create_transfer_proto_init()
+	file_rpc_reverse_transfer_proto_init()
+	file_rpc_place_hold_proto_init()
+	file_rpc_capture_hold_proto_init()
+	file_rpc_release_hold_proto_init()
+	file_rpc_get_hold_proto_init()
+	file_rpc_create_fx_quote_proto_init()
+	file_rpc_create_scheduled_transfer_proto_init()
+	file_rpc_get_scheduled_transfer_proto_init()
+	file_rpc_list_scheduled_transfers_proto_init()
+	file_rpc_update_scheduled_transfer_proto_init()
+	file_rpc_delete_scheduled_transfer_proto_init()
+	file_rpc_get_account_statement_proto_init()
+	file_rpc_download_account_statement_proto_init()
+	file_rpc_create_statement_export_proto_init()
+	file_rpc_get_statement_export_proto_init()
+	file_rpc_download_statement_export_proto_init()
+	file_rpc_verify_email_proto_init()
+	file_rpc_list_sessions_proto_init()
+	file_rpc_revoke_session_proto_init()
+	file_rpc_revoke_other_sessions_proto_init()
+	file_rpc_logout_user_proto_init()
+	file_rpc_renew_access_token_proto_init()
+	file_rpc_freeze_account_proto_init()
+	file_rpc_list_token_keys_proto_init()
+	file_rpc_verify_login_mfa_proto_init()
+	file_rpc_enroll_mfa_proto_init()
+	file_rpc_confirm_mfa_proto_init()
+	file_rpc_disable_mfa_proto_init()
+	file_rpc_request_password_reset_proto_init()
+	file_rpc_reset_password_proto_init()
+	file_rpc_list_audit_events_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -29,8 +29,11 @@ type User struct {
 	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
 	PasswordChangedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=password_changed_at,proto3" json:"password_changed_at,omitempty"`
 	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,proto3" json:"created_at,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	IsEmailVerified   bool                   `protobuf:"varint,6,opt,name=is_email_verified,proto3" json:"is_email_verified,omitempty"`
+	// One of depositor, banker or admin.
+	Role          string `protobuf:"bytes,7,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -98,12 +101,26 @@ func (x *User) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *User) GetIsEmailVerified() bool {
+	if x != nil {
+		return x.IsEmailVerified
+	}
+	return false
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 var File_user_proto protoreflect.FileDescriptor
 
 const file_user_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"user.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe0\x01\n" +
+	"user.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa2\x02\n" +
 	"\x04User\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1c\n" +
 	"\tfull_name\x18\x02 \x01(\tR\tfull_name\x12\x14\n" +
@@ -111,7 +128,9 @@ const file_user_proto_rawDesc = "" +
 	"\x13password_changed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x13password_changed_at\x12:\n" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"created_atB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
+	"created_at\x12,\n" +
+	"\x11is_email_verified\x18\x06 \x01(\bR\x11is_email_verified\x12\x12\n" +
+	"\x04role\x18\a \x01(\tR\x04roleB%Z#github.com/VihangaFTW/Go-Backend/pbb\x06proto3"
 
 var (
 	file_user_proto_rawDescOnce sync.Once
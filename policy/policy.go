@@ -0,0 +1,85 @@
+// Package policy decides what an authenticated user may do, based on their role and on who owns the resource.
+// Both the HTTP and the gRPC servers go through it instead of comparing usernames inline.
+package policy
+
+import (
+	"slices"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+)
+
+// Permission is an action a role may take on resources that belong to other users.
+// Every user may act on their own resources regardless of role.
+type Permission string
+
+const (
+	ReadAnyAccount     Permission = "accounts:read_any"
+	FreezeAccount      Permission = "accounts:freeze"
+	ManageUsers        Permission = "users:manage"
+	ReadAuditLog       Permission = "audit:read"
+	ReverseAnyTransfer Permission = "transfers:reverse_any"
+	ReleaseAnyHold     Permission = "holds:release_any"
+)
+
+var rolePermissions = map[string][]Permission{
+	util.DepositorRole: {},
+	util.BankerRole:    {ReadAnyAccount, FreezeAccount},
+	util.AdminRole:     {ManageUsers, ReadAuditLog, ReverseAnyTransfer, ReleaseAnyHold},
+}
+
+// Allowed reports whether the role of the token grants the permission.
+// Unknown roles are granted nothing.
+func Allowed(payload *token.Payload, permission Permission) bool {
+	return slices.Contains(rolePermissions[payload.Role], permission)
+}
+
+// CanReadAccount reports whether the user may see the account and its statements.
+func CanReadAccount(payload *token.Payload, account db.Account) bool {
+	return account.Owner == payload.Username || Allowed(payload, ReadAnyAccount)
+}
+
+// CanMoveMoney reports whether the user may send money out of the account.
+// No role can move money on behalf of another user.
+func CanMoveMoney(payload *token.Payload, account db.Account) bool {
+	return account.Owner == payload.Username
+}
+
+// CanReverseTransfer reports whether the user may refund a transfer received by the account.
+// The receiver may give money back, the sender has to ask them or an admin.
+func CanReverseTransfer(payload *token.Payload, receiver db.Account) bool {
+	return receiver.Owner == payload.Username || Allowed(payload, ReverseAnyTransfer)
+}
+
+// CanReadHold reports whether the user may see a hold on the funds of the payer towards the payee account.
+func CanReadHold(payload *token.Payload, hold db.Hold, payee db.Account) bool {
+	return hold.Owner == payload.Username || payee.Owner == payload.Username || Allowed(payload, ReadAnyAccount)
+}
+
+// CanCaptureHold reports whether the user may collect a hold towards the payee account.
+// The payer authorized the payment when placing the hold, so only the payee collects it.
+func CanCaptureHold(payload *token.Payload, payee db.Account) bool {
+	return payee.Owner == payload.Username
+}
+
+// CanReleaseHold reports whether the user may lift a hold towards the payee account without collecting it.
+// The payer cannot take back funds they promised, the payee or an admin has to release them.
+func CanReleaseHold(payload *token.Payload, payee db.Account) bool {
+	return payee.Owner == payload.Username || Allowed(payload, ReleaseAnyHold)
+}
+
+// CanFreezeAccount reports whether the user may freeze or unfreeze accounts.
+func CanFreezeAccount(payload *token.Payload) bool {
+	return Allowed(payload, FreezeAccount)
+}
+
+// CanUpdateUser reports whether the user may change the profile of the given user.
+func CanUpdateUser(payload *token.Payload, username string) bool {
+	return username == payload.Username || Allowed(payload, ManageUsers)
+}
+
+// CanChangeRole reports whether the user may change the role of any user, including their own.
+func CanChangeRole(payload *token.Payload) bool {
+	return Allowed(payload, ManageUsers)
+}
@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"testing"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPayload(role string) *token.Payload {
+	return &token.Payload{Username: util.RandomOwner(), Role: role}
+}
+
+func TestCanReadAccount(t *testing.T) {
+	depositor := randomPayload(util.DepositorRole)
+	banker := randomPayload(util.BankerRole)
+	admin := randomPayload(util.AdminRole)
+
+	own := db.Account{Owner: depositor.Username}
+	other := db.Account{Owner: util.RandomOwner()}
+
+	require.True(t, CanReadAccount(depositor, own))
+	require.False(t, CanReadAccount(depositor, other))
+	require.True(t, CanReadAccount(banker, other))
+	require.False(t, CanReadAccount(admin, other))
+}
+
+func TestCanMoveMoney(t *testing.T) {
+	account := db.Account{Owner: util.RandomOwner()}
+
+	//? not even bankers may move money out of another user's account
+	for _, role := range util.SupportedRoles {
+		require.False(t, CanMoveMoney(randomPayload(role), account))
+	}
+
+	owner := randomPayload(util.DepositorRole)
+	owner.Username = account.Owner
+	require.True(t, CanMoveMoney(owner, account))
+}
+
+func TestCanReverseTransfer(t *testing.T) {
+	receiver := randomPayload(util.DepositorRole)
+	account := db.Account{Owner: receiver.Username}
+
+	require.True(t, CanReverseTransfer(receiver, account))
+	require.False(t, CanReverseTransfer(randomPayload(util.DepositorRole), account))
+	require.False(t, CanReverseTransfer(randomPayload(util.BankerRole), account))
+	require.True(t, CanReverseTransfer(randomPayload(util.AdminRole), account))
+}
+
+func TestHoldPermissions(t *testing.T) {
+	payer := randomPayload(util.DepositorRole)
+	payee := randomPayload(util.DepositorRole)
+	hold := db.Hold{Owner: payer.Username}
+	payeeAccount := db.Account{Owner: payee.Username}
+
+	require.True(t, CanReadHold(payer, hold, payeeAccount))
+	require.True(t, CanReadHold(payee, hold, payeeAccount))
+	require.True(t, CanReadHold(randomPayload(util.BankerRole), hold, payeeAccount))
+	require.False(t, CanReadHold(randomPayload(util.DepositorRole), hold, payeeAccount))
+
+	require.True(t, CanCaptureHold(payee, payeeAccount))
+	require.False(t, CanCaptureHold(payer, payeeAccount))
+	require.False(t, CanCaptureHold(randomPayload(util.AdminRole), payeeAccount))
+
+	require.True(t, CanReleaseHold(payee, payeeAccount))
+	require.False(t, CanReleaseHold(payer, payeeAccount))
+	require.False(t, CanReleaseHold(randomPayload(util.BankerRole), payeeAccount))
+	require.True(t, CanReleaseHold(randomPayload(util.AdminRole), payeeAccount))
+}
+
+func TestCanFreezeAccount(t *testing.T) {
+	require.False(t, CanFreezeAccount(randomPayload(util.DepositorRole)))
+	require.True(t, CanFreezeAccount(randomPayload(util.BankerRole)))
+	require.False(t, CanFreezeAccount(randomPayload(util.AdminRole)))
+}
+
+func TestCanUpdateUser(t *testing.T) {
+	depositor := randomPayload(util.DepositorRole)
+	banker := randomPayload(util.BankerRole)
+	admin := randomPayload(util.AdminRole)
+	username := util.RandomOwner()
+
+	require.True(t, CanUpdateUser(depositor, depositor.Username))
+	require.False(t, CanUpdateUser(depositor, username))
+	require.False(t, CanUpdateUser(banker, username))
+	require.True(t, CanUpdateUser(admin, username))
+
+	require.False(t, CanChangeRole(depositor))
+	require.False(t, CanChangeRole(banker))
+	require.True(t, CanChangeRole(admin))
+}
+
+func TestReadAuditLog(t *testing.T) {
+	require.False(t, Allowed(randomPayload(util.DepositorRole), ReadAuditLog))
+	require.False(t, Allowed(randomPayload(util.BankerRole), ReadAuditLog))
+	require.True(t, Allowed(randomPayload(util.AdminRole), ReadAuditLog))
+}
+
+func TestUnknownRole(t *testing.T) {
+	payload := randomPayload("superuser")
+
+	require.False(t, Allowed(payload, ReadAnyAccount))
+	require.False(t, Allowed(payload, FreezeAccount))
+	require.False(t, Allowed(payload, ManageUsers))
+	require.False(t, Allowed(payload, ReadAuditLog))
+	require.False(t, Allowed(payload, ReverseAnyTransfer))
+}
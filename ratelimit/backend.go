@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MemoryBackend keeps the buckets in the memory of a single process.
+// It suits tests and single instance setups; anything else should share a RedisBackend.
+type MemoryBackend struct {
+	mutex     sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt is when the bucket is full again, after which it no longer needs to be kept
+	fullAt time.Time
+}
+
+// sweepInterval is how often full buckets are dropped from a MemoryBackend
+const sweepInterval = time.Minute
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		buckets:   make(map[string]memoryBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (backend *MemoryBackend) Take(ctx context.Context, key string, limit Limit) (float64, bool, error) {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	now := time.Now()
+	backend.sweep(now)
+
+	capacity := float64(limit.Requests)
+
+	bucket, ok := backend.buckets[key]
+	if !ok {
+		bucket = memoryBucket{tokens: capacity, updatedAt: now}
+	}
+
+	//? tokens flow back in proportion to the time since the bucket was last used
+	refill := float64(now.Sub(bucket.updatedAt)) * capacity / float64(limit.Period)
+	bucket.tokens = min(capacity, bucket.tokens+refill)
+	bucket.updatedAt = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	bucket.fullAt = now.Add(limit.durationFor(capacity - bucket.tokens))
+	backend.buckets[key] = bucket
+
+	return bucket.tokens, allowed, nil
+}
+
+// sweep drops the buckets that are full again, which behave like new ones. The mutex must be held.
+func (backend *MemoryBackend) sweep(now time.Time) {
+	if now.Sub(backend.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, bucket := range backend.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(backend.buckets, key)
+		}
+	}
+
+	backend.lastSweep = now
+}
+
+// takeScript refills and takes from a bucket stored as a hash in a single step, so concurrent requests
+// cannot both take the last token. The clock of the Redis server is used, so server instances
+// with drifting clocks still agree on how much a bucket has refilled.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(bucket[1])
+local updated_at = tonumber(bucket[2])
+
+if tokens == nil or updated_at == nil then
+	tokens = capacity
+	updated_at = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - updated_at) * capacity / period)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', now)
+redis.call('PEXPIRE', KEYS[1], period)
+
+return {allowed, tostring(tokens)}
+`)
+
+// RedisBackend keeps the buckets in Redis, so every server instance shares them.
+// Buckets expire once they could have refilled completely, so nothing has to be cleaned up.
+type RedisBackend struct {
+	client redis.UniversalClient
+}
+
+func NewRedisBackend(client redis.UniversalClient) *RedisBackend {
+	return &RedisBackend{client: client}
+}
+
+func (backend *RedisBackend) Take(ctx context.Context, key string, limit Limit) (float64, bool, error) {
+	//! Lua numbers are truncated to integers on the way back, so the tokens are returned as a string
+	values, err := takeScript.Run(ctx, backend.client, []string{key}, limit.Requests, limit.Period.Milliseconds()).Slice()
+	if err != nil {
+		return 0, false, err
+	}
+
+	allowed, _ := values[0].(int64)
+	text, _ := values[1].(string)
+
+	tokens, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return tokens, allowed == 1, nil
+}
@@ -0,0 +1,218 @@
+// Package ratelimit limits how often a user or client can call the API with token buckets.
+// Every bucket holds up to Limit.Requests tokens, each request takes one, and the bucket refills
+// at a steady rate so that it is full again one Limit.Period after it was emptied.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/redis/go-redis/v9"
+)
+
+// Group is a set of routes or methods that share a limit.
+type Group string
+
+const (
+	// GroupLogin covers every endpoint that checks a password, a one-time code or a reset token
+	GroupLogin Group = "login"
+	// GroupTransfers covers endpoints that move money or schedule it to be moved
+	GroupTransfers Group = "transfers"
+	// GroupReads covers endpoints that only read data
+	GroupReads Group = "reads"
+	// GroupDefault covers every other endpoint
+	GroupDefault Group = "default"
+)
+
+// Limit allows Requests requests per Period, which may all be made at once.
+// A zero Limit does not limit anything.
+type Limit struct {
+	Requests int64
+	Period   time.Duration
+}
+
+// ParseLimit parses a limit written as "<requests>/<period>", e.g. "10/1m".
+// An empty string is a zero Limit.
+func ParseLimit(value string) (Limit, error) {
+	if value == "" {
+		return Limit{}, nil
+	}
+
+	requests, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<period>", value)
+	}
+
+	limit := Limit{}
+	var err error
+
+	limit.Requests, err = strconv.ParseInt(strings.TrimSpace(requests), 10, 64)
+	if err != nil || limit.Requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive number", value)
+	}
+
+	limit.Period, err = time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || limit.Period <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: period must be a positive duration", value)
+	}
+
+	return limit, nil
+}
+
+func (limit Limit) String() string {
+	return fmt.Sprintf("%d/%s", limit.Requests, limit.Period)
+}
+
+// durationFor returns how long the bucket takes to refill the given number of tokens.
+func (limit Limit) durationFor(tokens float64) time.Duration {
+	return time.Duration(tokens * float64(limit.Period) / float64(limit.Requests))
+}
+
+// Backend keeps the token buckets.
+type Backend interface {
+	// Take refills the bucket of the key for the time since it was last used and takes a token if there is one.
+	// It returns the tokens left in the bucket and whether a token was taken.
+	Take(ctx context.Context, key string, limit Limit) (tokens float64, allowed bool, err error)
+}
+
+// Result describes the bucket after a request, and is what the RateLimit headers are made of.
+type Result struct {
+	Limit   Limit
+	Allowed bool
+	// Remaining is the number of requests that can be made right away
+	Remaining int64
+	// ResetAfter is how long the bucket takes to be full again
+	ResetAfter time.Duration
+	// RetryAfter is how long to wait for the next token of a rejected request
+	RetryAfter time.Duration
+}
+
+// Limiter applies the limit of each group to the requests of every user or client.
+type Limiter struct {
+	backend Backend
+	limits  map[Group]Limit
+}
+
+// NewLimiter creates a Limiter with the given limits. Groups without a limit are not limited.
+func NewLimiter(backend Backend, limits map[Group]Limit) *Limiter {
+	return &Limiter{
+		backend: backend,
+		limits:  limits,
+	}
+}
+
+// Backends that can be selected with the RATE_LIMIT_BACKEND config
+const (
+	MemoryBackendType = "memory"
+	RedisBackendType  = "redis"
+)
+
+// NewLimiterFromConfig creates a Limiter with the limits and the backend selected by the config.
+// The redis client is only used by the redis backend.
+func NewLimiterFromConfig(config util.Config, client redis.UniversalClient) (*Limiter, error) {
+	var backend Backend
+
+	switch config.RateLimitBackend {
+	case "", MemoryBackendType:
+		backend = NewMemoryBackend()
+	case RedisBackendType:
+		backend = NewRedisBackend(client)
+	default:
+		return nil, fmt.Errorf("unsupported rate limit backend: %s", config.RateLimitBackend)
+	}
+
+	limits := make(map[Group]Limit)
+
+	for group, value := range map[Group]string{
+		GroupLogin:     config.RateLimitLogin,
+		GroupTransfers: config.RateLimitTransfers,
+		GroupReads:     config.RateLimitReads,
+		GroupDefault:   config.RateLimitDefault,
+	} {
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", group, err)
+		}
+
+		limits[group] = limit
+	}
+
+	return NewLimiter(backend, limits), nil
+}
+
+// UserKey identifies the requests of an authenticated user.
+func UserKey(username string) string {
+	return "user:" + username
+}
+
+// ClientIPKey identifies the requests of an anonymous client.
+func ClientIPKey(clientIP string) string {
+	return "ip:" + clientIP
+}
+
+// Allow takes a token from the bucket of the key in the group.
+// Every group has its own buckets, so exhausting the reads of a user does not stop their transfers.
+func (limiter *Limiter) Allow(ctx context.Context, group Group, key string) (Result, error) {
+	limit := limiter.limits[group]
+
+	if limit.Requests == 0 {
+		return Result{Allowed: true}, nil
+	}
+
+	tokens, allowed, err := limiter.backend.Take(ctx, "ratelimit:"+string(group)+":"+key, limit)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	result := Result{
+		Limit:      limit,
+		Allowed:    allowed,
+		Remaining:  int64(math.Floor(tokens)),
+		ResetAfter: limit.durationFor(float64(limit.Requests) - tokens),
+	}
+
+	if !allowed {
+		result.RetryAfter = limit.durationFor(1 - tokens)
+	}
+
+	return result, nil
+}
+
+// Standard rate limit headers of the IETF RateLimit header fields draft, plus Retry-After on rejected requests
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderPolicy     = "RateLimit-Policy"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Headers returns the rate limit headers of the result, or nothing when the request was not limited.
+func (result Result) Headers() map[string]string {
+	if result.Limit.Requests == 0 {
+		return nil
+	}
+
+	headers := map[string]string{
+		HeaderLimit:     strconv.FormatInt(result.Limit.Requests, 10),
+		HeaderRemaining: strconv.FormatInt(result.Remaining, 10),
+		HeaderReset:     strconv.FormatInt(seconds(result.ResetAfter), 10),
+		HeaderPolicy:    fmt.Sprintf("%d;w=%d", result.Limit.Requests, seconds(result.Limit.Period)),
+	}
+
+	if !result.Allowed {
+		headers[HeaderRetryAfter] = strconv.FormatInt(seconds(result.RetryAfter), 10)
+	}
+
+	return headers
+}
+
+// seconds rounds up, so clients never come back too early.
+func seconds(duration time.Duration) int64 {
+	return int64(math.Ceil(duration.Seconds()))
+}
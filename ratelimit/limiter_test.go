@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit("10/1m")
+	require.NoError(t, err)
+	require.Equal(t, Limit{Requests: 10, Period: time.Minute}, limit)
+
+	limit, err = ParseLimit("")
+	require.NoError(t, err)
+	require.Zero(t, limit)
+
+	for _, value := range []string{"10", "ten/1m", "0/1m", "10/0s", "10/minute"} {
+		_, err = ParseLimit(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	limit := Limit{Requests: 3, Period: time.Minute}
+	limiter := NewLimiter(NewMemoryBackend(), map[Group]Limit{GroupLogin: limit})
+	ctx := context.Background()
+
+	key := UserKey(util.RandomOwner())
+
+	for i := int64(1); i <= limit.Requests; i++ {
+		result, err := limiter.Allow(ctx, GroupLogin, key)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, limit.Requests-i, result.Remaining)
+	}
+
+	//? the bucket is empty, the next token arrives after a third of the period
+	result, err := limiter.Allow(ctx, GroupLogin, key)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Zero(t, result.Remaining)
+	require.InDelta(t, 20*time.Second, result.RetryAfter, float64(time.Second))
+	require.InDelta(t, time.Minute, result.ResetAfter, float64(time.Second))
+
+	//? other keys and other groups have their own buckets
+	result, err = limiter.Allow(ctx, GroupLogin, ClientIPKey("10.0.0.1"))
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	//? a group without a limit is not limited and sends no headers
+	result, err = limiter.Allow(ctx, GroupReads, key)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Nil(t, result.Headers())
+}
+
+func TestMemoryBackendRefill(t *testing.T) {
+	backend := NewMemoryBackend()
+	limit := Limit{Requests: 2, Period: 100 * time.Millisecond}
+
+	for range limit.Requests {
+		_, allowed, err := backend.Take(context.Background(), "key", limit)
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+
+	_, allowed, err := backend.Take(context.Background(), "key", limit)
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	//? half the period refills one token
+	time.Sleep(limit.Period / 2)
+
+	_, allowed, err = backend.Take(context.Background(), "key", limit)
+	require.NoError(t, err)
+	require.True(t, allowed)
+}
+
+func TestResultHeaders(t *testing.T) {
+	result := Result{
+		Limit:      Limit{Requests: 10, Period: time.Minute},
+		Allowed:    false,
+		Remaining:  0,
+		ResetAfter: 59500 * time.Millisecond,
+		RetryAfter: 5500 * time.Millisecond,
+	}
+
+	require.Equal(t, map[string]string{
+		HeaderLimit:      "10",
+		HeaderRemaining:  "0",
+		HeaderReset:      "60",
+		HeaderPolicy:     "10;w=60",
+		HeaderRetryAfter: "6",
+	}, result.Headers())
+
+	result.Allowed = true
+	require.NotContains(t, result.Headers(), HeaderRetryAfter)
+}
+
+func TestNewLimiterFromConfig(t *testing.T) {
+	limiter, err := NewLimiterFromConfig(util.Config{RateLimitLogin: "5/1m"}, nil)
+	require.NoError(t, err)
+	require.IsType(t, &MemoryBackend{}, limiter.backend)
+	require.Equal(t, Limit{Requests: 5, Period: time.Minute}, limiter.limits[GroupLogin])
+	require.Zero(t, limiter.limits[GroupReads])
+
+	_, err = NewLimiterFromConfig(util.Config{RateLimitBackend: "etcd"}, nil)
+	require.Error(t, err)
+
+	_, err = NewLimiterFromConfig(util.Config{RateLimitTransfers: "fast"}, nil)
+	require.Error(t, err)
+}
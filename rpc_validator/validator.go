@@ -4,7 +4,16 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+	"slices"
+	"strings"
 	"unicode/utf8"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/mfa"
+	"github.com/VihangaFTW/Go-Backend/schedule"
+	"github.com/VihangaFTW/Go-Backend/statement"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
 )
 
 var (
@@ -70,3 +79,109 @@ func ValidateFullName(value string) error {
 
 	return nil
 }
+
+func ValidateCurrency(currency string) error {
+	// same currency list as the gin "currency" binding tag
+	if !util.IsSupportedCurrency(currency) {
+		return fmt.Errorf("unsupported currency: %s", currency)
+	}
+
+	return nil
+}
+
+func ValidateID(id int64) error {
+	if id < 1 {
+		return fmt.Errorf("must be a positive integer")
+	}
+
+	return nil
+}
+
+func ValidatePageID(pageID int32) error {
+	if pageID < 1 {
+		return fmt.Errorf("must be at least 1")
+	}
+
+	return nil
+}
+
+func ValidatePageSize(pageSize int32) error {
+	if pageSize < 5 || pageSize > 10 {
+		return fmt.Errorf("must be from 5 to 10")
+	}
+
+	return nil
+}
+
+func ValidateAmount(amount int64) error {
+	if amount <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+
+	return nil
+}
+
+func ValidateIdempotencyKey(key string) error {
+	return ValidateString(key, 1, 255)
+}
+
+func ValidateUUID(value string) error {
+	if _, err := uuid.Parse(value); err != nil {
+		return fmt.Errorf("must be a valid UUID")
+	}
+
+	return nil
+}
+
+func ValidateFrequency(frequency string) error {
+	if !slices.Contains(schedule.Frequencies, frequency) {
+		return fmt.Errorf("must be one of %s", strings.Join(schedule.Frequencies, ", "))
+	}
+
+	return nil
+}
+
+func ValidateStatementFormat(format string) error {
+	if format != statement.FormatCSV && format != statement.FormatOFX {
+		return fmt.Errorf("must be one of %s, %s", statement.FormatCSV, statement.FormatOFX)
+	}
+
+	return nil
+}
+
+func ValidateEmailID(value int64) error {
+	return ValidateID(value)
+}
+
+func ValidateSecretCode(value string) error {
+	return ValidateString(value, 32, 128)
+}
+
+func ValidateRole(role string) error {
+	if !util.IsSupportedRole(role) {
+		return fmt.Errorf("must be one of %s", strings.Join(util.SupportedRoles, ", "))
+	}
+
+	return nil
+}
+
+// ValidateMFACode accepts both one-time passwords and the longer recovery codes
+func ValidateMFACode(code string) error {
+	return ValidateString(code, mfa.Digits, 32)
+}
+
+func ValidateAuditAction(action string) error {
+	if !slices.Contains(db.AuditActions, action) {
+		return fmt.Errorf("must be one of %s", strings.Join(db.AuditActions, ", "))
+	}
+
+	return nil
+}
+
+func ValidateAuditResourceType(resourceType string) error {
+	if !slices.Contains(db.AuditResourceTypes, resourceType) {
+		return fmt.Errorf("must be one of %s", strings.Join(db.AuditResourceTypes, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Supported frequencies of a standing order.
+const (
+	Once    = "once"
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+	Cron    = "cron"
+)
+
+var Frequencies = []string{Once, Daily, Weekly, Monthly, Cron}
+
+var ErrInvalidFrequency = errors.New("invalid frequency")
+
+// Schedule describes when a standing order runs.
+// Daily, weekly and monthly schedules repeat at the time of day of StartAt.
+// Monthly schedules keep the day of month of StartAt, clamped to the last day of shorter months.
+type Schedule struct {
+	Frequency      string
+	CronExpression string
+	StartAt        time.Time
+}
+
+// Validate checks that the frequency is supported and that cron schedules carry a valid expression.
+func (s Schedule) Validate() error {
+	if !slices.Contains(Frequencies, s.Frequency) {
+		return fmt.Errorf("%w: %q", ErrInvalidFrequency, s.Frequency)
+	}
+
+	if s.Frequency == Cron {
+		if _, err := cron.ParseStandard(s.CronExpression); err != nil {
+			return fmt.Errorf("invalid cron expression: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// First returns the first run of the schedule.
+func (s Schedule) First() (time.Time, error) {
+	if s.Frequency != Cron {
+		return s.StartAt, s.Validate()
+	}
+
+	//? a cron schedule may run at StartAt itself
+	return s.Next(s.StartAt.Add(-time.Second))
+}
+
+// Next returns the first run strictly after the given time.
+// The zero time is returned when the schedule has no more runs.
+func (s Schedule) Next(after time.Time) (time.Time, error) {
+	if err := s.Validate(); err != nil {
+		return time.Time{}, err
+	}
+
+	if after.Before(s.StartAt) && s.Frequency != Cron {
+		return s.StartAt, nil
+	}
+
+	switch s.Frequency {
+	case Daily:
+		return s.nextEvery(after, 1), nil
+	case Weekly:
+		return s.nextEvery(after, 7), nil
+	case Monthly:
+		return s.nextMonth(after), nil
+	case Cron:
+		expr, _ := cron.ParseStandard(s.CronExpression)
+
+		if after.Before(s.StartAt) {
+			after = s.StartAt.Add(-time.Second)
+		}
+
+		return expr.Next(after), nil
+	}
+
+	return time.Time{}, nil
+}
+
+// Upcoming returns the first run at or after now, skipping any runs that are already in the past.
+// The zero time is returned when the schedule has no more runs.
+func (s Schedule) Upcoming(now time.Time) (time.Time, error) {
+	if s.StartAt.After(now) {
+		return s.First()
+	}
+
+	return s.Next(now)
+}
+
+// nextEvery returns the first run after the given time for a schedule repeating every n days.
+func (s Schedule) nextEvery(after time.Time, days int) time.Time {
+	//? estimate the number of periods and step forward, calendar days keep the time of day across DST changes
+	periods := int(after.Sub(s.StartAt).Hours()/24) / days
+
+	next := s.StartAt.AddDate(0, 0, periods*days)
+	for !next.After(after) {
+		next = next.AddDate(0, 0, days)
+	}
+
+	return next
+}
+
+// nextMonth returns the first run after the given time for a monthly schedule.
+func (s Schedule) nextMonth(after time.Time) time.Time {
+	months := (after.Year()-s.StartAt.Year())*12 + int(after.Month()-s.StartAt.Month())
+
+	next := addMonths(s.StartAt, months)
+	for !next.After(after) {
+		months++
+		next = addMonths(s.StartAt, months)
+	}
+
+	return next
+}
+
+// addMonths adds n months to t without overflowing into the following month,
+// so Jan 31 plus one month is the last day of February.
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+
+	first := time.Date(year, month+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+// IsBusinessDay reports whether transfers can be executed on the day of t.
+// Runs falling on a weekend are held until the next business day.
+func IsBusinessDay(t time.Time) bool {
+	weekday := t.Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
@@ -0,0 +1,114 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDaily(t *testing.T) {
+	start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	s := Schedule{Frequency: Daily, StartAt: start}
+
+	next, err := s.Next(start.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, start, next)
+
+	next, err = s.Next(start)
+	require.NoError(t, err)
+	require.Equal(t, start.AddDate(0, 0, 1), next)
+
+	//? missed runs collapse into the next upcoming one
+	next, err = s.Next(start.AddDate(0, 0, 10).Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, start.AddDate(0, 0, 11), next)
+}
+
+func TestNextWeekly(t *testing.T) {
+	start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	s := Schedule{Frequency: Weekly, StartAt: start}
+
+	next, err := s.Next(start.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	require.Equal(t, start.AddDate(0, 0, 7), next)
+}
+
+func TestNextMonthly(t *testing.T) {
+	start := time.Date(2025, time.January, 31, 9, 0, 0, 0, time.UTC)
+	s := Schedule{Frequency: Monthly, StartAt: start}
+
+	//? short months are clamped to their last day
+	next, err := s.Next(start)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, time.February, 28, 9, 0, 0, 0, time.UTC), next)
+
+	//? the anchor day is kept after a short month
+	next, err = s.Next(next)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, time.March, 31, 9, 0, 0, 0, time.UTC), next)
+
+	next, err = s.Next(time.Date(2025, time.April, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, time.April, 30, 9, 0, 0, 0, time.UTC), next)
+}
+
+func TestNextOnce(t *testing.T) {
+	start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	s := Schedule{Frequency: Once, StartAt: start}
+
+	first, err := s.First()
+	require.NoError(t, err)
+	require.Equal(t, start, first)
+
+	next, err := s.Next(start)
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+}
+
+func TestNextCron(t *testing.T) {
+	start := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	// 09:30 on the 1st and 15th of every month
+	s := Schedule{Frequency: Cron, CronExpression: "30 9 1,15 * *", StartAt: start}
+
+	first, err := s.First()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, time.March, 15, 9, 30, 0, 0, time.UTC), first)
+
+	next, err := s.Next(first)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, time.April, 1, 9, 30, 0, 0, time.UTC), next)
+}
+
+func TestUpcoming(t *testing.T) {
+	start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	s := Schedule{Frequency: Weekly, StartAt: start}
+
+	next, err := s.Upcoming(start.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, start, next)
+
+	next, err = s.Upcoming(start.AddDate(0, 0, 8))
+	require.NoError(t, err)
+	require.Equal(t, start.AddDate(0, 0, 14), next)
+
+	//? a one-off transfer in the past has nothing left to run
+	next, err = Schedule{Frequency: Once, StartAt: start}.Upcoming(start.Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Schedule{Frequency: Daily}.Validate())
+	require.ErrorIs(t, Schedule{Frequency: "yearly"}.Validate(), ErrInvalidFrequency)
+	require.Error(t, Schedule{Frequency: Cron, CronExpression: "not a cron"}.Validate())
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	monday := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+
+	require.True(t, IsBusinessDay(monday))
+	require.True(t, IsBusinessDay(monday.AddDate(0, 0, 4)))
+	require.False(t, IsBusinessDay(monday.AddDate(0, 0, 5)))
+	require.False(t, IsBusinessDay(monday.AddDate(0, 0, 6)))
+}
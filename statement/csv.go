@@ -0,0 +1,58 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{
+	"date",
+	"entry_id",
+	"transfer_id",
+	"counterparty_account_id",
+	"counterparty_owner",
+	"amount",
+	"balance",
+	"currency",
+}
+
+// WriteCSV renders the statement as CSV with one row per entry.
+// Amounts and balances are decimal numbers in the account currency.
+func WriteCSV(w io.Writer, statement Statement) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, line := range statement.Lines {
+		record := []string{
+			line.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatInt(line.EntryID, 10),
+			optionalID(line.TransferID),
+			optionalID(line.CounterpartyAccountID),
+			line.CounterpartyOwner,
+			formatAmount(line.Amount),
+			formatAmount(line.Balance),
+			statement.Account.Currency,
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// optionalID leaves unknown ids empty instead of writing 0.
+func optionalID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
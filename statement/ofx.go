@@ -0,0 +1,136 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" +
+		`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+	ofxTimeLayout = "20060102150405"
+	ofxBankID     = "SIMPLEBANK"
+)
+
+// the OFX 2.2 elements needed for a bank statement response
+
+type ofxDocument struct {
+	XMLName xml.Name     `xml:"OFX"`
+	SignOn  ofxSignOn    `xml:"SIGNONMSGSRSV1>SONRS"`
+	Bank    ofxStmtTrnRs `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignOn struct {
+	Status   ofxStatus `xml:"STATUS"`
+	DTServer string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxStmtTrnRs struct {
+	TrnUID string    `xml:"TRNUID"`
+	Status ofxStatus `xml:"STATUS"`
+	StmtRs ofxStmtRs `xml:"STMTRS"`
+}
+
+type ofxStmtRs struct {
+	CurDef    string       `xml:"CURDEF"`
+	BankID    string       `xml:"BANKACCTFROM>BANKID"`
+	AcctID    string       `xml:"BANKACCTFROM>ACCTID"`
+	AcctType  string       `xml:"BANKACCTFROM>ACCTTYPE"`
+	TranList  ofxTranList  `xml:"BANKTRANLIST"`
+	LedgerBal ofxLedgerBal `xml:"LEDGERBAL"`
+}
+
+type ofxTranList struct {
+	DTStart      string       `xml:"DTSTART"`
+	DTEnd        string       `xml:"DTEND"`
+	Transactions []ofxStmtTrn `xml:"STMTTRN"`
+}
+
+type ofxStmtTrn struct {
+	TrnType  string `xml:"TRNTYPE"`
+	DTPosted string `xml:"DTPOSTED"`
+	TrnAmt   string `xml:"TRNAMT"`
+	FITID    string `xml:"FITID"`
+	Name     string `xml:"NAME,omitempty"`
+	Memo     string `xml:"MEMO,omitempty"`
+}
+
+type ofxLedgerBal struct {
+	BalAmt string `xml:"BALAMT"`
+	DTAsOf string `xml:"DTASOF"`
+}
+
+// WriteOFX renders the statement as an OFX 2.2 bank statement response.
+func WriteOFX(w io.Writer, statement Statement) error {
+	ok := ofxStatus{Code: 0, Severity: "INFO"}
+
+	document := ofxDocument{
+		SignOn: ofxSignOn{
+			Status:   ok,
+			DTServer: formatOFXTime(time.Now()),
+			Language: "ENG",
+		},
+		Bank: ofxStmtTrnRs{
+			TrnUID: "0",
+			Status: ok,
+			StmtRs: ofxStmtRs{
+				CurDef:   statement.Account.Currency,
+				BankID:   ofxBankID,
+				AcctID:   strconv.FormatInt(statement.Account.ID, 10),
+				AcctType: "CHECKING",
+				TranList: ofxTranList{
+					DTStart:      formatOFXTime(statement.From),
+					DTEnd:        formatOFXTime(statement.To),
+					Transactions: make([]ofxStmtTrn, 0, len(statement.Lines)),
+				},
+				LedgerBal: ofxLedgerBal{
+					BalAmt: formatAmount(statement.ClosingBalance),
+					DTAsOf: formatOFXTime(statement.To),
+				},
+			},
+		},
+	}
+
+	for _, line := range statement.Lines {
+		transaction := ofxStmtTrn{
+			TrnType:  "CREDIT",
+			DTPosted: formatOFXTime(line.CreatedAt),
+			TrnAmt:   formatAmount(line.Amount),
+			//? entry ids are unique and stable, so importers can de-duplicate overlapping statements
+			FITID: strconv.FormatInt(line.EntryID, 10),
+			Name:  line.CounterpartyOwner,
+		}
+
+		if line.Amount < 0 {
+			transaction.TrnType = "DEBIT"
+		}
+
+		if line.TransferID != 0 {
+			transaction.Memo = fmt.Sprintf("Transfer %d", line.TransferID)
+		}
+
+		document.Bank.StmtRs.TranList.Transactions = append(document.Bank.StmtRs.TranList.Transactions, transaction)
+	}
+
+	if _, err := io.WriteString(w, ofxHeader); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(document)
+}
+
+func formatOFXTime(t time.Time) string {
+	return t.UTC().Format(ofxTimeLayout)
+}
@@ -0,0 +1,113 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+)
+
+// Supported export formats.
+const (
+	FormatCSV = "csv"
+	FormatOFX = "ofx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported statement format")
+
+// Line is a single entry of a statement.
+type Line struct {
+	EntryID    int64
+	TransferID int64
+	Amount     int64
+	// Balance is the running balance of the account after this entry.
+	Balance               int64
+	CounterpartyAccountID int64
+	CounterpartyOwner     string
+	CreatedAt             time.Time
+}
+
+// Statement is the history of an account over [From, To).
+type Statement struct {
+	Account        db.Account
+	From           time.Time
+	To             time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+	Lines          []Line
+}
+
+// New builds a statement from the result of AccountStatementTx, computing the running balance after every entry.
+// Entries recorded before their time was tracked are not listed, their amounts are part of the opening balance.
+func New(result db.AccountStatementTxResult, from time.Time, to time.Time) Statement {
+	statement := Statement{
+		Account:        result.Account,
+		From:           from,
+		To:             to,
+		OpeningBalance: result.OpeningBalance,
+		ClosingBalance: result.ClosingBalance,
+		Lines:          make([]Line, 0, len(result.Entries)),
+	}
+
+	balance := result.OpeningBalance
+
+	for _, entry := range result.Entries {
+		balance += entry.Amount
+
+		//? ListStatementEntries only returns entries with a created_at
+		statement.Lines = append(statement.Lines, Line{
+			EntryID:               entry.ID,
+			TransferID:            entry.TransferID.Int64,
+			Amount:                entry.Amount,
+			Balance:               balance,
+			CounterpartyAccountID: entry.CounterpartyAccountID,
+			CounterpartyOwner:     entry.CounterpartyOwner,
+			CreatedAt:             entry.CreatedAt.Time,
+		})
+	}
+
+	return statement
+}
+
+// Write renders the statement in the given format.
+func Write(w io.Writer, statement Statement, format string) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, statement)
+	case FormatOFX:
+		return WriteOFX(w, statement)
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+// ContentType returns the MIME type of the given format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatOFX:
+		return "application/x-ofx"
+	}
+
+	return "application/octet-stream"
+}
+
+// FileName returns a download file name for the statement.
+func FileName(statement Statement, format string) string {
+	return fmt.Sprintf("statement_%d_%s_%s.%s",
+		statement.Account.ID, statement.From.Format("20060102"), statement.To.Format("20060102"), format)
+}
+
+// formatAmount formats an amount in minor units with two decimal places, the precision of every supported currency.
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
@@ -0,0 +1,106 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomStatement(t *testing.T) Statement {
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	result := db.AccountStatementTxResult{
+		Account: db.Account{
+			ID:       util.RandomInt(1, 1000),
+			Owner:    util.RandomOwner(),
+			Balance:  1_000,
+			Currency: util.USD,
+		},
+		OpeningBalance: 10_000,
+		ClosingBalance: 10_750,
+		Entries: []db.ListStatementEntriesRow{
+			{
+				ID:                    1,
+				Amount:                1_250,
+				TransferID:            sql.NullInt64{Int64: 7, Valid: true},
+				CounterpartyAccountID: 42,
+				CounterpartyOwner:     "alice",
+				CreatedAt:             sql.NullTime{Time: from.Add(time.Hour), Valid: true},
+			},
+			{
+				ID:        2,
+				Amount:    -500,
+				CreatedAt: sql.NullTime{Time: from.Add(2 * time.Hour), Valid: true},
+			},
+		},
+	}
+
+	return New(result, from, to)
+}
+
+func TestNew(t *testing.T) {
+	statement := randomStatement(t)
+
+	require.Len(t, statement.Lines, 2)
+	//? running balance starts from the opening balance
+	require.Equal(t, int64(11_250), statement.Lines[0].Balance)
+	require.Equal(t, int64(10_750), statement.Lines[1].Balance)
+	require.Equal(t, statement.ClosingBalance, statement.Lines[1].Balance)
+	require.Equal(t, int64(7), statement.Lines[0].TransferID)
+	require.Equal(t, "alice", statement.Lines[0].CounterpartyOwner)
+}
+
+func TestWriteCSV(t *testing.T) {
+	statement := randomStatement(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, statement, FormatCSV))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	require.Equal(t, csvHeader, records[0])
+	require.Equal(t, []string{"2025-03-01T01:00:00Z", "1", "7", "42", "alice", "12.50", "112.50", util.USD}, records[1])
+	//? entries without a transfer leave the counterparty empty
+	require.Equal(t, []string{"2025-03-01T02:00:00Z", "2", "", "", "", "-5.00", "107.50", util.USD}, records[2])
+}
+
+func TestWriteOFX(t *testing.T) {
+	statement := randomStatement(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, statement, FormatOFX))
+
+	ofx := buf.String()
+	require.True(t, strings.HasPrefix(ofx, "<?xml"))
+	require.Contains(t, ofx, `<?OFX OFXHEADER="200" VERSION="220"`)
+	require.Contains(t, ofx, "<CURDEF>USD</CURDEF>")
+	require.Contains(t, ofx, "<DTSTART>20250301000000</DTSTART>")
+	require.Contains(t, ofx, "<TRNTYPE>CREDIT</TRNTYPE>")
+	require.Contains(t, ofx, "<TRNAMT>12.50</TRNAMT>")
+	require.Contains(t, ofx, "<TRNTYPE>DEBIT</TRNTYPE>")
+	require.Contains(t, ofx, "<TRNAMT>-5.00</TRNAMT>")
+	require.Contains(t, ofx, "<MEMO>Transfer 7</MEMO>")
+	require.Contains(t, ofx, "<BALAMT>107.50</BALAMT>")
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	require.ErrorIs(t, Write(&buf, randomStatement(t), "pdf"), ErrUnsupportedFormat)
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "0.00", formatAmount(0))
+	require.Equal(t, "0.05", formatAmount(5))
+	require.Equal(t, "-0.05", formatAmount(-5))
+	require.Equal(t, "1234.56", formatAmount(123_456))
+}
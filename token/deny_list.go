@@ -0,0 +1,82 @@
+package token
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DenyList remembers revoked tokens until they would have expired on their own.
+type DenyList interface {
+	// Deny rejects tokens whose ID or family ID is the given ID until expiresAt
+	Deny(ctx context.Context, id uuid.UUID, expiresAt time.Time) error
+	// IsDenied reports whether any of the IDs has been denied
+	IsDenied(ctx context.Context, ids ...uuid.UUID) (bool, error)
+	// DenyIssuedBefore rejects tokens of the user issued before the given time, until expiresAt
+	DenyIssuedBefore(ctx context.Context, username string, issuedBefore time.Time, expiresAt time.Time) error
+	// DeniedBefore returns the time before which tokens of the user are rejected, or the zero time
+	DeniedBefore(ctx context.Context, username string) (time.Time, error)
+}
+
+// MemoryDenyList is a DenyList kept in the memory of a single process.
+// It suits tests and single instance setups; anything else should share a RedisDenyList.
+type MemoryDenyList struct {
+	mutex        sync.Mutex
+	denied       map[uuid.UUID]time.Time
+	deniedBefore map[string]deniedBefore
+}
+
+type deniedBefore struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+func NewMemoryDenyList() *MemoryDenyList {
+	return &MemoryDenyList{
+		denied:       make(map[uuid.UUID]time.Time),
+		deniedBefore: make(map[string]deniedBefore),
+	}
+}
+
+func (list *MemoryDenyList) Deny(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.denied[id] = expiresAt
+	return nil
+}
+
+func (list *MemoryDenyList) IsDenied(ctx context.Context, ids ...uuid.UUID) (bool, error) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	for _, id := range ids {
+		if expiresAt, ok := list.denied[id]; ok && time.Now().Before(expiresAt) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (list *MemoryDenyList) DenyIssuedBefore(ctx context.Context, username string, issuedBefore time.Time, expiresAt time.Time) error {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.deniedBefore[username] = deniedBefore{issuedBefore: issuedBefore, expiresAt: expiresAt}
+	return nil
+}
+
+func (list *MemoryDenyList) DeniedBefore(ctx context.Context, username string) (time.Time, error) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	entry, ok := list.deniedBefore[username]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return time.Time{}, nil
+	}
+
+	return entry.issuedBefore, nil
+}
@@ -0,0 +1,169 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSAKeyBits = 2048
+
+// Errors returned while loading the keys of a JWTKeyring or verifying a token signed with one.
+var (
+	ErrInvalidPEM           = errors.New("no PEM block found")
+	ErrUnsupportedKeyType   = errors.New("unsupported key type: only Ed25519 and RSA keys can sign tokens")
+	ErrWeakRSAKey           = fmt.Errorf("rsa keys must be at least %d bits", minRSAKeyBits)
+	ErrUnknownKeyID         = errors.New("token is signed with an unknown key id")
+	ErrAlgorithmMismatch    = errors.New("token algorithm does not match the algorithm of its key")
+	ErrMissingJWTSigningKey = errors.New("unspecified signing key file for jwt tokens")
+)
+
+// jwtVerificationKey is a public key together with the only algorithm tokens signed by it may use.
+type jwtVerificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+// JWTKeyring holds the private key that signs new tokens and the public keys that tokens are still accepted from.
+// Every key is bound to the algorithm of its type, EdDSA for Ed25519 keys and RS256 for RSA keys,
+// so a token cannot choose how its signature is checked.
+type JWTKeyring struct {
+	activeKeyID      string
+	method           jwt.SigningMethod
+	signingKey       crypto.Signer
+	verificationKeys map[string]jwtVerificationKey
+}
+
+// NewJWTKeyring creates a keyring that signs tokens with a PKCS #8 PEM encoded Ed25519 or RSA private key.
+func NewJWTKeyring(activeKeyID string, privateKeyPEM []byte) (*JWTKeyring, error) {
+	if activeKeyID == "" {
+		return nil, ErrMissingKeyID
+	}
+
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse private key: %w", err)
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedKeyType
+	}
+
+	method, err := signingMethodForKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTKeyring{
+		activeKeyID: activeKeyID,
+		method:      method,
+		signingKey:  signer,
+		verificationKeys: map[string]jwtVerificationKey{
+			activeKeyID: {method: method, key: signer.Public()},
+		},
+	}, nil
+}
+
+// AddRetiredKey accepts tokens signed by a previous key, given as a PKIX PEM encoded public key.
+func (keyring *JWTKeyring) AddRetiredKey(keyID string, publicKeyPEM []byte) error {
+	if keyID == "" {
+		return ErrMissingKeyID
+	}
+
+	if _, ok := keyring.verificationKeys[keyID]; ok {
+		return ErrDuplicateKeyID
+	}
+
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return ErrInvalidPEM
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("cannot parse public key: %w", err)
+	}
+
+	method, err := signingMethodForKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	keyring.verificationKeys[keyID] = jwtVerificationKey{method: method, key: publicKey}
+	return nil
+}
+
+// LoadJWTKeyring reads the signing key and the retired public keys, keyed by key id, from PEM files.
+func LoadJWTKeyring(activeKeyID string, privateKeyFile string, retiredKeyFiles map[string]string) (*JWTKeyring, error) {
+	if privateKeyFile == "" {
+		return nil, ErrMissingJWTSigningKey
+	}
+
+	privateKeyPEM, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read signing key: %w", err)
+	}
+
+	keyring, err := NewJWTKeyring(activeKeyID, privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	for keyID, publicKeyFile := range retiredKeyFiles {
+		publicKeyPEM, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read retired key %s: %w", keyID, err)
+		}
+
+		if err := keyring.AddRetiredKey(keyID, publicKeyPEM); err != nil {
+			return nil, fmt.Errorf("retired key %s: %w", keyID, err)
+		}
+	}
+
+	return keyring, nil
+}
+
+// verificationKey picks the key named by the kid header and checks that the token uses the algorithm of that key.
+func (keyring *JWTKeyring) verificationKey(token *jwt.Token) (any, error) {
+	keyID, _ := token.Header["kid"].(string)
+
+	verificationKey, ok := keyring.verificationKeys[keyID]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	//! e.g. an HS256 token "signed" with our public key, which is no secret, must never be checked as an HMAC
+	if token.Method.Alg() != verificationKey.method.Alg() {
+		return nil, ErrAlgorithmMismatch
+	}
+
+	return verificationKey.key, nil
+}
+
+func signingMethodForKey(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, ErrWeakRSAKey
+		}
+
+		return jwt.SigningMethodRS256, nil
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 const (
@@ -13,8 +14,10 @@ const (
 )
 
 // JWTMaker is a JSON Web Token maker. It implements the Maker interface.
+// Tokens are signed with HS256 and the secret key, or with the active key of the keyring when one is set.
 type JWTMaker struct {
 	secretKey string
+	keyring   *JWTKeyring
 }
 
 // JWTPayloadClaims is a wrapper that adds the registered claims.
@@ -50,12 +53,26 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 	return &JWTMaker{secretKey: secretKey}, nil
 }
 
-func (m *JWTMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, duration)
+// NewAsymmetricJWTMaker creates a JWTMaker that signs with the active key of the keyring
+// and puts its key id in the kid header of every token.
+func NewAsymmetricJWTMaker(keyring *JWTKeyring) Maker {
+	return &JWTMaker{keyring: keyring}
+}
+
+func (m *JWTMaker) CreateToken(username string, role string, tokenType TokenType, familyID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, tokenType, familyID, duration)
 
 	if err != nil {
 		return "", payload, err
 	}
+	if m.keyring != nil {
+		jwtToken := jwt.NewWithClaims(m.keyring.method, NewJWTPayloadClaims(payload))
+		jwtToken.Header["kid"] = m.keyring.activeKeyID
+
+		signedString, err := jwtToken.SignedString(m.keyring.signingKey)
+		return signedString, payload, err
+	}
+
 	//* create an unsigned JWT token struct
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, NewJWTPayloadClaims(payload))
 	//* serialize the struct into the standard JWT string format (header.payload.signature)
@@ -63,11 +80,16 @@ func (m *JWTMaker) CreateToken(username string, duration time.Duration) (string,
 	return signedString, payload, err
 }
 
-func (m *JWTMaker) VerifyToken(token string) (*Payload, error) {
+func (m *JWTMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
 
 	// keyFunc is a callback function that jwt libary calls to verify the signing algorithm.
 	// Returns the secret key for signature verification.
 	keyFunc := func(token *jwt.Token) (any, error) {
+		//? with a keyring the key, and with it the algorithm, is chosen by the kid header
+		if m.keyring != nil {
+			return m.keyring.verificationKey(token)
+		}
+
 		// assert whether the signing method field in the given token is the expected algorithm
 		_, ok := token.Method.(*jwt.SigningMethodHMAC)
 
@@ -99,6 +121,11 @@ func (m *JWTMaker) VerifyToken(token string) (*Payload, error) {
 		return nil, jwt.ErrTokenInvalidClaims
 	}
 
+	//? a refresh token must not be accepted as an access token and vice versa
+	if err = payloadClaims.Payload.verifyType(tokenType); err != nil {
+		return nil, err
+	}
+
 	// return the extracted Payload from the verified token
 	return &payloadClaims.Payload, nil
 
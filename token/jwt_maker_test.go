@@ -1,12 +1,23 @@
 package token
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,20 +39,22 @@ func TestJWTMaker(t *testing.T) {
 
 	// Step 3: Create a JWT token using our maker
 	// This tests the CreateToken functionality
-	token, payload, err := maker.CreateToken(username, duration)
+	token, payload, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, duration)
 	require.NoError(t, err)    // Token creation should succeed
 	require.NotEmpty(t, token) // Token should not be empty string
 	require.NotEmpty(t, payload)
 
 	// Step 4: Verify the token and extract its payload
 	// This tests the VerifyToken functionality and ensures the round-trip works
-	payload, err = maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token, TokenTypeAccessToken)
 	require.NoError(t, err)      // Token verification should succeed
 	require.NotEmpty(t, payload) // Payload should not be nil
 
 	// Step 5: Validate all payload fields are correct
 	// This ensures the token contains exactly what we put into it
 	require.NotZero(t, payload.ID)                                       // ID should be generated (UUID)
+	require.Equal(t, TokenTypeAccessToken, payload.Type)                 // Type should match input
+	require.Equal(t, util.DepositorRole, payload.Role)                   // Role should match input
 	require.Equal(t, payload.Username, username)                         // Username should match input
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)   // IssuedAt should be close to our timestamp
 	require.WithinDuration(t, expiresAt, payload.ExpiresAt, time.Second) // ExpiresAt should be close to our calculated time
@@ -61,14 +74,14 @@ func TestExpiredJWTToken(t *testing.T) {
 	// Step 3: Create a token that's already expired
 	// We use -time.Minute to create a token that expired 1 minute ago
 	// This simulates a real-world scenario where a token has expired
-	token, payload, err := maker.CreateToken(username, -time.Minute)
+	token, payload, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, -time.Minute)
 	require.NoError(t, err)    // Token creation should still succeed
 	require.NotEmpty(t, token) // Token should be created (expiration is checked during verification)
 	require.NotEmpty(t, payload)
 
 	// Step 4: Try to verify the expired token
 	// This should fail because the token is expired
-	payload, err = maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token, TokenTypeAccessToken)
 	require.Error(t, err) // Verification should fail
 
 	// Step 5: Ensure we get the correct error type
@@ -87,7 +100,7 @@ func TestExpiredJWTToken(t *testing.T) {
 func TestInvalidJWTTokenAlgNone(t *testing.T) {
 	// Step 1: Create a valid payload for testing
 	// We create a legitimate payload to ensure the rejection is due to the algorithm, not the content
-	payload, err := NewPayload(util.RandomOwner(), time.Minute)
+	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
 	require.NoError(t, err) // Payload creation should succeed
 
 	// Step 2: Convert payload to JWT claims format
@@ -106,7 +119,7 @@ func TestInvalidJWTTokenAlgNone(t *testing.T) {
 
 	// Step 5: Try to verify the malicious token
 	// Our JWT maker should reject tokens with "none" algorithm
-	payload, err = maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token, TokenTypeAccessToken)
 	require.Error(t, err) // Verification should fail
 
 	// Step 6: Ensure we get the correct error type
@@ -117,3 +130,216 @@ func TestInvalidJWTTokenAlgNone(t *testing.T) {
 	// This prevents any accidental use of the unsigned token's data
 	require.Nil(t, payload)
 }
+
+// TestJWTWrongTokenType tests that access and refresh tokens cannot be used in place of each other
+// A refresh token must never authenticate a request, and an access token must never renew a session
+func TestJWTWrongTokenType(t *testing.T) {
+	maker, err := NewJWTMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+
+	// a refresh token presented as an access token
+	refreshToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeRefreshToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(refreshToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrInvalidTokenType)
+	require.Nil(t, payload)
+
+	// an access token presented as a refresh token
+	accessToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(accessToken, TokenTypeRefreshToken)
+	require.ErrorIs(t, err, ErrInvalidTokenType)
+	require.Nil(t, payload)
+
+	payload, err = maker.VerifyToken(refreshToken, TokenTypeRefreshToken)
+	require.NoError(t, err)
+	require.Equal(t, TokenTypeRefreshToken, payload.Type)
+}
+
+// newTestKeyPEMs generates a key pair and returns it in the PEM formats that JWTKeyring reads
+func newTestKeyPEMs(t *testing.T, rsaKey bool) (privateKeyPEM []byte, publicKeyPEM []byte) {
+	var privateKey crypto.Signer
+	var err error
+
+	if rsaKey {
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	} else {
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	}
+	require.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+
+	privateKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	publicKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	return
+}
+
+// TestAsymmetricJWTMaker tests that both supported algorithms sign tokens that carry the key id
+func TestAsymmetricJWTMaker(t *testing.T) {
+	testcases := []struct {
+		name   string
+		rsaKey bool
+		alg    string
+	}{
+		{name: "EdDSA", rsaKey: false, alg: "EdDSA"},
+		{name: "RS256", rsaKey: true, alg: "RS256"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			privateKeyPEM, _ := newTestKeyPEMs(t, tc.rsaKey)
+
+			keyring, err := NewJWTKeyring("2025-01", privateKeyPEM)
+			require.NoError(t, err)
+			maker := NewAsymmetricJWTMaker(keyring)
+
+			username := util.RandomOwner()
+
+			token, _, err := maker.CreateToken(username, util.BankerRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTPayloadClaims{})
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, parsed.Header["alg"])
+			require.Equal(t, "2025-01", parsed.Header["kid"])
+
+			payload, err := maker.VerifyToken(token, TokenTypeAccessToken)
+			require.NoError(t, err)
+			require.Equal(t, username, payload.Username)
+			require.Equal(t, util.BankerRole, payload.Role)
+		})
+	}
+}
+
+// TestJWTKeyRotation tests that tokens of a retired key stay valid after the signing key changes
+func TestJWTKeyRotation(t *testing.T) {
+	oldPrivateKeyPEM, oldPublicKeyPEM := newTestKeyPEMs(t, true)
+
+	oldKeyring, err := NewJWTKeyring("2025-01", oldPrivateKeyPEM)
+	require.NoError(t, err)
+
+	oldToken, _, err := NewAsymmetricJWTMaker(oldKeyring).CreateToken(util.RandomOwner(), util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	//? the new key may even use a different algorithm than the retired one
+	newPrivateKeyPEM, _ := newTestKeyPEMs(t, false)
+
+	newKeyring, err := NewJWTKeyring("2025-02", newPrivateKeyPEM)
+	require.NoError(t, err)
+	require.NoError(t, newKeyring.AddRetiredKey("2025-01", oldPublicKeyPEM))
+	require.ErrorIs(t, newKeyring.AddRetiredKey("2025-01", oldPublicKeyPEM), ErrDuplicateKeyID)
+
+	_, err = NewAsymmetricJWTMaker(newKeyring).VerifyToken(oldToken, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	//? once the retired key is dropped its tokens are rejected
+	otherKeyring, err := NewJWTKeyring("2025-02", newPrivateKeyPEM)
+	require.NoError(t, err)
+
+	_, err = NewAsymmetricJWTMaker(otherKeyring).VerifyToken(oldToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrUnknownKeyID)
+}
+
+// TestJWTAlgorithmConfusion tests that a token cannot pick a different algorithm than the one bound to its key
+func TestJWTAlgorithmConfusion(t *testing.T) {
+	privateKeyPEM, publicKeyPEM := newTestKeyPEMs(t, true)
+
+	keyring, err := NewJWTKeyring("2025-01", privateKeyPEM)
+	require.NoError(t, err)
+	maker := NewAsymmetricJWTMaker(keyring)
+
+	payload, err := NewPayload(util.RandomOwner(), util.AdminRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	//! the public key is no secret, so an HMAC "signed" with it proves nothing
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, NewJWTPayloadClaims(payload))
+	hmacToken.Header["kid"] = "2025-01"
+	forged, err := hmacToken.SignedString(publicKeyPEM)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(forged, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrAlgorithmMismatch)
+
+	noneToken := jwt.NewWithClaims(jwt.SigningMethodNone, NewJWTPayloadClaims(payload))
+	noneToken.Header["kid"] = "2025-01"
+	unsigned, err := noneToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(unsigned, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrAlgorithmMismatch)
+
+	//? a token without a kid has no key to be checked with
+	symmetricMaker, err := NewJWTMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	symmetricToken, _, err := symmetricMaker.CreateToken(util.RandomOwner(), util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(symmetricToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrUnknownKeyID)
+}
+
+// TestJWTKeyringRejectsBadKeys tests that only Ed25519 and strong RSA keys are loaded
+func TestJWTKeyringRejectsBadKeys(t *testing.T) {
+	privateKeyPEM, _ := newTestKeyPEMs(t, false)
+
+	_, err := NewJWTKeyring("", privateKeyPEM)
+	require.ErrorIs(t, err, ErrMissingKeyID)
+
+	_, err = NewJWTKeyring("2025-01", []byte("not a pem"))
+	require.ErrorIs(t, err, ErrInvalidPEM)
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	weakDER, err := x509.MarshalPKCS8PrivateKey(weakKey)
+	require.NoError(t, err)
+
+	_, err = NewJWTKeyring("2025-01", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: weakDER}))
+	require.ErrorIs(t, err, ErrWeakRSAKey)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaDER, err := x509.MarshalPKCS8PrivateKey(ecdsaKey)
+	require.NoError(t, err)
+
+	_, err = NewJWTKeyring("2025-01", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecdsaDER}))
+	require.ErrorIs(t, err, ErrUnsupportedKeyType)
+}
+
+// TestNewJWTMakerFromConfig tests that the config selects a JWT maker that reads its keys from PEM files
+func TestNewJWTMakerFromConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	privateKeyPEM, _ := newTestKeyPEMs(t, false)
+	_, retiredPublicKeyPEM := newTestKeyPEMs(t, true)
+
+	signingKeyFile := filepath.Join(dir, "signing.pem")
+	retiredKeyFile := filepath.Join(dir, "retired.pem")
+	require.NoError(t, os.WriteFile(signingKeyFile, privateKeyPEM, 0600))
+	require.NoError(t, os.WriteFile(retiredKeyFile, retiredPublicKeyPEM, 0600))
+
+	maker, err := NewMakerFromConfig(util.Config{
+		TokenMaker:               JWTMakerType,
+		JWTSigningKeyID:          "2025-02",
+		JWTSigningKeyFile:        signingKeyFile,
+		JWTRetiredPublicKeyFiles: "2025-01:" + retiredKeyFile,
+	})
+	require.NoError(t, err)
+	require.IsType(t, &JWTMaker{}, maker)
+	require.Len(t, maker.(*JWTMaker).keyring.verificationKeys, 2)
+
+	_, err = NewMakerFromConfig(util.Config{TokenMaker: JWTMakerType})
+	require.ErrorIs(t, err, ErrMissingJWTSigningKey)
+
+	_, err = NewMakerFromConfig(util.Config{TokenMaker: "macaroon"})
+	require.Error(t, err)
+}
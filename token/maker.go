@@ -1,12 +1,76 @@
 package token
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+)
 
 // Maker is an interface for managing tokens.
 type Maker interface {
-	// CreateToken creates a new token for a specific username and duration
-	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	// CreateToken creates a new token of the given type for a specific username, role, token family and duration
+	CreateToken(username string, role string, tokenType TokenType, familyID uuid.UUID, duration time.Duration) (string, *Payload, error)
+
+	// VerifyToken checks if the token is valid and of the expected type
+	VerifyToken(token string, tokenType TokenType) (*Payload, error)
+}
+
+// Token formats that can be selected with the TOKEN_MAKER config
+const (
+	PasetoMakerType = "paseto"
+	JWTMakerType    = "jwt"
+)
+
+// NewMakerFromConfig creates the token maker selected by the config.
+func NewMakerFromConfig(config util.Config) (Maker, error) {
+	switch config.TokenMaker {
+	case "", PasetoMakerType:
+		return newPasetoMakerFromConfig(config)
+	case JWTMakerType:
+		return newJWTMakerFromConfig(config)
+	default:
+		return nil, fmt.Errorf("unsupported token maker: %s", config.TokenMaker)
+	}
+}
+
+// newPasetoMakerFromConfig signs tokens with v4.public when a signing key is configured and encrypts them with v4.local otherwise.
+func newPasetoMakerFromConfig(config util.Config) (Maker, error) {
+	if config.PasetoSigningKey == "" {
+		return NewPasetoMaker(config.PasetoHexKey)
+	}
+
+	keyring, err := NewPasetoKeyring(config.PasetoSigningKeyID, config.PasetoSigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	retiredKeys, err := ParseKeyList(config.PasetoRetiredPublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	for keyID, publicKey := range retiredKeys {
+		if err := keyring.AddRetiredKey(keyID, publicKey); err != nil {
+			return nil, fmt.Errorf("retired key %s: %w", keyID, err)
+		}
+	}
+
+	return NewPasetoPublicMaker(keyring), nil
+}
+
+// newJWTMakerFromConfig signs tokens with the asymmetric key in the configured PEM file.
+func newJWTMakerFromConfig(config util.Config) (Maker, error) {
+	retiredKeyFiles, err := ParseKeyList(config.JWTRetiredPublicKeyFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring, err := LoadJWTKeyring(config.JWTSigningKeyID, config.JWTSigningKeyFile, retiredKeyFiles)
+	if err != nil {
+		return nil, err
+	}
 
-	// VerifyToken checks if the token is valid or not
-	VerifyToken(token string) (*Payload, error)
+	return NewAsymmetricJWTMaker(keyring), nil
 }
@@ -39,12 +39,12 @@ func NewPasetoMaker(hexKey string) (Maker, error) {
 	}, nil
 }
 
-func (p *PasetoMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
+func (p *PasetoMaker) CreateToken(username string, role string, tokenType TokenType, familyID uuid.UUID, duration time.Duration) (string, *Payload, error) {
 	// create the paseto token
 	token := paseto.NewToken()
 
 	// create payload (payload id is the token uuid)
-	payload, err := NewPayload(username, duration)
+	payload, err := NewPayload(username, role, tokenType, familyID, duration)
 
 	if err != nil {
 		return "", payload, err
@@ -52,7 +52,10 @@ func (p *PasetoMaker) CreateToken(username string, duration time.Duration) (stri
 
 	// add data to token
 	token.Set("id", payload.ID)
+	token.Set("token_type", tokenType)
+	token.Set("family_id", payload.FamilyID)
 	token.Set("username", username)
+	token.Set("role", role)
 	token.SetIssuedAt(payload.IssuedAt)
 	token.SetExpiration(payload.ExpiresAt)
 
@@ -60,7 +63,7 @@ func (p *PasetoMaker) CreateToken(username string, duration time.Duration) (stri
 
 }
 
-func (p *PasetoMaker) VerifyToken(token string) (*Payload, error) {
+func (p *PasetoMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
 	parser := paseto.NewParser()
 	parser.AddRule(paseto.NotExpired())
 	parsedToken, err := parser.ParseV4Local(p.symmetricKey, token, p.implicit)
@@ -75,6 +78,11 @@ func (p *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 		return nil, err
 	}
 
+	//? a refresh token must not be accepted as an access token and vice versa
+	if err = payload.verifyType(tokenType); err != nil {
+		return nil, err
+	}
+
 	return payload, nil
 }
 
@@ -85,12 +93,36 @@ func getPayloadFromToken(t *paseto.Token) (*Payload, error) {
 		return nil, ErrInvalidToken
 	}
 
+	tokenType, err := t.GetString("token_type")
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	familyIDString, err := t.GetString("family_id")
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	familyID, err := uuid.Parse(familyIDString)
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
 	username, err := t.GetString("username")
 
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
+	role, err := t.GetString("role")
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
 	issuedAt, err := t.GetIssuedAt()
 
 	if err != nil {
@@ -105,7 +137,10 @@ func getPayloadFromToken(t *paseto.Token) (*Payload, error) {
 
 	return &Payload{
 		ID:        uuid.MustParse(id),
+		Type:      TokenType(tokenType),
+		FamilyID:  familyID,
 		Username:  username,
+		Role:      role,
 		IssuedAt:  issuedAt,
 		ExpiresAt: expiresAt,
 	}, nil
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,18 +20,20 @@ func TestPasetoMaker(t *testing.T) {
 	expiresAt := issuedAt.Add(duration)
 
 	// create the paseto token
-	token,payload,err := maker.CreateToken(username, duration)
+	token,payload,err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
 	// verify token
-	payload, err = maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token, TokenTypeAccessToken)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
 	require.NotZero(t, payload.ID)
+	require.Equal(t, TokenTypeAccessToken, payload.Type)
 	require.Equal(t, username, payload.Username)
+	require.Equal(t, util.DepositorRole, payload.Role)
 	require.WithinDuration(t, payload.IssuedAt, issuedAt, time.Second)
 	require.WithinDuration(t, payload.ExpiresAt, expiresAt, time.Second)
 }
@@ -42,18 +45,45 @@ func TestExpiredPaseto(t *testing.T) {
 
 	username := util.RandomOwner()
 	// create the paseto token
-	token, payload, err := maker.CreateToken(username, -time.Minute)
+	token, payload, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, -time.Minute)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
 
 	// verify token
-	payload, err = maker.VerifyToken(token)
+	payload, err = maker.VerifyToken(token, TokenTypeAccessToken)
 	require.Error(t, err)
 	require.EqualError(t, err, ErrExpiredToken.Error())
 	require.Nil(t, payload)
 }
 
+func TestPasetoWrongTokenType(t *testing.T) {
+	maker, err := NewPasetoMaker(TestingHexKey)
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+
+	// a refresh token presented as an access token
+	refreshToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeRefreshToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(refreshToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrInvalidTokenType)
+	require.Nil(t, payload)
+
+	// an access token presented as a refresh token
+	accessToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(accessToken, TokenTypeRefreshToken)
+	require.ErrorIs(t, err, ErrInvalidTokenType)
+	require.Nil(t, payload)
+
+	payload, err = maker.VerifyToken(refreshToken, TokenTypeRefreshToken)
+	require.NoError(t, err)
+	require.Equal(t, TokenTypeRefreshToken, payload.Type)
+}
+
 func TestSKeyEnvNotSet(t *testing.T) {
 	maker, err := NewPasetoMaker("") // simulate missing key
 	require.Error(t, err)
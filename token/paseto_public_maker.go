@@ -0,0 +1,247 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/google/uuid"
+)
+
+// Errors returned while loading the keys of a PasetoPublicMaker.
+var (
+	ErrMissingKeyID       = errors.New("key id must not be empty")
+	ErrDuplicateKeyID     = errors.New("key id is already in the keyring")
+	ErrInvalidSecretKey   = errors.New("hex to ed25519 secret key conversion failed")
+	ErrInvalidPublicKey   = errors.New("hex to ed25519 public key conversion failed")
+	ErrInvalidKeyListItem = errors.New("key list items must look like <key id>:<key>")
+)
+
+// Status of a key in the keyring
+const (
+	KeyStatusActive  = "active"
+	KeyStatusRetired = "retired"
+)
+
+const pasetoPublicVersion = "v4.public"
+
+// PublicKey is a verification key that can be shared with other services.
+type PublicKey struct {
+	KeyID   string `json:"kid"`
+	Version string `json:"version"`
+	// Key is the hex encoded Ed25519 public key
+	Key    string `json:"key"`
+	Status string `json:"status"`
+}
+
+// PublicKeyPublisher is implemented by makers whose tokens can be verified without a shared secret.
+type PublicKeyPublisher interface {
+	PublicKeys() []PublicKey
+}
+
+// AsPublicKeyPublisher looks for a PublicKeyPublisher behind any decorators of the maker, such as a RevocationMaker.
+func AsPublicKeyPublisher(maker Maker) (PublicKeyPublisher, bool) {
+	for {
+		if publisher, ok := maker.(PublicKeyPublisher); ok {
+			return publisher, true
+		}
+
+		wrapper, ok := maker.(interface{ Unwrap() Maker })
+		if !ok {
+			return nil, false
+		}
+
+		maker = wrapper.Unwrap()
+	}
+}
+
+// PasetoKeyring holds the key that signs new tokens and the public keys that tokens are still accepted from.
+// Retired keys no longer sign anything, but tokens they signed stay valid until they expire,
+// so a key can be rotated without logging everyone out.
+type PasetoKeyring struct {
+	activeKeyID string
+	secretKey   paseto.V4AsymmetricSecretKey
+	publicKeys  map[string]paseto.V4AsymmetricPublicKey
+}
+
+// NewPasetoKeyring creates a keyring that signs tokens with the given hex encoded Ed25519 secret key.
+func NewPasetoKeyring(activeKeyID string, secretKeyHex string) (*PasetoKeyring, error) {
+	if activeKeyID == "" {
+		return nil, ErrMissingKeyID
+	}
+
+	secretKey, err := paseto.NewV4AsymmetricSecretKeyFromHex(secretKeyHex)
+	if err != nil {
+		return nil, ErrInvalidSecretKey
+	}
+
+	return &PasetoKeyring{
+		activeKeyID: activeKeyID,
+		secretKey:   secretKey,
+		publicKeys: map[string]paseto.V4AsymmetricPublicKey{
+			activeKeyID: secretKey.Public(),
+		},
+	}, nil
+}
+
+// AddRetiredKey accepts tokens signed by a previous key, given as a hex encoded Ed25519 public key.
+func (keyring *PasetoKeyring) AddRetiredKey(keyID string, publicKeyHex string) error {
+	if keyID == "" {
+		return ErrMissingKeyID
+	}
+
+	if _, ok := keyring.publicKeys[keyID]; ok {
+		return ErrDuplicateKeyID
+	}
+
+	publicKey, err := paseto.NewV4AsymmetricPublicKeyFromHex(publicKeyHex)
+	if err != nil {
+		return ErrInvalidPublicKey
+	}
+
+	keyring.publicKeys[keyID] = publicKey
+	return nil
+}
+
+// ParseKeyList reads a comma separated list of "<key id>:<key>" pairs, e.g. from an environment variable.
+func ParseKeyList(keyList string) (map[string]string, error) {
+	keys := make(map[string]string)
+
+	for _, item := range strings.Split(keyList, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		keyID, key, ok := strings.Cut(item, ":")
+		if !ok || keyID == "" || key == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidKeyListItem, item)
+		}
+
+		keys[keyID] = key
+	}
+
+	return keys, nil
+}
+
+// pasetoFooter is stored unencrypted but signed in every v4.public token, so the verifier can pick the right key.
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// PasetoPublicMaker signs v4.public tokens with Ed25519.
+// Unlike PasetoMaker, anyone holding the public keys can verify its tokens but only this maker can create them.
+type PasetoPublicMaker struct {
+	keyring  *PasetoKeyring
+	implicit []byte
+}
+
+// NewPasetoPublicMaker creates a maker that signs with the active key of the keyring.
+func NewPasetoPublicMaker(keyring *PasetoKeyring) Maker {
+	return &PasetoPublicMaker{
+		keyring:  keyring,
+		implicit: []byte{},
+	}
+}
+
+func (p *PasetoPublicMaker) CreateToken(username string, role string, tokenType TokenType, familyID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	token := paseto.NewToken()
+
+	payload, err := NewPayload(username, role, tokenType, familyID, duration)
+
+	if err != nil {
+		return "", payload, err
+	}
+
+	footer, err := json.Marshal(pasetoFooter{KeyID: p.keyring.activeKeyID})
+
+	if err != nil {
+		return "", payload, err
+	}
+
+	token.Set("id", payload.ID)
+	token.Set("token_type", tokenType)
+	token.Set("family_id", payload.FamilyID)
+	token.Set("username", username)
+	token.Set("role", role)
+	token.SetIssuedAt(payload.IssuedAt)
+	token.SetExpiration(payload.ExpiresAt)
+	token.SetFooter(footer)
+
+	return token.V4Sign(p.keyring.secretKey, p.implicit), payload, nil
+}
+
+func (p *PasetoPublicMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
+	parser := paseto.NewParser()
+	parser.AddRule(paseto.NotExpired())
+
+	//? the footer is read before the signature is checked, so it is only trusted to pick a key
+	rawFooter, err := parser.UnsafeParseFooter(paseto.V4Public, token)
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var footer pasetoFooter
+
+	if err = json.Unmarshal(rawFooter, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, ok := p.keyring.publicKeys[footer.KeyID]
+
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	parsedToken, err := parser.ParseV4Public(publicKey, token, p.implicit)
+
+	if err != nil {
+		return nil, ErrExpiredToken
+	}
+
+	payload, err := getPayloadFromToken(parsedToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = payload.verifyType(tokenType); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// PublicKeys lists every key that tokens are accepted from, the active key first.
+func (p *PasetoPublicMaker) PublicKeys() []PublicKey {
+	keys := make([]PublicKey, 0, len(p.keyring.publicKeys))
+
+	for keyID, publicKey := range p.keyring.publicKeys {
+		status := KeyStatusRetired
+		if keyID == p.keyring.activeKeyID {
+			status = KeyStatusActive
+		}
+
+		keys = append(keys, PublicKey{
+			KeyID:   keyID,
+			Version: pasetoPublicVersion,
+			Key:     publicKey.ExportHex(),
+			Status:  status,
+		})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Status != keys[j].Status {
+			return keys[i].Status == KeyStatusActive
+		}
+
+		return keys[i].KeyID < keys[j].KeyID
+	})
+
+	return keys
+}
@@ -0,0 +1,160 @@
+package token
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPasetoKeyring(t *testing.T, keyID string) *PasetoKeyring {
+	keyring, err := NewPasetoKeyring(keyID, paseto.NewV4AsymmetricSecretKey().ExportHex())
+	require.NoError(t, err)
+
+	return keyring
+}
+
+func TestPasetoPublicMaker(t *testing.T) {
+	maker := NewPasetoPublicMaker(newTestPasetoKeyring(t, "2025-01"))
+
+	username := util.RandomOwner()
+	duration := time.Minute
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(username, util.BankerRole, TokenTypeAccessToken, uuid.Nil, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+	require.True(t, strings.HasPrefix(token, "v4.public."))
+
+	payload, err = maker.VerifyToken(token, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, TokenTypeAccessToken, payload.Type)
+	require.Equal(t, username, payload.Username)
+	require.Equal(t, util.BankerRole, payload.Role)
+	require.WithinDuration(t, payload.IssuedAt, issuedAt, time.Second)
+	require.WithinDuration(t, payload.ExpiresAt, expiresAt, time.Second)
+
+	//? a refresh token must not be accepted as an access token
+	_, err = maker.VerifyToken(token, TokenTypeRefreshToken)
+	require.ErrorIs(t, err, ErrInvalidTokenType)
+}
+
+func TestExpiredPasetoPublic(t *testing.T) {
+	maker := NewPasetoPublicMaker(newTestPasetoKeyring(t, "2025-01"))
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, TokenTypeAccessToken, uuid.Nil, -time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token, TokenTypeAccessToken)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicKeyRotation(t *testing.T) {
+	oldKeyring := newTestPasetoKeyring(t, "2025-01")
+	oldMaker := NewPasetoPublicMaker(oldKeyring)
+
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	//? after the rotation the previous key only verifies
+	newKeyring := newTestPasetoKeyring(t, "2025-02")
+	require.NoError(t, newKeyring.AddRetiredKey("2025-01", oldKeyring.secretKey.Public().ExportHex()))
+	newMaker := NewPasetoPublicMaker(newKeyring)
+
+	_, err = newMaker.VerifyToken(oldToken, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	newToken, _, err := newMaker.CreateToken(util.RandomOwner(), util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = newMaker.VerifyToken(newToken, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	//! a maker that never learned about the new key must not accept its tokens
+	_, err = oldMaker.VerifyToken(newToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	keys := newMaker.(PublicKeyPublisher).PublicKeys()
+	require.Len(t, keys, 2)
+	require.Equal(t, "2025-02", keys[0].KeyID)
+	require.Equal(t, KeyStatusActive, keys[0].Status)
+	require.Equal(t, newKeyring.secretKey.Public().ExportHex(), keys[0].Key)
+	require.Equal(t, "2025-01", keys[1].KeyID)
+	require.Equal(t, KeyStatusRetired, keys[1].Status)
+	require.Equal(t, "v4.public", keys[1].Version)
+}
+
+func TestPasetoPublicForgedKeyID(t *testing.T) {
+	maker := NewPasetoPublicMaker(newTestPasetoKeyring(t, "2025-01"))
+
+	//? an attacker signing with their own key cannot pass it off as ours by reusing our key id
+	attacker := NewPasetoPublicMaker(newTestPasetoKeyring(t, "2025-01"))
+
+	token, _, err := attacker.CreateToken(util.RandomOwner(), util.AdminRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(token, TokenTypeAccessToken)
+	require.Error(t, err)
+
+	//? a symmetric token has no key id to look up
+	localMaker, err := NewPasetoMaker(TestingHexKey)
+	require.NoError(t, err)
+
+	localToken, _, err := localMaker.CreateToken(util.RandomOwner(), util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(localToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPasetoKeyring(t *testing.T) {
+	_, err := NewPasetoKeyring("", paseto.NewV4AsymmetricSecretKey().ExportHex())
+	require.ErrorIs(t, err, ErrMissingKeyID)
+
+	_, err = NewPasetoKeyring("2025-01", "not hex")
+	require.ErrorIs(t, err, ErrInvalidSecretKey)
+
+	keyring := newTestPasetoKeyring(t, "2025-01")
+	publicKey := paseto.NewV4AsymmetricSecretKey().Public().ExportHex()
+
+	require.ErrorIs(t, keyring.AddRetiredKey("2025-01", publicKey), ErrDuplicateKeyID)
+	require.ErrorIs(t, keyring.AddRetiredKey("2024-12", "not hex"), ErrInvalidPublicKey)
+	require.NoError(t, keyring.AddRetiredKey("2024-12", publicKey))
+}
+
+func TestParseKeyList(t *testing.T) {
+	keys, err := ParseKeyList(" 2024-11:aa11, 2024-12:bb22 ,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"2024-11": "aa11", "2024-12": "bb22"}, keys)
+
+	keys, err = ParseKeyList("")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	_, err = ParseKeyList("2024-11")
+	require.ErrorIs(t, err, ErrInvalidKeyListItem)
+}
+
+func TestNewMakerFromConfig(t *testing.T) {
+	maker, err := NewMakerFromConfig(util.Config{PasetoHexKey: TestingHexKey})
+	require.NoError(t, err)
+	require.IsType(t, &PasetoMaker{}, maker)
+
+	retired := paseto.NewV4AsymmetricSecretKey().Public().ExportHex()
+
+	maker, err = NewMakerFromConfig(util.Config{
+		PasetoSigningKeyID:      "2025-02",
+		PasetoSigningKey:        paseto.NewV4AsymmetricSecretKey().ExportHex(),
+		PasetoRetiredPublicKeys: "2025-01:" + retired,
+	})
+	require.NoError(t, err)
+	require.Len(t, maker.(PublicKeyPublisher).PublicKeys(), 2)
+}
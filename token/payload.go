@@ -14,18 +14,37 @@ var (
 	ErrMissingPasetoEnvVariable = errors.New("unspecified environment variable for symmetric encryption")
 	ErrFailedSKeyConversion     = errors.New("hex to symmetric key conversion failed")
 	ErrInvalidKeySize           = errors.New("invalid key size: must be 64 hex characters (32 bytes)")
+	ErrInvalidTokenType         = errors.New("token type is invalid")
+	ErrRevokedToken             = errors.New("token has been revoked")
+)
+
+// TokenType tells access tokens and refresh tokens apart, so neither can be used in place of the other
+type TokenType string
+
+const (
+	TokenTypeAccessToken  TokenType = "access"
+	TokenTypeRefreshToken TokenType = "refresh"
+	// TokenTypeMFAChallenge is issued after the password check of a user with two-factor authentication,
+	// and can only be exchanged for the real tokens together with a valid code
+	TokenTypeMFAChallenge TokenType = "mfa_challenge"
 )
 
 // Payload contains the payload data of the token
+// FamilyID identifies the login the token was issued for. Every token renewed from that login shares it,
+// so all of them can be revoked together when the login is signed out.
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
+	Type      TokenType `json:"token_type"`
+	FamilyID  uuid.UUID `json:"family_id"`
 	Username  string    `json:"username"`
+	Role      string    `json:"role"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiresAt time.Time `json:"expired_at"`
 }
 
-// NewPayload creates a new token payload with a specific username and duration
-func NewPayload(username string, duration time.Duration) (*Payload, error) {
+// NewPayload creates a new token payload with a specific username, role, token type, family and duration
+// A zero family ID starts a new family named after the token itself.
+func NewPayload(username string, role string, tokenType TokenType, familyID uuid.UUID, duration time.Duration) (*Payload, error) {
 
 	tokenID, err := uuid.NewRandom()
 
@@ -33,12 +52,28 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 		return nil, err
 	}
 
+	if familyID == uuid.Nil {
+		familyID = tokenID
+	}
+
 	payload := &Payload{
 		ID:        tokenID,
+		Type:      tokenType,
+		FamilyID:  familyID,
 		Username:  username,
+		Role:      role,
 		IssuedAt:  time.Now(),
 		ExpiresAt: time.Now().Add(duration),
 	}
 
 	return payload, nil
 }
+
+// verifyType checks that a verified token is of the type the caller expects
+func (payload *Payload) verifyType(tokenType TokenType) error {
+	if payload.Type != tokenType {
+		return ErrInvalidTokenType
+	}
+
+	return nil
+}
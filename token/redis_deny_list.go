@@ -0,0 +1,73 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	deniedTokenKeyPrefix  = "token:denied:"
+	deniedBeforeKeyPrefix = "token:denied_before:"
+)
+
+// RedisDenyList is a DenyList shared by every server instance through Redis.
+// Each entry expires together with the last token it rejects, so the list never outgrows the live tokens.
+type RedisDenyList struct {
+	client redis.UniversalClient
+}
+
+func NewRedisDenyList(client redis.UniversalClient) *RedisDenyList {
+	return &RedisDenyList{client: client}
+}
+
+func (list *RedisDenyList) Deny(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+
+	//? the tokens have already expired, so there is nothing left to reject
+	if ttl <= 0 {
+		return nil
+	}
+
+	return list.client.Set(ctx, deniedTokenKeyPrefix+id.String(), 1, ttl).Err()
+}
+
+func (list *RedisDenyList) IsDenied(ctx context.Context, ids ...uuid.UUID) (bool, error) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, deniedTokenKeyPrefix+id.String())
+	}
+
+	count, err := list.client.Exists(ctx, keys...).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (list *RedisDenyList) DenyIssuedBefore(ctx context.Context, username string, issuedBefore time.Time, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+
+	if ttl <= 0 {
+		return nil
+	}
+
+	return list.client.Set(ctx, deniedBeforeKeyPrefix+username, issuedBefore.Unix(), ttl).Err()
+}
+
+func (list *RedisDenyList) DeniedBefore(ctx context.Context, username string) (time.Time, error) {
+	unix, err := list.client.Get(ctx, deniedBeforeKeyPrefix+username).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, err
+	}
+
+	return time.Unix(unix, 0), nil
+}
@@ -0,0 +1,99 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revoker revokes tokens before they expire.
+type Revoker interface {
+	// RevokeFamily rejects every token issued for a login, e.g. once its session is blocked.
+	// expiresAt is when the last token of the family expires anyway, usually the session expiry.
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, expiresAt time.Time) error
+	// RevokeIssuedBefore rejects every token of the user issued before the given time, e.g. their password change.
+	RevokeIssuedBefore(ctx context.Context, username string, issuedBefore time.Time) error
+}
+
+// DenyListRevoker revokes tokens by adding them to a DenyList, where they are kept until they would have expired on their own.
+// It suits processes that revoke tokens without issuing or verifying any, e.g. the task processor.
+type DenyListRevoker struct {
+	denyList DenyList
+	// maxTokenDuration is the lifetime of the longest lived tokens, which bounds how long entries are kept
+	maxTokenDuration time.Duration
+}
+
+// NewDenyListRevoker returns a Revoker backed by the deny list.
+// maxTokenDuration must be at least the lifetime of any token issued, i.e. the refresh token duration.
+func NewDenyListRevoker(denyList DenyList, maxTokenDuration time.Duration) *DenyListRevoker {
+	return &DenyListRevoker{
+		denyList:         denyList,
+		maxTokenDuration: maxTokenDuration,
+	}
+}
+
+// RevocationMaker decorates a Maker so that VerifyToken also rejects tokens that have been revoked.
+type RevocationMaker struct {
+	Maker
+	*DenyListRevoker
+}
+
+// NewRevocationMaker wraps the maker with the deny list.
+// maxTokenDuration must be at least the lifetime of any token the maker creates, i.e. the refresh token duration.
+func NewRevocationMaker(maker Maker, denyList DenyList, maxTokenDuration time.Duration) *RevocationMaker {
+	return &RevocationMaker{
+		Maker:           maker,
+		DenyListRevoker: NewDenyListRevoker(denyList, maxTokenDuration),
+	}
+}
+
+func (m *RevocationMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
+	payload, err := m.Maker.VerifyToken(token, tokenType)
+
+	if err != nil {
+		return nil, err
+	}
+
+	//? VerifyToken has no context of its own, the lookups are bounded by the Redis client timeouts instead
+	ctx := context.Background()
+
+	//! a failed lookup rejects the token, as it might have been revoked
+	denied, err := m.denyList.IsDenied(ctx, payload.ID, payload.FamilyID)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot check token revocation: %w", err)
+	}
+
+	if denied {
+		return nil, ErrRevokedToken
+	}
+
+	deniedBefore, err := m.denyList.DeniedBefore(ctx, payload.Username)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot check token revocation: %w", err)
+	}
+
+	//? token timestamps only keep whole seconds, so a token issued right after a password change is still accepted
+	if payload.IssuedAt.Before(deniedBefore.Truncate(time.Second)) {
+		return nil, ErrRevokedToken
+	}
+
+	return payload, nil
+}
+
+// Unwrap returns the decorated maker.
+func (m *RevocationMaker) Unwrap() Maker {
+	return m.Maker
+}
+
+func (m *DenyListRevoker) RevokeFamily(ctx context.Context, familyID uuid.UUID, expiresAt time.Time) error {
+	return m.denyList.Deny(ctx, familyID, expiresAt)
+}
+
+func (m *DenyListRevoker) RevokeIssuedBefore(ctx context.Context, username string, issuedBefore time.Time) error {
+	//? no token issued before this time can outlive the longest token duration
+	return m.denyList.DenyIssuedBefore(ctx, username, issuedBefore, issuedBefore.Add(m.maxTokenDuration))
+}
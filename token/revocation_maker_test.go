@@ -0,0 +1,116 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRevocationMaker(t *testing.T) *RevocationMaker {
+	maker, err := NewPasetoMaker(TestingHexKey)
+	require.NoError(t, err)
+
+	return NewRevocationMaker(maker, NewMemoryDenyList(), time.Hour)
+}
+
+func TestTokenFamily(t *testing.T) {
+	maker := newTestRevocationMaker(t)
+	username := util.RandomOwner()
+
+	//? a refresh token created without a family starts its own
+	refreshToken, refreshPayload, err := maker.CreateToken(username, util.DepositorRole, TokenTypeRefreshToken, uuid.Nil, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, refreshPayload.ID, refreshPayload.FamilyID)
+
+	accessToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, refreshPayload.FamilyID, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(refreshToken, TokenTypeRefreshToken)
+	require.NoError(t, err)
+	require.Equal(t, refreshPayload.FamilyID, payload.FamilyID)
+
+	payload, err = maker.VerifyToken(accessToken, TokenTypeAccessToken)
+	require.NoError(t, err)
+	require.Equal(t, refreshPayload.FamilyID, payload.FamilyID)
+	require.NotEqual(t, payload.ID, payload.FamilyID)
+}
+
+func TestRevokeFamily(t *testing.T) {
+	maker := newTestRevocationMaker(t)
+	ctx := context.Background()
+	username := util.RandomOwner()
+
+	_, refreshPayload, err := maker.CreateToken(username, util.DepositorRole, TokenTypeRefreshToken, uuid.Nil, time.Hour)
+	require.NoError(t, err)
+
+	accessToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, refreshPayload.FamilyID, time.Minute)
+	require.NoError(t, err)
+
+	otherToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, maker.RevokeFamily(ctx, refreshPayload.FamilyID, refreshPayload.ExpiresAt))
+
+	payload, err := maker.VerifyToken(accessToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrRevokedToken)
+	require.Nil(t, payload)
+
+	//? tokens of other logins are not affected
+	_, err = maker.VerifyToken(otherToken, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	//? an entry past its expiry no longer rejects anything
+	_, otherPayload, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, maker.RevokeFamily(ctx, otherPayload.FamilyID, time.Now().Add(-time.Second)))
+
+	denied, err := maker.denyList.IsDenied(ctx, otherPayload.FamilyID)
+	require.NoError(t, err)
+	require.False(t, denied)
+}
+
+func TestRevocationMakerPublicKeys(t *testing.T) {
+	_, ok := AsPublicKeyPublisher(newTestRevocationMaker(t))
+	require.False(t, ok)
+
+	maker := NewRevocationMaker(NewPasetoPublicMaker(newTestPasetoKeyring(t, "2025-01")), NewMemoryDenyList(), time.Hour)
+
+	publisher, ok := AsPublicKeyPublisher(maker)
+	require.True(t, ok)
+	require.Len(t, publisher.PublicKeys(), 1)
+}
+
+func TestRevokeIssuedBefore(t *testing.T) {
+	maker := newTestRevocationMaker(t)
+	ctx := context.Background()
+	username := util.RandomOwner()
+
+	oldToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	//? token timestamps keep whole seconds, so the cutoff has to be at least a second later
+	require.NoError(t, maker.RevokeIssuedBefore(ctx, username, time.Now().Add(time.Second)))
+
+	_, err = maker.VerifyToken(oldToken, TokenTypeAccessToken)
+	require.ErrorIs(t, err, ErrRevokedToken)
+
+	//? other users keep their tokens
+	otherToken, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(otherToken, TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	//? a login right after the password change is accepted
+	require.NoError(t, maker.RevokeIssuedBefore(ctx, username, time.Now()))
+
+	newToken, _, err := maker.CreateToken(username, util.DepositorRole, TokenTypeAccessToken, uuid.Nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(newToken, TokenTypeAccessToken)
+	require.NoError(t, err)
+}
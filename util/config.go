@@ -18,10 +18,29 @@ type Config struct {
 	HTTPServerAddress string `mapstructure:"HTTP_SERVER_ADDRESS"`
 	GRPCServerAddress string `mapstructure:"GRPC_SERVER_ADDRESS"`
 
+	// TokenMaker selects the token format, "paseto" (the default) or "jwt"
+	TokenMaker string `mapstructure:"TOKEN_MAKER"`
+
 	PasetoHexKey         string        `mapstructure:"PASETO_SYMMETRIC_KEY"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
 
+	// When a signing key is set, tokens are signed with it instead of being encrypted with the symmetric key.
+	// Retired public keys are "<key id>:<hex key>" pairs separated by commas.
+	PasetoSigningKeyID      string `mapstructure:"PASETO_SIGNING_KEY_ID"`
+	PasetoSigningKey        string `mapstructure:"PASETO_SIGNING_KEY"`
+	PasetoRetiredPublicKeys string `mapstructure:"PASETO_RETIRED_PUBLIC_KEYS"`
+
+	// JWTs are signed with a PKCS #8 PEM private key file. Retired public keys are "<key id>:<PEM file>" pairs separated by commas.
+	JWTSigningKeyID          string `mapstructure:"JWT_SIGNING_KEY_ID"`
+	JWTSigningKeyFile        string `mapstructure:"JWT_SIGNING_KEY_FILE"`
+	JWTRetiredPublicKeyFiles string `mapstructure:"JWT_RETIRED_PUBLIC_KEY_FILES"`
+
+	// MFAEncryptionKey is the 64 hex character key TOTP secrets are encrypted with
+	MFAEncryptionKey     string        `mapstructure:"MFA_ENCRYPTION_KEY"`
+	MFAIssuer            string        `mapstructure:"MFA_ISSUER"`
+	MFAChallengeDuration time.Duration `mapstructure:"MFA_CHALLENGE_DURATION"`
+
 	RedisAddress string `mapstructure:"REDIS_ADDRESS"`
 
 	EmailSenderName     string `mapstructure:"EMAIL_SENDER_NAME"`
@@ -29,6 +48,41 @@ type Config struct {
 	EmailSenderPassword string `mapstructure:"EMAIL_SENDER_PASSWORD"`
 
 	EmailTestRecipient string `mapstructure:"EMAIL_TEST_RECIPIENT"`
+
+	VerifyEmailURL string `mapstructure:"VERIFY_EMAIL_URL"`
+	// PasswordResetURL is the page password reset emails link to, with the token in the "token" query parameter
+	PasswordResetURL string `mapstructure:"PASSWORD_RESET_URL"`
+
+	FXRatesFile           string        `mapstructure:"FX_RATES_FILE"`
+	FXRateRefreshInterval time.Duration `mapstructure:"FX_RATE_REFRESH_INTERVAL"`
+	FXSpreadBps           int64         `mapstructure:"FX_SPREAD_BPS"`
+	FXQuoteDuration       time.Duration `mapstructure:"FX_QUOTE_DURATION"`
+
+	ScheduledTransferPollInterval time.Duration `mapstructure:"SCHEDULED_TRANSFER_POLL_INTERVAL"`
+
+	// Holds last HoldDuration unless the request asks for an earlier expiry, and never longer than HoldMaxDuration.
+	// Expired holds are released by the worker every HoldExpiryInterval.
+	HoldDuration       time.Duration `mapstructure:"HOLD_DURATION"`
+	HoldMaxDuration    time.Duration `mapstructure:"HOLD_MAX_DURATION"`
+	HoldExpiryInterval time.Duration `mapstructure:"HOLD_EXPIRY_INTERVAL"`
+
+	// LedgerCheckSchedule is the cron spec of the check that recomputes every balance from the entries, empty turns it off.
+	// The report of a check that finds problems is emailed to the addresses of LedgerAlertEmails, separated by commas.
+	LedgerCheckSchedule string `mapstructure:"LEDGER_CHECK_SCHEDULE"`
+	LedgerAlertEmails   string `mapstructure:"LEDGER_ALERT_EMAILS"`
+
+	// Background tasks are written to the outbox table and relayed to Redis by the worker.
+	// Relayed rows are deleted once they are older than the retention.
+	OutboxPollInterval time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	OutboxRetention    time.Duration `mapstructure:"OUTBOX_RETENTION"`
+
+	// RateLimitBackend selects where the rate limits are counted, "memory" (the default) or "redis".
+	// Each limit is "<requests>/<period>", e.g. "10/1m", and an empty limit turns it off.
+	RateLimitBackend   string `mapstructure:"RATE_LIMIT_BACKEND"`
+	RateLimitLogin     string `mapstructure:"RATE_LIMIT_LOGIN"`
+	RateLimitTransfers string `mapstructure:"RATE_LIMIT_TRANSFERS"`
+	RateLimitReads     string `mapstructure:"RATE_LIMIT_READS"`
+	RateLimitDefault   string `mapstructure:"RATE_LIMIT_DEFAULT"`
 }
 
 // LoadConfig is responsible for loading the configuration from a file or env variable
@@ -1,17 +1,37 @@
 package util
 
+import (
+	"math/big"
+	"slices"
+)
+
 const (
 	USD = "USD"
 	EUR = "EUR"
 	CAD = "CAD"
 )
 
+// SupportedCurrencies lists every currency an account can hold.
+var SupportedCurrencies = []string{USD, EUR, CAD}
+
 // IsSupportedCurrency returns true if the currency is supported
 func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, CAD:
-		return true
-	default:
-		return false
-	}
+	return slices.Contains(SupportedCurrencies, currency)
+}
+
+// FXRateScale is the fixed point scale of exchange rates stored as integers.
+// A rate of 1.25 is stored as 125_000_000.
+const FXRateScale = 100_000_000
+
+// ConvertAmount converts an amount in minor units with a scaled exchange rate, rounding down.
+func ConvertAmount(amount int64, rate int64) int64 {
+	//? big.Int avoids overflowing int64 on amount * rate before scaling back down
+	converted := new(big.Int).Mul(big.NewInt(amount), big.NewInt(rate))
+	converted.Quo(converted, big.NewInt(FXRateScale))
+	return converted.Int64()
+}
+
+// ApplySpread lowers a mid-market rate by a spread given in basis points (1 bps = 0.01%).
+func ApplySpread(rate int64, spreadBps int64) int64 {
+	return rate * (10_000 - spreadBps) / 10_000
 }
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSupportedCurrency(t *testing.T) {
+	for _, currency := range SupportedCurrencies {
+		require.True(t, IsSupportedCurrency(currency))
+	}
+
+	require.False(t, IsSupportedCurrency("XYZ"))
+	require.False(t, IsSupportedCurrency(""))
+}
+
+func TestConvertAmount(t *testing.T) {
+	// 1.25 rate
+	require.Equal(t, int64(125), ConvertAmount(100, 125_000_000))
+	//? fractional minor units are rounded down
+	require.Equal(t, int64(1), ConvertAmount(1, 125_000_000))
+	require.Equal(t, int64(0), ConvertAmount(1, 80_000_000))
+	//? large amounts do not overflow while scaling
+	require.Equal(t, int64(2_000_000_000_000), ConvertAmount(1_000_000_000_000, 200_000_000))
+}
+
+func TestApplySpread(t *testing.T) {
+	require.Equal(t, int64(99_500_000), ApplySpread(100_000_000, 50))
+	require.Equal(t, int64(100_000_000), ApplySpread(100_000_000, 0))
+}
@@ -20,3 +20,16 @@ func CheckPassword(password string, hashedPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 
 }
+
+// missingUserHash is the bcrypt hash of a random password that was thrown away
+const missingUserHash = "$2a$10$NjiZNVf7/ZXSs2PVkjzOruLQbXflY1R19MHEe/T4zrs0Yq46eVjSW"
+
+// CheckMissingUserPassword takes as long as CheckPassword but always fails.
+// Logins for usernames that do not exist call it, so they cannot be told apart from wrong passwords by timing.
+func CheckMissingUserPassword(password string) error {
+	if err := CheckPassword(password, missingUserHash); err != nil {
+		return err
+	}
+
+	return bcrypt.ErrMismatchedHashAndPassword
+}
@@ -29,3 +29,8 @@ func TestPassword(t *testing.T) {
 	require.NotEqual(t, hashedPassword1, hashedPassword2)
 
 }
+
+func TestCheckMissingUserPassword(t *testing.T) {
+	err := CheckMissingUserPassword(RandomString(6))
+	require.ErrorIs(t, err, bcrypt.ErrMismatchedHashAndPassword)
+}
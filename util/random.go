@@ -40,9 +40,8 @@ func RandomMoney() int64 {
 
 // generate a random currency
 func RandomCurrency() string {
-	currencies := []string{USD, EUR, CAD}
-	n := len(currencies)
-	return currencies[rand.Intn(n)]
+	n := len(SupportedCurrencies)
+	return SupportedCurrencies[rand.Intn(n)]
 }
 
 // RandomAccountId generates a random account id from 1 to 27
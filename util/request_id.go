@@ -0,0 +1,28 @@
+package util
+
+import (
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header that carries the ID matching a request with its log lines and audit events.
+const RequestIDHeader = "X-Request-Id"
+
+// maxRequestIDLength keeps clients from filling the logs and the audit log with arbitrary data
+const maxRequestIDLength = 128
+
+// RequestID returns the request ID sent by a client or a proxy in front of the server,
+// or a new random one when none or an unusable one was sent.
+func RequestID(sent string) string {
+	if sent == "" || len(sent) > maxRequestIDLength {
+		return uuid.NewString()
+	}
+
+	for _, r := range sent {
+		//? only printable ASCII, so the ID cannot break log lines or headers
+		if r < 0x21 || r > 0x7e {
+			return uuid.NewString()
+		}
+	}
+
+	return sent
+}
@@ -0,0 +1,22 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	require.Equal(t, "req-123", RequestID("req-123"))
+
+	for _, sent := range []string{"", "with space", "line\nbreak", strings.Repeat("a", maxRequestIDLength+1)} {
+		id := RequestID(sent)
+
+		_, err := uuid.Parse(id)
+		require.NoError(t, err, "sent: %q", sent)
+	}
+
+	require.NotEqual(t, RequestID(""), RequestID(""))
+}
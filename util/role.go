@@ -0,0 +1,20 @@
+package util
+
+import "slices"
+
+const (
+	// DepositorRole is given to every user who signs up. Depositors only see their own accounts.
+	DepositorRole = "depositor"
+	// BankerRole can read any account and freeze or unfreeze it.
+	BankerRole = "banker"
+	// AdminRole can manage users, including their roles.
+	AdminRole = "admin"
+)
+
+// SupportedRoles lists every role a user can hold.
+var SupportedRoles = []string{DepositorRole, BankerRole, AdminRole}
+
+// IsSupportedRole returns true if the role is supported
+func IsSupportedRole(role string) bool {
+	return slices.Contains(SupportedRoles, role)
+}
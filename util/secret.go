@@ -0,0 +1,28 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// RandomSecret generates a URL safe secret from n cryptographically secure random bytes.
+// Unlike the Random* test helpers it is safe to use for codes that are sent to users.
+func RandomSecret(n int) (string, error) {
+	buf := make([]byte, n)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashSecret returns the SHA-256 hex digest a secret is stored and looked up by.
+// Secrets from RandomSecret are random enough that a fast hash is safe, unlike passwords.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
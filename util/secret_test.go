@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomSecret(t *testing.T) {
+	secret1, err := RandomSecret(32)
+	require.NoError(t, err)
+	// 32 bytes in unpadded base64
+	require.Len(t, secret1, 43)
+
+	secret2, err := RandomSecret(32)
+	require.NoError(t, err)
+	require.NotEqual(t, secret1, secret2)
+}
+
+func TestHashSecret(t *testing.T) {
+	secret, err := RandomSecret(32)
+	require.NoError(t, err)
+
+	hash := HashSecret(secret)
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, HashSecret(secret))
+	require.NotContains(t, hash, secret)
+	require.NotEqual(t, hash, HashSecret(secret+"x"))
+}
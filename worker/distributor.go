@@ -6,10 +6,12 @@ import (
 	"github.com/hibiken/asynq"
 )
 
+// TaskDistributor enqueues tasks into Redis right away. Tasks that belong to a database change
+// are written to the outbox with the change instead, see OutboxRelay.
 type TaskDistributor interface {
-	DistributeTaskSendVerifyEmail(
+	DistributeTaskExecuteScheduledTransfer(
 		ctx context.Context,
-		payload *PayloadSendVerifyEmail,
+		payload *PayloadExecuteScheduledTransfer,
 		opts ...asynq.Option,
 	) error
 }
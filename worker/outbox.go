@@ -0,0 +1,183 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// outboxBatchSize is the number of messages a relay hands to asynq in one transaction
+	outboxBatchSize = 100
+	// outboxCleanupInterval is how often dispatched messages past the retention are deleted
+	outboxCleanupInterval = time.Hour
+	// defaultMaxRetry is asynq's default, used when a task does not set its own
+	defaultMaxRetry = 25
+	// outboxMaxAttempts is the number of failed hand-overs after which a message is given up on
+	outboxMaxAttempts = 25
+	// outboxRetryDelay is the wait after the first failed hand-over, doubled after every further failure
+	outboxRetryDelay = time.Second
+	// outboxMaxRetryDelay caps the wait between two attempts
+	outboxMaxRetryDelay = time.Hour
+)
+
+// newOutboxMessage turns a task into an outbox row. The asynq options are stored in columns,
+// so only the options the relay can restore are accepted.
+func newOutboxMessage(taskType string, payload any, opts ...asynq.Option) (db.CreateOutboxMessageParams, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return db.CreateOutboxMessageParams{}, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	message := db.CreateOutboxMessageParams{
+		TaskType:  taskType,
+		Payload:   jsonPayload,
+		Queue:     QueueDefault,
+		MaxRetry:  defaultMaxRetry,
+		ProcessAt: time.Now(),
+	}
+
+	for _, opt := range opts {
+		switch opt.Type() {
+		case asynq.MaxRetryOpt:
+			message.MaxRetry = int32(opt.Value().(int))
+		case asynq.QueueOpt:
+			message.Queue = opt.Value().(string)
+		case asynq.ProcessInOpt:
+			message.ProcessAt = time.Now().Add(opt.Value().(time.Duration))
+		case asynq.ProcessAtOpt:
+			message.ProcessAt = opt.Value().(time.Time)
+		case asynq.TaskIDOpt:
+			message.TaskID = opt.Value().(string)
+		default:
+			return db.CreateOutboxMessageParams{}, fmt.Errorf("unsupported outbox task option %s", opt)
+		}
+	}
+
+	return message, nil
+}
+
+// outboxTask restores the task of an outbox row. A row without a task ID gets one derived from its row ID,
+// so a row that is relayed again after a failed commit is not queued twice while the first task is pending.
+func outboxTask(message db.Outbox) *asynq.Task {
+	taskID := message.TaskID
+	if taskID == "" {
+		taskID = fmt.Sprintf("outbox:%d", message.ID)
+	}
+
+	return asynq.NewTask(message.TaskType, message.Payload,
+		asynq.Queue(message.Queue),
+		asynq.MaxRetry(int(message.MaxRetry)),
+		asynq.ProcessAt(message.ProcessAt),
+		asynq.TaskID(taskID),
+	)
+}
+
+// taskEnqueuer is the part of the asynq client the relay uses.
+type taskEnqueuer interface {
+	EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+// OutboxRelay polls the outbox and hands pending tasks to asynq. Every worker instance may run one,
+// the rows being relayed are locked so each is handed over by a single relay at a time.
+type OutboxRelay struct {
+	store        db.Store
+	client       taskEnqueuer
+	pollInterval time.Duration
+	retention    time.Duration
+}
+
+func NewOutboxRelay(redisOpt asynq.RedisClientOpt, store db.Store, pollInterval time.Duration, retention time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		store:        store,
+		client:       asynq.NewClient(redisOpt),
+		pollInterval: pollInterval,
+		retention:    retention,
+	}
+}
+
+// Start relays the outbox until the context is cancelled. A full batch is followed by the next one right away,
+// otherwise the relay waits for the poll interval.
+func (relay *OutboxRelay) Start(ctx context.Context) error {
+	var lastCleanup time.Time
+
+	for {
+		result, err := relay.Relay(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to relay outbox")
+		}
+
+		if time.Since(lastCleanup) >= outboxCleanupInterval {
+			if _, err := relay.Cleanup(ctx); err != nil {
+				log.Error().Err(err).Msg("failed to clean up outbox")
+			}
+
+			lastCleanup = time.Now()
+		}
+
+		//? with a full batch there may be more rows waiting, unless they all failed because asynq is unavailable
+		if err == nil && result.Dispatched == outboxBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(relay.pollInterval):
+		}
+	}
+}
+
+// Relay hands one batch of pending messages to asynq.
+func (relay *OutboxRelay) Relay(ctx context.Context) (db.RelayOutboxTxResult, error) {
+	return relay.store.RelayOutboxTx(ctx, db.RelayOutboxTxParams{
+		Limit:       outboxBatchSize,
+		RetryDelay:  outboxBackoff,
+		MaxAttempts: outboxMaxAttempts,
+		Dispatch: func(message db.Outbox) error {
+			task := outboxTask(message)
+
+			_, err := relay.client.EnqueueContext(ctx, task)
+
+			//? the task was queued by an earlier relay whose commit failed
+			if errors.Is(err, asynq.ErrTaskIDConflict) {
+				return nil
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to enqueue task into redis queue: %w", err)
+			}
+
+			log.Info().
+				Str("type", task.Type()).
+				Bytes("payload", task.Payload()).
+				Str("queue", message.Queue).
+				Int64("outbox_id", message.ID).
+				Msg("enqueued task")
+
+			return nil
+		},
+	})
+}
+
+// outboxBackoff returns the exponential wait after the attempts-th failed hand-over of a message.
+func outboxBackoff(attempts int32) time.Duration {
+	delay := outboxRetryDelay
+
+	for i := int32(1); i < attempts && delay < outboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, outboxMaxRetryDelay)
+}
+
+// Cleanup deletes the messages that were dispatched longer than the retention ago.
+func (relay *OutboxRelay) Cleanup(ctx context.Context) (int64, error) {
+	return relay.store.DeleteDispatchedOutboxMessages(ctx, time.Now().Add(-relay.retention))
+}
@@ -0,0 +1,219 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeEnqueuer struct {
+	tasks []*asynq.Task
+	err   error
+	// failType fails only the tasks of this type, when err is nil
+	failType string
+}
+
+func (enqueuer *fakeEnqueuer) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if enqueuer.err != nil {
+		return nil, enqueuer.err
+	}
+
+	if task.Type() == enqueuer.failType {
+		return nil, errors.New("task rejected")
+	}
+
+	enqueuer.tasks = append(enqueuer.tasks, task)
+	return &asynq.TaskInfo{}, nil
+}
+
+func TestNewOutboxMessage(t *testing.T) {
+	payload := &PayloadSendVerifyEmail{Username: "alice"}
+
+	message, err := newOutboxMessage(TaskSendVerifyEmail, payload)
+	require.NoError(t, err)
+	require.Equal(t, TaskSendVerifyEmail, message.TaskType)
+	require.Equal(t, QueueDefault, message.Queue)
+	require.Equal(t, int32(defaultMaxRetry), message.MaxRetry)
+	require.WithinDuration(t, time.Now(), message.ProcessAt, time.Second)
+	require.Empty(t, message.TaskID)
+
+	var decoded PayloadSendVerifyEmail
+	require.NoError(t, json.Unmarshal(message.Payload, &decoded))
+	require.Equal(t, *payload, decoded)
+
+	message, err = newOutboxMessage(TaskSendVerifyEmail, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue(QueueCritical),
+		asynq.ProcessIn(time.Minute),
+		asynq.TaskID("verify:alice"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), message.MaxRetry)
+	require.Equal(t, QueueCritical, message.Queue)
+	require.WithinDuration(t, time.Now().Add(time.Minute), message.ProcessAt, time.Second)
+	require.Equal(t, "verify:alice", message.TaskID)
+
+	processAt := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	message, err = newOutboxMessage(TaskSendVerifyEmail, payload, asynq.ProcessAt(processAt))
+	require.NoError(t, err)
+	require.Equal(t, processAt, message.ProcessAt)
+
+	_, err = newOutboxMessage(TaskSendVerifyEmail, payload, asynq.Unique(time.Minute))
+	require.Error(t, err)
+}
+
+func TestOutboxTask(t *testing.T) {
+	message := db.Outbox{
+		ID:        42,
+		TaskType:  TaskSendVerifyEmail,
+		Payload:   []byte(`{"username":"alice"}`),
+		Queue:     QueueCritical,
+		MaxRetry:  3,
+		ProcessAt: time.Now(),
+	}
+
+	task := outboxTask(message)
+	require.Equal(t, message.TaskType, task.Type())
+	require.Equal(t, []byte(message.Payload), task.Payload())
+}
+
+func TestOutboxRelay(t *testing.T) {
+	messages := []db.Outbox{
+		{ID: 1, TaskType: TaskSendVerifyEmail, Payload: []byte(`{}`), Queue: QueueCritical, MaxRetry: 10, ProcessAt: time.Now()},
+		{ID: 2, TaskType: TaskSendLockoutEmail, Payload: []byte(`{}`), Queue: QueueDefault, MaxRetry: 25, ProcessAt: time.Now()},
+	}
+
+	testCases := []struct {
+		name           string
+		enqueueErr     error
+		wantDispatched int
+		wantFailed     int
+		wantEnqueued   int
+	}{
+		{
+			name:           "OK",
+			wantDispatched: 2,
+			wantEnqueued:   2,
+		},
+		{
+			name:           "AlreadyQueued",
+			enqueueErr:     asynq.ErrTaskIDConflict,
+			wantDispatched: 2,
+		},
+		{
+			name:       "RedisUnavailable",
+			enqueueErr: errors.New("connection refused"),
+			wantFailed: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+
+			store.EXPECT().
+				RelayOutboxTx(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, arg db.RelayOutboxTxParams) (db.RelayOutboxTxResult, error) {
+					require.Equal(t, int32(outboxBatchSize), arg.Limit)
+
+					var result db.RelayOutboxTxResult
+					for _, message := range messages {
+						if err := arg.Dispatch(message); err != nil {
+							result.Failed++
+							continue
+						}
+						result.Dispatched++
+					}
+					return result, nil
+				})
+
+			enqueuer := &fakeEnqueuer{err: tc.enqueueErr}
+			relay := &OutboxRelay{store: store, client: enqueuer}
+
+			result, err := relay.Relay(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.wantDispatched, result.Dispatched)
+			require.Equal(t, tc.wantFailed, result.Failed)
+			require.Len(t, enqueuer.tasks, tc.wantEnqueued)
+		})
+	}
+}
+
+func TestOutboxRelayFailingMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	//? the failing message comes first, as it would if it were the oldest pending row
+	messages := []db.Outbox{
+		{ID: 1, TaskType: TaskSendLockoutEmail, Payload: []byte(`{}`), Queue: QueueDefault, MaxRetry: 25, ProcessAt: time.Now(), Attempts: 3},
+		{ID: 2, TaskType: TaskSendVerifyEmail, Payload: []byte(`{}`), Queue: QueueCritical, MaxRetry: 10, ProcessAt: time.Now()},
+	}
+
+	store.EXPECT().
+		RelayOutboxTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.RelayOutboxTxParams) (db.RelayOutboxTxResult, error) {
+			require.Equal(t, int32(outboxMaxAttempts), arg.MaxAttempts)
+
+			var result db.RelayOutboxTxResult
+			for _, message := range messages {
+				if err := arg.Dispatch(message); err != nil {
+					result.Failed++
+
+					//? the failed message is held back before it is tried again
+					require.Positive(t, arg.RetryDelay(message.Attempts+1))
+					continue
+				}
+				result.Dispatched++
+			}
+			return result, nil
+		})
+
+	enqueuer := &fakeEnqueuer{failType: TaskSendLockoutEmail}
+	relay := &OutboxRelay{store: store, client: enqueuer}
+
+	result, err := relay.Relay(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Dispatched)
+	require.Equal(t, 1, result.Failed)
+	require.Len(t, enqueuer.tasks, 1)
+	require.Equal(t, TaskSendVerifyEmail, enqueuer.tasks[0].Type())
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	require.Equal(t, outboxRetryDelay, outboxBackoff(1))
+	require.Equal(t, 2*outboxRetryDelay, outboxBackoff(2))
+	require.Equal(t, 8*outboxRetryDelay, outboxBackoff(4))
+	require.Equal(t, outboxMaxRetryDelay, outboxBackoff(outboxMaxAttempts))
+}
+
+func TestOutboxRelayCleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	retention := 24 * time.Hour
+
+	store.EXPECT().
+		DeleteDispatchedOutboxMessages(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, dispatchedBefore time.Time) (int64, error) {
+			require.WithinDuration(t, time.Now().Add(-retention), dispatchedBefore, time.Second)
+			return 3, nil
+		})
+
+	relay := &OutboxRelay{store: store, retention: retention}
+
+	deleted, err := relay.Cleanup(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), deleted)
+}
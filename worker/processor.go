@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/mail"
+	"github.com/VihangaFTW/Go-Backend/token"
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
@@ -19,14 +21,40 @@ const (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail) error
+	ProcessTaskEnqueueDueScheduledTransfers(ctx context.Context) error
+	ProcessTaskExecuteScheduledTransfer(ctx context.Context, payload *PayloadExecuteScheduledTransfer) error
+	ProcessTaskGenerateStatementExport(ctx context.Context, payload *PayloadGenerateStatementExport) error
+	ProcessTaskSendPasswordResetEmail(ctx context.Context, payload *PayloadSendPasswordResetEmail) error
+	ProcessTaskRevokeUserTokens(ctx context.Context, payload *PayloadRevokeUserTokens) error
+	ProcessTaskVerifyLedger(ctx context.Context) error
+	ProcessTaskExpireHolds(ctx context.Context) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
+	server      *asynq.Server
+	store       db.Store
+	distributor TaskDistributor
+	mailer      mail.EmailSender
+	// verifyEmailURL is the VerifyEmail gateway route linked from verification emails.
+	verifyEmailURL string
+	// passwordResetURL is the page linked from password reset emails, which submits the token to ResetPassword.
+	passwordResetURL string
+	// ledgerAlertEmails receive the report of a ledger check that found problems.
+	ledgerAlertEmails []string
+	// tokenRevoker rejects the tokens of users who changed their password.
+	tokenRevoker token.Revoker
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskProcessor {
+func NewRedisTaskProcessor(
+	redisOpt asynq.RedisClientOpt,
+	store db.Store,
+	distributor TaskDistributor,
+	mailer mail.EmailSender,
+	verifyEmailURL string,
+	passwordResetURL string,
+	ledgerAlertEmails []string,
+	tokenRevoker token.Revoker,
+) TaskProcessor {
 
 	logger := NewLogger()
 	redis.SetLogger(logger)
@@ -50,8 +78,14 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskPr
 	)
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
+		server:            server,
+		store:             store,
+		distributor:       distributor,
+		mailer:            mailer,
+		verifyEmailURL:    verifyEmailURL,
+		passwordResetURL:  passwordResetURL,
+		ledgerAlertEmails: ledgerAlertEmails,
+		tokenRevoker:      tokenRevoker,
 	}
 }
 
@@ -68,5 +102,62 @@ func (processor *RedisTaskProcessor) Start() error {
 		return processor.ProcessTaskSendVerifyEmail(ctx, &payload)
 	})
 
+	mux.HandleFunc(TaskEnqueueDueScheduledTransfers, func(ctx context.Context, task *asynq.Task) error {
+		return processor.ProcessTaskEnqueueDueScheduledTransfers(ctx)
+	})
+
+	mux.HandleFunc(TaskExecuteScheduledTransfer, func(ctx context.Context, task *asynq.Task) error {
+		var payload PayloadExecuteScheduledTransfer
+
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", fmt.Errorf("%w: %v", asynq.SkipRetry, err))
+		}
+		return processor.ProcessTaskExecuteScheduledTransfer(ctx, &payload)
+	})
+
+	mux.HandleFunc(TaskGenerateStatementExport, func(ctx context.Context, task *asynq.Task) error {
+		var payload PayloadGenerateStatementExport
+
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", fmt.Errorf("%w: %v", asynq.SkipRetry, err))
+		}
+		return processor.ProcessTaskGenerateStatementExport(ctx, &payload)
+	})
+
+	mux.HandleFunc(TaskSendPasswordResetEmail, func(ctx context.Context, task *asynq.Task) error {
+		var payload PayloadSendPasswordResetEmail
+
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", fmt.Errorf("%w: %v", asynq.SkipRetry, err))
+		}
+		return processor.ProcessTaskSendPasswordResetEmail(ctx, &payload)
+	})
+
+	mux.HandleFunc(TaskRevokeUserTokens, func(ctx context.Context, task *asynq.Task) error {
+		var payload PayloadRevokeUserTokens
+
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", fmt.Errorf("%w: %v", asynq.SkipRetry, err))
+		}
+		return processor.ProcessTaskRevokeUserTokens(ctx, &payload)
+	})
+
+	mux.HandleFunc(TaskSendLockoutEmail, func(ctx context.Context, task *asynq.Task) error {
+		var payload PayloadSendLockoutEmail
+
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", fmt.Errorf("%w: %v", asynq.SkipRetry, err))
+		}
+		return processor.ProcessTaskSendLockoutEmail(ctx, &payload)
+	})
+
+	mux.HandleFunc(TaskVerifyLedger, func(ctx context.Context, task *asynq.Task) error {
+		return processor.ProcessTaskVerifyLedger(ctx)
+	})
+
+	mux.HandleFunc(TaskExpireHolds, func(ctx context.Context, task *asynq.Task) error {
+		return processor.ProcessTaskExpireHolds(ctx)
+	})
+
 	return processor.server.Start(mux)
 }
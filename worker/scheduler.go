@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// TaskScheduler enqueues periodic tasks for the task processor.
+type TaskScheduler interface {
+	Start() error
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+	interval  time.Duration
+	// holdExpiryInterval is how often expired holds are released.
+	holdExpiryInterval time.Duration
+	// ledgerCheckSpec is the cron spec of the ledger check, an empty spec turns it off.
+	ledgerCheckSpec string
+}
+
+// NewRedisTaskScheduler creates a scheduler that checks for due standing orders at the given interval,
+// releases expired holds at the hold expiry interval and verifies the ledger on the given cron spec.
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, interval time.Duration, holdExpiryInterval time.Duration, ledgerCheckSpec string) TaskScheduler {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+	})
+
+	return &RedisTaskScheduler{
+		scheduler:          scheduler,
+		interval:           interval,
+		holdExpiryInterval: holdExpiryInterval,
+		ledgerCheckSpec:    ledgerCheckSpec,
+	}
+}
+
+func (taskScheduler *RedisTaskScheduler) Start() error {
+	//? every instance registers the same entry, the task id of each run stops duplicate ticks from enqueueing a standing order twice
+	task := asynq.NewTask(TaskEnqueueDueScheduledTransfers, nil, asynq.Queue(QueueCritical), asynq.MaxRetry(0))
+
+	_, err := taskScheduler.scheduler.Register(fmt.Sprintf("@every %s", taskScheduler.interval), task)
+	if err != nil {
+		return fmt.Errorf("failed to register periodic task: %w", err)
+	}
+
+	//? a run that overlaps another only finds the holds the other one has not locked, so duplicate ticks are harmless
+	task = asynq.NewTask(TaskExpireHolds, nil, asynq.Queue(QueueDefault), asynq.MaxRetry(0))
+
+	_, err = taskScheduler.scheduler.Register(fmt.Sprintf("@every %s", taskScheduler.holdExpiryInterval), task)
+	if err != nil {
+		return fmt.Errorf("failed to register hold expiry: %w", err)
+	}
+
+	if taskScheduler.ledgerCheckSpec != "" {
+		//? every instance registers the check as well, the unique lock lets only one of them enqueue it per run
+		task := asynq.NewTask(TaskVerifyLedger, nil, asynq.Queue(QueueDefault), asynq.MaxRetry(3), asynq.Unique(time.Hour))
+
+		if _, err = taskScheduler.scheduler.Register(taskScheduler.ledgerCheckSpec, task); err != nil {
+			return fmt.Errorf("failed to register ledger check: %w", err)
+		}
+	}
+
+	return taskScheduler.scheduler.Start()
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+// TaskExpireHolds is registered with the periodic scheduler and releases the holds whose expiry time has passed.
+const TaskExpireHolds = "task:expire_holds"
+
+// expireHoldsBatch limits how many holds are expired in one transaction.
+const expireHoldsBatch = 100
+
+// ProcessTaskExpireHolds releases expired holds batch by batch until none are left.
+// Holds that are being captured or released at the same time are skipped and picked up by the next run if they are still active.
+func (processor *RedisTaskProcessor) ProcessTaskExpireHolds(ctx context.Context) error {
+	expired := 0
+
+	for {
+		result, err := processor.store.ExpireHoldsTx(ctx, db.ExpireHoldsTxParams{Limit: expireHoldsBatch})
+		if err != nil {
+			return fmt.Errorf("failed to expire holds: %w", err)
+		}
+
+		for _, hold := range result.Holds {
+			log.Info().
+				Int64("hold_id", hold.ID).
+				Int64("account_id", hold.AccountID).
+				Int64("amount", hold.Amount).
+				Msg("expired hold")
+		}
+
+		expired += len(result.Holds)
+
+		if len(result.Holds) < expireHoldsBatch {
+			break
+		}
+	}
+
+	if expired > 0 {
+		log.Info().
+			Int("expired", expired).
+			Msg("processed task")
+	}
+
+	return nil
+}
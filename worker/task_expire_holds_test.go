@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessTaskExpireHolds(t *testing.T) {
+	fullBatch := make([]db.Hold, expireHoldsBatch)
+	for i := range fullBatch {
+		fullBatch[i] = db.Hold{ID: int64(i + 1), AccountID: 1, Amount: 10, Status: db.HoldExpired}
+	}
+
+	testCases := []struct {
+		name       string
+		batches    [][]db.Hold
+		storeErr   error
+		wantCalls  int
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name:      "NothingExpired",
+			batches:   [][]db.Hold{{}},
+			wantCalls: 1,
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "FullBatchRunsAgain",
+			batches:   [][]db.Hold{fullBatch, {{ID: 101, AccountID: 2, Amount: 10, Status: db.HoldExpired}}},
+			wantCalls: 2,
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "StoreError",
+			storeErr:  sql.ErrConnDone,
+			wantCalls: 1,
+			checkError: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, sql.ErrConnDone))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+
+			calls := 0
+			store.EXPECT().
+				ExpireHoldsTx(gomock.Any(), gomock.Eq(db.ExpireHoldsTxParams{Limit: expireHoldsBatch})).
+				Times(tc.wantCalls).
+				DoAndReturn(func(ctx context.Context, arg db.ExpireHoldsTxParams) (db.ExpireHoldsTxResult, error) {
+					defer func() { calls++ }()
+
+					if tc.storeErr != nil {
+						return db.ExpireHoldsTxResult{}, tc.storeErr
+					}
+					return db.ExpireHoldsTxResult{Holds: tc.batches[calls]}, nil
+				})
+
+			processor := &RedisTaskProcessor{store: store}
+
+			err := processor.ProcessTaskExpireHolds(context.Background())
+			tc.checkError(t, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/statement"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskGenerateStatementExport = "task:generate_statement_export"
+
+type PayloadGenerateStatementExport struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// OutboxTaskGenerateStatementExport returns the outbox message of the task, to be written within the transaction of the change it belongs to.
+func OutboxTaskGenerateStatementExport(payload *PayloadGenerateStatementExport, opts ...asynq.Option) (db.CreateOutboxMessageParams, error) {
+	return newOutboxMessage(TaskGenerateStatementExport, payload, opts...)
+}
+
+// ProcessTaskGenerateStatementExport renders a pending statement export and stores the file for download.
+// The export is marked as failed once the last retry fails.
+func (processor *RedisTaskProcessor) ProcessTaskGenerateStatementExport(ctx context.Context, payload *PayloadGenerateStatementExport) error {
+	export, err := processor.store.GetStatementExport(ctx, payload.ExportID)
+
+	if err != nil {
+		//? the outbox only relays the task once the export is committed, so a missing export will not show up on a retry
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("statement export not found: %w", fmt.Errorf("%w: %v", asynq.SkipRetry, err))
+		}
+		return fmt.Errorf("failed to get statement export: %w", err)
+	}
+
+	if export.Status != db.StatementExportPending {
+		return nil
+	}
+
+	content, renderErr := renderStatement(ctx, processor.store, export)
+
+	if renderErr != nil {
+		retryCount, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+		if retryCount < maxRetry {
+			return fmt.Errorf("failed to render statement: %w", renderErr)
+		}
+	}
+
+	arg := db.CompleteStatementExportParams{
+		ID:      export.ID,
+		Status:  db.StatementExportReady,
+		Content: content,
+	}
+
+	if renderErr != nil {
+		arg.Status = db.StatementExportFailed
+		arg.Content = []byte{}
+		arg.Error = renderErr.Error()
+	}
+
+	if _, err = processor.store.CompleteStatementExport(ctx, arg); err != nil {
+		return fmt.Errorf("failed to complete statement export: %w", err)
+	}
+
+	log.Info().
+		Str("export_id", export.ID.String()).
+		Str("status", arg.Status).
+		Int("size", len(content)).
+		Msg("processed task")
+
+	return nil
+}
+
+func renderStatement(ctx context.Context, store db.Store, export db.StatementExport) ([]byte, error) {
+	result, err := store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: export.AccountID,
+		FromTime:  export.FromTime,
+		ToTime:    export.ToTime,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	err = statement.Write(&buf, statement.New(result, export.FromTime, export.ToTime), export.Format)
+
+	return buf.Bytes(), err
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessTaskGenerateStatementExportNotFound(t *testing.T) {
+	testCases := []struct {
+		name       string
+		storeErr   error
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			//? the task is only relayed after the export is committed, so it is gone for good
+			name:     "NotFound",
+			storeErr: sql.ErrNoRows,
+			checkError: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, asynq.SkipRetry))
+			},
+		},
+		{
+			name:     "StoreError",
+			storeErr: sql.ErrConnDone,
+			checkError: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, sql.ErrConnDone))
+				require.False(t, errors.Is(err, asynq.SkipRetry))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+
+			payload := &PayloadGenerateStatementExport{ExportID: uuid.New()}
+
+			store.EXPECT().GetStatementExport(gomock.Any(), gomock.Eq(payload.ExportID)).Times(1).Return(db.StatementExport{}, tc.storeErr)
+			store.EXPECT().CompleteStatementExport(gomock.Any(), gomock.Any()).Times(0)
+
+			processor := &RedisTaskProcessor{store: store}
+
+			err := processor.ProcessTaskGenerateStatementExport(context.Background(), payload)
+			tc.checkError(t, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskRevokeUserTokens = "task:revoke_user_tokens"
+
+// PayloadRevokeUserTokens names the user whose password changed.
+type PayloadRevokeUserTokens struct {
+	Username string `json:"username"`
+}
+
+// OutboxTaskRevokeUserTokens returns the outbox message of the task, to be written within the transaction that changes the password.
+func OutboxTaskRevokeUserTokens(payload *PayloadRevokeUserTokens, opts ...asynq.Option) (db.CreateOutboxMessageParams, error) {
+	return newOutboxMessage(TaskRevokeUserTokens, payload, opts...)
+}
+
+// ProcessTaskRevokeUserTokens rejects every token of the user issued before their last password change.
+// The cutoff is read from the user rather than the payload, so a late or repeated task never moves it back.
+func (processor *RedisTaskProcessor) ProcessTaskRevokeUserTokens(ctx context.Context, payload *PayloadRevokeUserTokens) error {
+	user, err := processor.store.GetUser(ctx, payload.Username)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user not found: %w", fmt.Errorf("%w: %v", asynq.SkipRetry, err))
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err = processor.tokenRevoker.RevokeIssuedBefore(ctx, user.Username, user.PasswordChangedAt); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	log.Info().
+		Str("username", user.Username).
+		Time("issued_before", user.PasswordChangedAt).
+		Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/token"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessTaskRevokeUserTokens(t *testing.T) {
+	user := randomUser()
+	user.PasswordChangedAt = time.Now().Truncate(time.Second)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	//? the cutoff comes from the user, so a late task for an earlier change revokes up to the latest one
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+
+	denyList := token.NewMemoryDenyList()
+	processor := &RedisTaskProcessor{store: store, tokenRevoker: token.NewDenyListRevoker(denyList, time.Hour)}
+
+	err := processor.ProcessTaskRevokeUserTokens(context.Background(), &PayloadRevokeUserTokens{Username: user.Username})
+	require.NoError(t, err)
+
+	deniedBefore, err := denyList.DeniedBefore(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.True(t, user.PasswordChangedAt.Equal(deniedBefore))
+}
+
+func TestProcessTaskRevokeUserTokensUnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrNoRows)
+
+	processor := &RedisTaskProcessor{store: store, tokenRevoker: token.NewDenyListRevoker(token.NewMemoryDenyList(), time.Hour)}
+
+	//? there is nobody left to sign out, retrying will not change that
+	err := processor.ProcessTaskRevokeUserTokens(context.Background(), &PayloadRevokeUserTokens{Username: "alice"})
+	require.True(t, errors.Is(err, asynq.SkipRetry))
+}
@@ -0,0 +1,162 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/schedule"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// TaskEnqueueDueScheduledTransfers is registered with the periodic scheduler and fans out one task per due standing order.
+	TaskEnqueueDueScheduledTransfers = "task:enqueue_due_scheduled_transfers"
+	TaskExecuteScheduledTransfer     = "task:execute_scheduled_transfer"
+)
+
+const (
+	// ScheduledTransferMaxRetry caps how many times a failed run is retried before it is recorded as failed.
+	ScheduledTransferMaxRetry = 3
+	// dueScheduledTransfersBatch limits how many standing orders are enqueued per tick.
+	dueScheduledTransfersBatch = 100
+)
+
+type PayloadExecuteScheduledTransfer struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledFor        time.Time `json:"scheduled_for"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskExecuteScheduledTransfer(
+	ctx context.Context,
+	payload *PayloadExecuteScheduledTransfer,
+	opts ...asynq.Option,
+) error {
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskExecuteScheduledTransfer, jsonPayload, opts...)
+
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task into redis queue: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskEnqueueDueScheduledTransfers enqueues an execute task for every active standing order whose next run is due.
+// Nothing is enqueued outside business days, so weekend runs are executed on the next business day.
+func (processor *RedisTaskProcessor) ProcessTaskEnqueueDueScheduledTransfers(ctx context.Context) error {
+	now := time.Now()
+
+	if !schedule.IsBusinessDay(now) {
+		return nil
+	}
+
+	scheduledTransfers, err := processor.store.ListDueScheduledTransfers(ctx, db.ListDueScheduledTransfersParams{
+		Now:        now,
+		LimitCount: dueScheduledTransfersBatch,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to list due scheduled transfers: %w", err)
+	}
+
+	for _, scheduledTransfer := range scheduledTransfers {
+		payload := &PayloadExecuteScheduledTransfer{
+			ScheduledTransferID: scheduledTransfer.ID,
+			ScheduledFor:        scheduledTransfer.NextRunAt,
+		}
+
+		opts := []asynq.Option{
+			asynq.MaxRetry(ScheduledTransferMaxRetry),
+			asynq.Queue(QueueCritical),
+			//? one task per run: a run still being retried is not enqueued again on the next tick
+			asynq.TaskID(fmt.Sprintf("scheduled_transfer:%d:%d", scheduledTransfer.ID, scheduledTransfer.NextRunAt.Unix())),
+		}
+
+		//? enqueued directly rather than through the outbox: the worker depends on Redis anyway,
+		//? and there is no database change to commit along with the task
+		err := processor.distributor.DistributeTaskExecuteScheduledTransfer(ctx, payload, opts...)
+
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessTaskExecuteScheduledTransfer executes one run of a standing order and records its outcome.
+// A failed run is retried by asynq up to ScheduledTransferMaxRetry times before the standing order moves on.
+func (processor *RedisTaskProcessor) ProcessTaskExecuteScheduledTransfer(ctx context.Context, payload *PayloadExecuteScheduledTransfer) error {
+	//? the key ties the audit event of the transfer to the standing order and the run
+	requestID := fmt.Sprintf("scheduled_transfer:%d:%d", payload.ScheduledTransferID, payload.ScheduledFor.Unix())
+
+	retryCount, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+	result, err := processor.store.ExecuteScheduledRunTx(ctx, db.ExecuteScheduledRunTxParams{
+		ScheduledTransferID: payload.ScheduledTransferID,
+		ScheduledFor:        payload.ScheduledFor,
+		Attempt:             int32(retryCount + 1),
+		FinalAttempt:        retryCount >= maxRetry,
+		NextRunAt: func(scheduledTransfer db.ScheduledTransfer) (time.Time, error) {
+			s := schedule.Schedule{
+				Frequency:      scheduledTransfer.Frequency,
+				CronExpression: scheduledTransfer.CronExpression,
+				StartAt:        scheduledTransfer.StartAt,
+			}
+
+			//? runs missed while the worker was down or over a weekend collapse into this one
+			nextRunAt, err := s.Next(time.Now())
+			if err != nil {
+				return time.Time{}, fmt.Errorf("failed to compute next run: %w", fmt.Errorf("%w: %v", asynq.SkipRetry, err))
+			}
+
+			return nextRunAt, nil
+		},
+		//? the run is the job's doing, not the owner's
+		Audit: db.AuditContext{
+			Actor:     db.AuditActorSystem,
+			RequestID: requestID,
+		},
+	})
+
+	//? the run was already settled, or the owner paused, cancelled or rescheduled the standing order after the task was enqueued
+	if errors.Is(err, db.ErrScheduledRunSettled) {
+		log.Info().
+			Int64("scheduled_transfer_id", payload.ScheduledTransferID).
+			Time("scheduled_for", payload.ScheduledFor).
+			Err(err).
+			Msg("skipped scheduled transfer run")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to execute scheduled transfer: %w", err)
+	}
+
+	log.Info().
+		Int64("scheduled_transfer_id", payload.ScheduledTransferID).
+		Int64("transfer_id", result.Transfer.Transfer.ID).
+		Msg("processed task")
+
+	return nil
+}
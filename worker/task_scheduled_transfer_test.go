@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessTaskExecuteScheduledTransfer(t *testing.T) {
+	scheduledFor := time.Now().Truncate(time.Second)
+
+	payload := &PayloadExecuteScheduledTransfer{
+		ScheduledTransferID: 7,
+		ScheduledFor:        scheduledFor,
+	}
+
+	scheduledTransfer := db.ScheduledTransfer{
+		ID:        payload.ScheduledTransferID,
+		Frequency: "daily",
+		StartAt:   scheduledFor,
+		NextRunAt: scheduledFor,
+		Status:    db.ScheduledTransferActive,
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledRunTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.ExecuteScheduledRunTxParams) (db.ExecuteScheduledRunTxResult, error) {
+						require.Equal(t, payload.ScheduledTransferID, arg.ScheduledTransferID)
+						require.True(t, payload.ScheduledFor.Equal(arg.ScheduledFor))
+						require.Equal(t, int32(1), arg.Attempt)
+						require.Equal(t, db.AuditActorSystem, arg.Audit.Actor)
+						require.Equal(t, fmt.Sprintf("scheduled_transfer:%d:%d", payload.ScheduledTransferID, scheduledFor.Unix()), arg.Audit.RequestID)
+
+						//? the next run is computed from the standing order as the transaction sees it
+						nextRunAt, err := arg.NextRunAt(scheduledTransfer)
+						require.NoError(t, err)
+						require.True(t, nextRunAt.After(scheduledFor))
+
+						return db.ExecuteScheduledRunTxResult{
+							Transfer: db.TransferTxResult{Transfer: db.Transfer{ID: 1}},
+						}, nil
+					})
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "PausedBeforeRun",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledRunTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledRunTxResult{}, fmt.Errorf("%w: scheduled transfer [7] is paused", db.ErrScheduledRunSettled))
+			},
+			checkError: func(t *testing.T, err error) {
+				//* the standing order was locked and re-checked, so no money moved and there is nothing to retry
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "CancelledBeforeRun",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledRunTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledRunTxResult{}, fmt.Errorf("%w: scheduled transfer [7] is cancelled", db.ErrScheduledRunSettled))
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledRunTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledRunTxResult{
+						Run: db.ScheduledTransferRun{Status: db.ScheduledTransferRunFailed},
+					}, db.ErrInsufficientFunds)
+			},
+			checkError: func(t *testing.T, err error) {
+				//? the failed run is recorded, asynq retries it
+				require.True(t, errors.Is(err, db.ErrInsufficientFunds))
+			},
+		},
+		{
+			name: "InvalidSchedule",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledRunTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.ExecuteScheduledRunTxParams) (db.ExecuteScheduledRunTxResult, error) {
+						invalid := scheduledTransfer
+						invalid.Frequency = "fortnightly"
+
+						_, err := arg.NextRunAt(invalid)
+						return db.ExecuteScheduledRunTxResult{}, err
+					})
+			},
+			checkError: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, asynq.SkipRetry))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			processor := &RedisTaskProcessor{store: store}
+
+			err := processor.ProcessTaskExecuteScheduledTransfer(context.Background(), payload)
+			tc.checkError(t, err)
+		})
+	}
+}
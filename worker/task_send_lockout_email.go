@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"time"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendLockoutEmail = "task:send_lockout_email"
+
+// PayloadSendLockoutEmail names the user whose login was locked after too many wrong passwords.
+type PayloadSendLockoutEmail struct {
+	Username    string    `json:"username"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// OutboxTaskSendLockoutEmail returns the outbox message of the task, to be written within the transaction of the change it belongs to.
+func OutboxTaskSendLockoutEmail(payload *PayloadSendLockoutEmail, opts ...asynq.Option) (db.CreateOutboxMessageParams, error) {
+	return newOutboxMessage(TaskSendLockoutEmail, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendLockoutEmail(ctx context.Context, payload *PayloadSendLockoutEmail) error {
+	user, err := processor.store.GetUser(ctx, payload.Username)
+
+	if err != nil {
+		//? the user was deleted in the meantime, there is no one to warn
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Your Simple Bank login was locked"
+
+	content := fmt.Sprintf(`Hello %s,<br/>
+	There were too many failed attempts to log in to your account, so logins are locked until %s.<br/>
+	If this was you, you can try again after that time or reset your password.<br/>
+	If it was not, someone may be guessing your password. Your account is safe, but consider choosing a stronger one.<br/>
+	`, html.EscapeString(user.FullName), payload.LockedUntil.UTC().Format("2006-01-02 15:04 MST"))
+
+	to := []string{user.Email}
+
+	if err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to send lockout email: %w", err)
+	}
+
+	log.Info().
+		Str("username", user.Username).
+		Msg("processed task")
+
+	return nil
+}
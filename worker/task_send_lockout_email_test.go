@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessTaskSendLockoutEmail(t *testing.T) {
+	user := randomUser()
+	lockedUntil := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+
+	mailer := &fakeEmailSender{}
+	processor := &RedisTaskProcessor{store: store, mailer: mailer}
+
+	err := processor.ProcessTaskSendLockoutEmail(context.Background(), &PayloadSendLockoutEmail{
+		Username:    user.Username,
+		LockedUntil: lockedUntil,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, mailer.sent)
+	require.Equal(t, []string{user.Email}, mailer.to)
+	require.Contains(t, mailer.content, "2025-03-14 09:30 UTC")
+}
+
+func TestProcessTaskSendLockoutEmailUnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	username := util.RandomOwner()
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+
+	mailer := &fakeEmailSender{}
+	processor := &RedisTaskProcessor{store: store, mailer: mailer}
+
+	err := processor.ProcessTaskSendLockoutEmail(context.Background(), &PayloadSendLockoutEmail{Username: username})
+	require.NoError(t, err)
+	require.Zero(t, mailer.sent)
+}
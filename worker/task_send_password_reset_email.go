@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendPasswordResetEmail = "task:send_password_reset_email"
+
+// PayloadSendPasswordResetEmail names the address a reset was requested for.
+// The address may not belong to any user, which is only found out here so the request itself reveals nothing.
+type PayloadSendPasswordResetEmail struct {
+	Email string `json:"email"`
+}
+
+// OutboxTaskSendPasswordResetEmail returns the outbox message of the task, to be written within the transaction of the change it belongs to.
+func OutboxTaskSendPasswordResetEmail(payload *PayloadSendPasswordResetEmail, opts ...asynq.Option) (db.CreateOutboxMessageParams, error) {
+	return newOutboxMessage(TaskSendPasswordResetEmail, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendPasswordResetEmail(ctx context.Context, payload *PayloadSendPasswordResetEmail) error {
+	user, err := processor.store.GetUserByEmail(ctx, payload.Email)
+
+	if err != nil {
+		//? nobody has this address, there is no one to send a link to
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	token, err := util.RandomSecret(32)
+	if err != nil {
+		return err
+	}
+
+	//? only the hash is stored, so the link cannot be rebuilt from a database dump
+	_, err = processor.store.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		Username:  user.Username,
+		Email:     user.Email,
+		TokenHash: util.HashSecret(token),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	subject := "Reset your Simple Bank password"
+
+	resetURL := fmt.Sprintf("%s?token=%s", processor.passwordResetURL, url.QueryEscape(token))
+
+	content := fmt.Sprintf(`Hello %s,<br/>
+	We received a request to reset your password.<br/>
+	Please <a href="%s">click here</a> to choose a new one. The link expires in 15 minutes.<br/>
+	If you did not ask for this, you can ignore this email.<br/>
+	`, html.EscapeString(user.FullName), resetURL)
+
+	to := []string{user.Email}
+
+	if err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	log.Info().
+		Str("username", user.Username).
+		Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"regexp"
+	"testing"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testPasswordResetURL = "http://localhost:3000/reset_password"
+
+func TestProcessTaskSendPasswordResetEmail(t *testing.T) {
+	user := randomUser()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	var arg db.CreatePasswordResetParams
+
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+	store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, params db.CreatePasswordResetParams) (db.PasswordReset, error) {
+			arg = params
+			return db.PasswordReset{ID: 1, Username: params.Username, Email: params.Email, TokenHash: params.TokenHash}, nil
+		})
+
+	mailer := &fakeEmailSender{}
+	processor := &RedisTaskProcessor{store: store, mailer: mailer, passwordResetURL: testPasswordResetURL}
+
+	err := processor.ProcessTaskSendPasswordResetEmail(context.Background(), &PayloadSendPasswordResetEmail{Email: user.Email})
+	require.NoError(t, err)
+
+	require.Equal(t, user.Username, arg.Username)
+	require.Equal(t, user.Email, arg.Email)
+
+	require.Equal(t, 1, mailer.sent)
+	require.Equal(t, []string{user.Email}, mailer.to)
+
+	//? the emailed token is the one whose hash was stored, and the hash itself never leaves the server
+	match := regexp.MustCompile(regexp.QuoteMeta(testPasswordResetURL) + `\?token=([^"]+)`).FindStringSubmatch(mailer.content)
+	require.Len(t, match, 2)
+
+	token, err := url.QueryUnescape(match[1])
+	require.NoError(t, err)
+	require.Equal(t, util.HashSecret(token), arg.TokenHash)
+	require.NotContains(t, mailer.content, arg.TokenHash)
+}
+
+func TestProcessTaskSendPasswordResetEmailUnknownAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	email := util.RandomEmail()
+
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(email)).Times(1).Return(db.User{}, sql.ErrNoRows)
+	store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+
+	//? nothing is sent and the task is not retried
+	mailer := &fakeEmailSender{}
+	processor := &RedisTaskProcessor{store: store, mailer: mailer, passwordResetURL: testPasswordResetURL}
+
+	err := processor.ProcessTaskSendPasswordResetEmail(context.Background(), &PayloadSendPasswordResetEmail{Email: email})
+	require.NoError(t, err)
+	require.Zero(t, mailer.sent)
+}
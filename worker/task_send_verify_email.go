@@ -2,9 +2,12 @@ package worker
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"html"
+	"net/url"
 
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/util"
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
 )
@@ -15,52 +18,58 @@ type PayloadSendVerifyEmail struct {
 	Username string `json:"username"`
 }
 
-func (distributor *RedisTaskDistributor) DistributeTaskSendVerifyEmail(
-	ctx context.Context,
-	payload *PayloadSendVerifyEmail,
-	opts ...asynq.Option,
-) error {
+// OutboxTaskSendVerifyEmail returns the outbox message of the task, to be written within the transaction of the change it belongs to.
+func OutboxTaskSendVerifyEmail(payload *PayloadSendVerifyEmail, opts ...asynq.Option) (db.CreateOutboxMessageParams, error) {
+	return newOutboxMessage(TaskSendVerifyEmail, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail) error {
+	user, err := processor.store.GetUser(ctx, payload.Username)
 
-	// serialize payload struct into json (NewTask takes payload as []byte)
-	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal task payload: %w", err)
+		//? allow retries in case db transactions have not commited before task is processed
+		// if err == sql.ErrNoRows {
+		// 	return fmt.Errorf("user doesnt exist: %w", err)
+		// }
+		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	// create redis task
-	task := asynq.NewTask(TaskSendVerifyEmail, jsonPayload, opts...)
+	if user.IsEmailVerified {
+		return nil
+	}
+
+	secretCode, err := util.RandomSecret(32)
+	if err != nil {
+		return err
+	}
 
-	// send task to redis queue
-	info, err := distributor.client.EnqueueContext(ctx, task)
+	verifyEmail, err := processor.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: secretCode,
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to enqueue task into redis queue: %w", err)
+		return fmt.Errorf("failed to create verify email: %w", err)
 	}
 
-	// log task details
-	log.Info().
-		Str("type", task.Type()).
-		Bytes("payload", task.Payload()).
-		Str("queue", info.Queue).
-		Int("max_retry", info.MaxRetry).
-		Msg("enqueued task")
+	subject := "Welcome to Simple Bank"
 
-	return nil
+	// the link hits the VerifyEmail gateway route
+	verifyURL := fmt.Sprintf("%s?email_id=%d&secret_code=%s",
+		processor.verifyEmailURL, verifyEmail.ID, url.QueryEscape(verifyEmail.SecretCode))
 
-}
+	content := fmt.Sprintf(`Hello %s,<br/>
+	Thank you for registering with us!<br/>
+	Please <a href="%s">click here</a> to verify your email address.<br/>
+	`, html.EscapeString(user.FullName), verifyURL)
 
-func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail) error {
-	user, err := processor.store.GetUser(ctx, payload.Username)
+	to := []string{user.Email}
 
-	if err != nil {
-		//? allow retries in case db transactions have not commited before task is processed
-		// if err == sql.ErrNoRows {
-		// 	return fmt.Errorf("user doesnt exist: %w", err)
-		// }
-		return fmt.Errorf("failed to get user: %w", err)
+	if err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to send verify email: %w", err)
 	}
 
-	//TODO: send email to user
 	log.Info().
 		Str("username", payload.Username).
 		Str("email", user.Email).
@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/VihangaFTW/Go-Backend/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testVerifyEmailURL = "http://localhost:8080/v1/verify_email"
+
+// fakeEmailSender records the emails it is asked to send instead of delivering them.
+type fakeEmailSender struct {
+	subject string
+	content string
+	to      []string
+	sent    int
+	err     error
+}
+
+func (sender *fakeEmailSender) SendEmail(subject string, content string, to []string, cc []string, bcc []string, attachFiles []string) error {
+	sender.subject = subject
+	sender.content = content
+	sender.to = to
+	sender.sent++
+
+	return sender.err
+}
+
+func randomUser() db.User {
+	return db.User{
+		Username: util.RandomOwner(),
+		Email:    util.RandomEmail(),
+		FullName: util.RandomOwner(),
+	}
+}
+
+func TestProcessTaskSendVerifyEmail(t *testing.T) {
+	user := randomUser()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	var arg db.CreateVerifyEmailParams
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, params db.CreateVerifyEmailParams) (db.VerifyEmail, error) {
+			arg = params
+			return db.VerifyEmail{ID: 7, Username: params.Username, Email: params.Email, SecretCode: params.SecretCode}, nil
+		})
+
+	mailer := &fakeEmailSender{}
+	processor := &RedisTaskProcessor{store: store, mailer: mailer, verifyEmailURL: testVerifyEmailURL}
+
+	err := processor.ProcessTaskSendVerifyEmail(context.Background(), &PayloadSendVerifyEmail{Username: user.Username})
+	require.NoError(t, err)
+
+	require.Equal(t, user.Username, arg.Username)
+	require.Equal(t, user.Email, arg.Email)
+	require.GreaterOrEqual(t, len(arg.SecretCode), 32)
+
+	require.Equal(t, 1, mailer.sent)
+	require.Equal(t, []string{user.Email}, mailer.to)
+	require.NotEmpty(t, mailer.subject)
+	require.Contains(t, mailer.content, fmt.Sprintf("%s?email_id=7&secret_code=%s", testVerifyEmailURL, arg.SecretCode))
+}
+
+func TestProcessTaskSendVerifyEmailAlreadyVerified(t *testing.T) {
+	user := randomUser()
+	user.IsEmailVerified = true
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).Times(0)
+
+	mailer := &fakeEmailSender{}
+	processor := &RedisTaskProcessor{store: store, mailer: mailer, verifyEmailURL: testVerifyEmailURL}
+
+	err := processor.ProcessTaskSendVerifyEmail(context.Background(), &PayloadSendVerifyEmail{Username: user.Username})
+	require.NoError(t, err)
+	require.Zero(t, mailer.sent)
+}
+
+func TestProcessTaskSendVerifyEmailSendError(t *testing.T) {
+	user := randomUser()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).Times(1).Return(db.VerifyEmail{ID: 1}, nil)
+
+	//? a failed send is returned so that asynq retries the task
+	mailer := &fakeEmailSender{err: errors.New("smtp unavailable")}
+	processor := &RedisTaskProcessor{store: store, mailer: mailer, verifyEmailURL: testVerifyEmailURL}
+
+	err := processor.ProcessTaskSendVerifyEmail(context.Background(), &PayloadSendVerifyEmail{Username: user.Username})
+	require.ErrorIs(t, err, mailer.err)
+	require.Equal(t, 1, mailer.sent)
+}
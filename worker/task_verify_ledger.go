@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VihangaFTW/Go-Backend/ledger"
+	"github.com/rs/zerolog/log"
+)
+
+// TaskVerifyLedger is registered with the periodic scheduler and checks the balances and transfers against the entries.
+const TaskVerifyLedger = "task:verify_ledger"
+
+// ProcessTaskVerifyLedger verifies the ledger, logs every problem found and alerts the configured recipients by email.
+func (processor *RedisTaskProcessor) ProcessTaskVerifyLedger(ctx context.Context) error {
+	report, err := ledger.Verify(ctx, processor.store)
+	if err != nil {
+		return err
+	}
+
+	if report.OK() {
+		log.Info().
+			Int64("accounts_checked", report.AccountsChecked).
+			Int64("legacy_entries", report.LegacyEntries).
+			Msg("processed task")
+		return nil
+	}
+
+	for _, line := range ledger.Lines(report) {
+		log.Error().Msg(line)
+	}
+
+	log.Error().
+		Int64("accounts_checked", report.AccountsChecked).
+		Int("balance_drifts", len(report.BalanceDrifts)).
+		Int("held_balance_drifts", len(report.HeldBalanceDrifts)).
+		Int("unbalanced_transfers", len(report.UnbalancedTransfers)).
+		Int("orphan_entries", len(report.OrphanEntries)).
+		Int64("legacy_entries", report.LegacyEntries).
+		Msg("ledger check found problems")
+
+	//? the problems are already logged, so without recipients there is no one else to tell
+	if len(processor.ledgerAlertEmails) == 0 {
+		return nil
+	}
+
+	subject, content := ledger.AlertEmail(report)
+
+	if err = processor.mailer.SendEmail(subject, content, processor.ledgerAlertEmails, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to send ledger alert email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/VihangaFTW/Go-Backend/db/mock"
+	db "github.com/VihangaFTW/Go-Backend/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessTaskVerifyLedger(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   db.VerifyLedgerTxResult
+		alertTo  []string
+		wantSent int
+	}{
+		{
+			name:     "CleanLedger",
+			result:   db.VerifyLedgerTxResult{AccountsChecked: 2},
+			alertTo:  []string{"ops@example.com"},
+			wantSent: 0,
+		},
+		{
+			name:     "LegacyEntries",
+			result:   db.VerifyLedgerTxResult{AccountsChecked: 2, LegacyEntries: 5},
+			alertTo:  []string{"ops@example.com"},
+			wantSent: 0,
+		},
+		{
+			name: "Drift",
+			result: db.VerifyLedgerTxResult{
+				AccountsChecked: 2,
+				BalanceDrifts:   []db.ListBalanceDriftsRow{{AccountID: 1, Balance: 100, EntriesTotal: 0}},
+			},
+			alertTo:  []string{"ops@example.com"},
+			wantSent: 1,
+		},
+		{
+			name: "DriftWithoutRecipients",
+			result: db.VerifyLedgerTxResult{
+				AccountsChecked: 2,
+				OrphanEntries:   []db.Entry{{ID: 1, AccountID: 1, Amount: 100, TransferID: sql.NullInt64{Int64: 1, Valid: true}}},
+			},
+			wantSent: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+
+			store.EXPECT().VerifyLedgerTx(gomock.Any()).Times(1).Return(tc.result, nil)
+
+			mailer := &fakeEmailSender{}
+			processor := &RedisTaskProcessor{store: store, mailer: mailer, ledgerAlertEmails: tc.alertTo}
+
+			err := processor.ProcessTaskVerifyLedger(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.wantSent, mailer.sent)
+
+			if tc.wantSent > 0 {
+				require.Equal(t, tc.alertTo, mailer.to)
+				require.Contains(t, mailer.content, "account 1")
+			}
+		})
+	}
+}